@@ -0,0 +1,13 @@
+// +build !experimental
+
+package checkpoint
+
+import (
+	"github.com/docker/docker/api/client"
+	"github.com/spf13/cobra"
+)
+
+// NewCheckpointCommand returns no command
+func NewCheckpointCommand(dockerCli *client.DockerCli) *cobra.Command {
+	return &cobra.Command{}
+}
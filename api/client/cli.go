@@ -123,6 +123,42 @@ func (cli *DockerCli) ImagesFormat() string {
 	return cli.configFile.ImagesFormat
 }
 
+// StatsFormat returns the format string specified in the configuration for
+// `docker stats`.
+func (cli *DockerCli) StatsFormat() string {
+	return cli.configFile.StatsFormat
+}
+
+// NetworksFormat returns the format string specified in the configuration for
+// `docker network ls`.
+func (cli *DockerCli) NetworksFormat() string {
+	return cli.configFile.NetworksFormat
+}
+
+// VolumesFormat returns the format string specified in the configuration for
+// `docker volume ls`.
+func (cli *DockerCli) VolumesFormat() string {
+	return cli.configFile.VolumesFormat
+}
+
+// ServicesFormat returns the format string specified in the configuration for
+// `docker service ls`.
+func (cli *DockerCli) ServicesFormat() string {
+	return cli.configFile.ServicesFormat
+}
+
+// NodesFormat returns the format string specified in the configuration for
+// `docker node ls`.
+func (cli *DockerCli) NodesFormat() string {
+	return cli.configFile.NodesFormat
+}
+
+// PluginsFormat returns the format string specified in the configuration for
+// `docker plugin ls`.
+func (cli *DockerCli) PluginsFormat() string {
+	return cli.configFile.PluginsFormat
+}
+
 func (cli *DockerCli) setRawTerminal() error {
 	if cli.isTerminalIn && os.Getenv("NORAW") == "" {
 		state, err := term.SetRawTerminal(cli.inFd)
@@ -0,0 +1,215 @@
+// +build experimental
+
+package composefile
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func lookupEnvFrom(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func TestLoadFileSuccess(t *testing.T) {
+	reader := strings.NewReader(`
+version: "3"
+services:
+  web:
+    image: "nginx:${TAG:-latest}"
+    command: nginx -g "daemon off;"
+    environment:
+      - FOO=bar
+      - HOME
+    labels:
+      role: frontend
+    ports:
+      - "80:80"
+    networks:
+      front:
+        aliases: [www]
+    stop_grace_period: 1m30s
+    build: .
+    links: [db]
+    deploy:
+      replicas: 3
+      update_config:
+        parallelism: 2
+        delay: 10s
+      restart_policy:
+        condition: on-failure
+        max_attempts: 5
+networks:
+  front:
+    driver: overlay
+  outside:
+    external:
+      name: public
+volumes:
+  data:
+    external: true
+`)
+
+	config, err := LoadFile(reader, lookupEnvFrom(map[string]string{"TAG": "1.11"}))
+	assert.NilError(t, err)
+	assert.Equal(t, config.Version, "3")
+	assert.EqualStringSlice(t, config.Ignored, []string{"web.build", "web.links"})
+
+	web := config.Services["web"]
+	assert.Equal(t, web.Image, "nginx:1.11")
+	assert.EqualStringSlice(t, web.Command, []string{"nginx", "-g", "daemon off;"})
+	assert.EqualStringSlice(t, web.Environment.List(lookupEnvFrom(nil)), []string{"FOO=bar"})
+	assert.Equal(t, web.Labels.Map()["role"], "frontend")
+	assert.EqualStringSlice(t, web.Ports, []string{"80:80"})
+	assert.EqualStringSlice(t, web.Networks["front"].Aliases, []string{"www"})
+	assert.Equal(t, time.Duration(*web.StopGracePeriod), 90*time.Second)
+	assert.Equal(t, *web.Deploy.Replicas, uint64(3))
+	assert.Equal(t, web.Deploy.UpdateConfig.Parallelism, uint64(2))
+	assert.Equal(t, time.Duration(web.Deploy.UpdateConfig.Delay), 10*time.Second)
+	assert.Equal(t, web.Deploy.RestartPolicy.Condition, "on-failure")
+	assert.Equal(t, *web.Deploy.RestartPolicy.MaxAttempts, uint64(5))
+
+	assert.Equal(t, config.Networks["front"].Driver, "overlay")
+	assert.Equal(t, config.Networks["outside"].External.External, true)
+	assert.Equal(t, config.Networks["outside"].External.Name, "public")
+	assert.Equal(t, config.Volumes["data"].External.External, true)
+}
+
+func TestLoadFileServiceNetworksList(t *testing.T) {
+	reader := strings.NewReader(`
+version: "3.0"
+services:
+  db:
+    image: redis
+    networks: [back]
+`)
+
+	config, err := LoadFile(reader, lookupEnvFrom(nil))
+	assert.NilError(t, err)
+	_, ok := config.Services["db"].Networks["back"]
+	assert.Equal(t, ok, true)
+}
+
+func TestLoadFileInterpolateTypedValues(t *testing.T) {
+	reader := strings.NewReader(`
+version: "3"
+services:
+  web:
+    image: "nginx:${TAG}"
+    environment:
+      PORT: ${PORT}
+    deploy:
+      replicas: ${REPLICAS}
+      restart_policy:
+        max_attempts: ${ATTEMPTS:-3}
+volumes:
+  data:
+    external: ${EXTERNAL}
+`)
+
+	env := map[string]string{
+		"TAG":      "1.0",
+		"PORT":     "8080",
+		"REPLICAS": "4",
+		"EXTERNAL": "true",
+	}
+	config, err := LoadFile(reader, lookupEnvFrom(env))
+	assert.NilError(t, err)
+
+	web := config.Services["web"]
+	assert.Equal(t, web.Image, "nginx:1.0")
+	assert.Equal(t, web.Environment.Map()["PORT"], "8080")
+	assert.Equal(t, *web.Deploy.Replicas, uint64(4))
+	assert.Equal(t, *web.Deploy.RestartPolicy.MaxAttempts, uint64(3))
+	assert.Equal(t, config.Volumes["data"].External.External, true)
+}
+
+func TestToScalar(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected interface{}
+	}{
+		{"3", 3},
+		{"true", true},
+		{"1.5", 1.5},
+		{"1.0", "1.0"},
+		{"010", "010"},
+		{"latest", "latest"},
+		{"", ""},
+		{"null", "null"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, toScalar(c.value), c.expected)
+	}
+}
+
+func TestLoadFileUnsupportedVersion(t *testing.T) {
+	reader := strings.NewReader(`
+version: "2"
+services:
+  db:
+    image: redis
+`)
+
+	_, err := LoadFile(reader, lookupEnvFrom(nil))
+	assert.Error(t, err, "Unsupported compose file version \"2\"")
+}
+
+func TestLoadFileMissingImage(t *testing.T) {
+	reader := strings.NewReader(`
+version: "3"
+services:
+  db:
+    command: redis-server
+`)
+
+	_, err := LoadFile(reader, lookupEnvFrom(nil))
+	assert.Error(t, err, "Service db has no image")
+}
+
+func TestLoadFileUnknownOption(t *testing.T) {
+	reader := strings.NewReader(`
+version: "3"
+services:
+  db:
+    image: redis
+    imgae: redis:3
+`)
+
+	_, err := LoadFile(reader, lookupEnvFrom(nil))
+	assert.Error(t, err, "Service db has an unknown option \"imgae\"")
+}
+
+func TestInterpolate(t *testing.T) {
+	lookupEnv := lookupEnvFrom(map[string]string{"FOO": "foo", "EMPTY": ""})
+
+	for value, expected := range map[string]string{
+		"$FOO":              "foo",
+		"${FOO}bar":         "foobar",
+		"$FOO-bar":          "foo-bar",
+		"${UNSET}":          "",
+		"${UNSET:-default}": "default",
+		"${EMPTY:-default}": "default",
+		"${EMPTY-default}":  "",
+		"${UNSET-default}":  "default",
+		"$$FOO":             "$FOO",
+		"no variables":      "no variables",
+	} {
+		actual, err := interpolate(value, lookupEnv)
+		assert.NilError(t, err)
+		assert.Equal(t, actual, expected)
+	}
+}
+
+func TestInterpolateInvalid(t *testing.T) {
+	for _, value := range []string{"$", "${FOO", "${}", "$!"} {
+		_, err := interpolate(value, lookupEnvFrom(nil))
+		assert.Error(t, err, "invalid interpolation format")
+	}
+}
@@ -0,0 +1,97 @@
+// +build experimental
+
+package composefile
+
+import (
+	"fmt"
+	"strings"
+)
+
+// interpolate substitutes the variables of value with their value given by
+// lookupEnv. The supported forms are $VARIABLE, ${VARIABLE},
+// ${VARIABLE:-default}, which uses default if VARIABLE is unset or empty, and
+// ${VARIABLE-default}, which uses default only if VARIABLE is unset. A literal
+// $ is written $$.
+func interpolate(value string, lookupEnv func(string) (string, bool)) (string, error) {
+	var result []byte
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' {
+			result = append(result, value[i])
+			continue
+		}
+		if i+1 == len(value) {
+			return "", fmt.Errorf("invalid interpolation format for %q: trailing $", value)
+		}
+
+		switch next := value[i+1]; {
+		case next == '$':
+			result = append(result, '$')
+			i++
+		case next == '{':
+			end := strings.IndexByte(value[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("invalid interpolation format for %q: missing closing brace", value)
+			}
+			substituted, err := substitute(value[i+2:i+2+end], lookupEnv)
+			if err != nil {
+				return "", fmt.Errorf("invalid interpolation format for %q: %v", value, err)
+			}
+			result = append(result, substituted...)
+			i += 2 + end
+		case isNameStart(next):
+			end := i + 2
+			for end < len(value) && isNameChar(value[end]) {
+				end++
+			}
+			envValue, _ := lookupEnv(value[i+1 : end])
+			result = append(result, envValue...)
+			i = end - 1
+		default:
+			return "", fmt.Errorf("invalid interpolation format for %q: invalid character after $", value)
+		}
+	}
+	return string(result), nil
+}
+
+// substitute returns the value of the braced expression, without its braces
+func substitute(expression string, lookupEnv func(string) (string, bool)) (string, error) {
+	name, defaultValue, separator := expression, "", ""
+	for _, sep := range []string{":-", "-"} {
+		if i := strings.Index(expression, sep); i >= 0 {
+			name, defaultValue, separator = expression[:i], expression[i+len(sep):], sep
+			break
+		}
+	}
+	if !isName(name) {
+		return "", fmt.Errorf("invalid variable name %q", name)
+	}
+
+	value, ok := lookupEnv(name)
+	switch {
+	case separator == ":-" && value == "":
+		return defaultValue, nil
+	case separator == "-" && !ok:
+		return defaultValue, nil
+	}
+	return value, nil
+}
+
+func isName(name string) bool {
+	if name == "" || !isNameStart(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if !isNameChar(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || '0' <= c && c <= '9'
+}
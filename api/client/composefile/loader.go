@@ -0,0 +1,236 @@
+// +build experimental
+
+package composefile
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	supportedSections = map[string]bool{
+		"version":  true,
+		"services": true,
+		"networks": true,
+		"volumes":  true,
+	}
+
+	supportedServiceOptions = map[string]bool{
+		"image":             true,
+		"command":           true,
+		"entrypoint":        true,
+		"environment":       true,
+		"labels":            true,
+		"ports":             true,
+		"networks":          true,
+		"volumes":           true,
+		"working_dir":       true,
+		"user":              true,
+		"stop_grace_period": true,
+		"deploy":            true,
+	}
+
+	// unsupportedServiceOptions are the other options of the version 3
+	// schema. They are valid, but ignored when deploying to a swarm.
+	unsupportedServiceOptions = map[string]bool{
+		"build":          true,
+		"cap_add":        true,
+		"cap_drop":       true,
+		"cgroup_parent":  true,
+		"container_name": true,
+		"depends_on":     true,
+		"devices":        true,
+		"dns":            true,
+		"dns_search":     true,
+		"domainname":     true,
+		"env_file":       true,
+		"expose":         true,
+		"external_links": true,
+		"extra_hosts":    true,
+		"healthcheck":    true,
+		"hostname":       true,
+		"ipc":            true,
+		"links":          true,
+		"logging":        true,
+		"mac_address":    true,
+		"network_mode":   true,
+		"pid":            true,
+		"privileged":     true,
+		"read_only":      true,
+		"restart":        true,
+		"security_opt":   true,
+		"shm_size":       true,
+		"stdin_open":     true,
+		"stop_signal":    true,
+		"tmpfs":          true,
+		"tty":            true,
+		"ulimits":        true,
+		"userns_mode":    true,
+	}
+)
+
+// LoadFile loads a version 3 compose file. Variables in the values of the
+// file are substituted with their value given by lookupEnv.
+func LoadFile(reader io.Reader, lookupEnv func(string) (string, bool)) (*Config, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("Compose file is empty")
+	}
+
+	version, ok := raw["version"].(string)
+	if !ok {
+		return nil, fmt.Errorf("Compose file version must be a string such as \"3\"")
+	}
+	if version != "3" && !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("Unsupported compose file version %q, only version 3 is supported", version)
+	}
+	for section := range raw {
+		if !supportedSections[section] {
+			return nil, fmt.Errorf("Unsupported section %q in compose file", section)
+		}
+	}
+
+	ignored, err := removeUnsupportedOptions(raw["services"])
+	if err != nil {
+		return nil, err
+	}
+
+	interpolated, err := interpolateValues(raw, lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	data, err = yaml.Marshal(interpolated)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	config.Ignored = ignored
+
+	for name, service := range config.Services {
+		if service.Image == "" {
+			return nil, fmt.Errorf("Service %s has no image", name)
+		}
+	}
+	return config, nil
+}
+
+// removeUnsupportedOptions removes the options of the services which can not
+// be deployed to a swarm, and returns them sorted, as "service.option". An
+// option which is not in the version 3 schema is an error.
+func removeUnsupportedOptions(services interface{}) ([]string, error) {
+	if services == nil {
+		return nil, nil
+	}
+	serviceMap, ok := services.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Services must be a mapping")
+	}
+
+	var ignored []string
+	for name, service := range serviceMap {
+		options, ok := service.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Service %v must be a mapping", name)
+		}
+		for option := range options {
+			key, _ := option.(string)
+			if supportedServiceOptions[key] {
+				continue
+			}
+			if !unsupportedServiceOptions[key] {
+				return nil, fmt.Errorf("Service %v has an unknown option %q", name, fmt.Sprint(option))
+			}
+			ignored = append(ignored, fmt.Sprintf("%v.%v", name, option))
+			delete(options, option)
+		}
+	}
+	sort.Strings(ignored)
+	return ignored, nil
+}
+
+// interpolateValues returns a copy of value with the variables of every
+// string it contains substituted. Mapping keys are left as is. A substituted
+// string which is a plain scalar, such as a number or a boolean, is
+// converted to it, so that it has the same type as if it was written in the
+// file.
+func interpolateValues(value interface{}, lookupEnv func(string) (string, bool)) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		interpolated, err := interpolate(v, lookupEnv)
+		if err != nil {
+			return nil, err
+		}
+		if interpolated == v {
+			return v, nil
+		}
+		return toScalar(interpolated), nil
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			interpolated, err := interpolateValues(item, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = interpolated
+		}
+		return list, nil
+	case map[interface{}]interface{}:
+		mapping := make(map[interface{}]interface{}, len(v))
+		for key, item := range v {
+			interpolated, err := interpolateValues(item, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			mapping[key] = interpolated
+		}
+		return mapping, nil
+	case map[string]interface{}:
+		mapping := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			interpolated, err := interpolateValues(item, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			mapping[key] = interpolated
+		}
+		return mapping, nil
+	}
+	return value, nil
+}
+
+// toScalar returns the number or boolean written as s, or s itself if it is
+// not one, or if converting it would change how it is written, such as
+// "1.0".
+func toScalar(s string) interface{} {
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(s), &value); err != nil {
+		return s
+	}
+	switch value.(type) {
+	case int, int64, uint64, float64, bool:
+	default:
+		return s
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil || strings.TrimSpace(string(data)) != s {
+		return s
+	}
+	return value
+}
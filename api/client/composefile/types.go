@@ -0,0 +1,280 @@
+// +build experimental
+
+package composefile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-shellwords"
+)
+
+// Config is the configuration of a stack, loaded from a version 3 compose
+// file
+type Config struct {
+	Version  string
+	Services map[string]ServiceConfig
+	Networks map[string]NetworkConfig
+	Volumes  map[string]VolumeConfig
+
+	// Ignored lists the options of the compose file which are valid but
+	// not supported when deploying to a swarm, as "service.option".
+	Ignored []string `yaml:"-"`
+}
+
+// ServiceConfig is the configuration of a service
+type ServiceConfig struct {
+	Image           string
+	Command         ShellCommand
+	Entrypoint      ShellCommand
+	Environment     MappingWithEquals
+	Labels          MappingWithEquals
+	Ports           []string
+	Networks        ServiceNetworks
+	Volumes         []string
+	WorkingDir      string    `yaml:"working_dir"`
+	User            string
+	StopGracePeriod *Duration `yaml:"stop_grace_period"`
+	Deploy          DeployConfig
+}
+
+// DeployConfig is the deployment configuration of a service
+type DeployConfig struct {
+	Mode          string
+	Replicas      *uint64
+	Labels        MappingWithEquals
+	UpdateConfig  *UpdateConfig  `yaml:"update_config"`
+	Resources     Resources
+	RestartPolicy *RestartPolicy `yaml:"restart_policy"`
+	Placement     Placement
+}
+
+// UpdateConfig is the rolling update configuration of a service
+type UpdateConfig struct {
+	Parallelism uint64
+	Delay       Duration
+}
+
+// Resources are the resource limits and reservations of a service
+type Resources struct {
+	Limits       *Resource
+	Reservations *Resource
+}
+
+// Resource is an amount of resources. CPUs is a number of CPUs, which may be
+// fractional, and Memory an amount of memory with an optional unit.
+type Resource struct {
+	CPUs   string
+	Memory string
+}
+
+// RestartPolicy is the restart policy of the tasks of a service
+type RestartPolicy struct {
+	Condition   string
+	Delay       *Duration
+	MaxAttempts *uint64 `yaml:"max_attempts"`
+	Window      *Duration
+}
+
+// Placement holds the placement constraints of a service
+type Placement struct {
+	Constraints []string
+}
+
+// ServiceNetworks are the networks a service is attached to, by name
+type ServiceNetworks map[string]*ServiceNetworkConfig
+
+// ServiceNetworkConfig is the configuration of the attachment of a service
+// to a network
+type ServiceNetworkConfig struct {
+	Aliases []string
+}
+
+// NetworkConfig is the configuration of a network of the stack
+type NetworkConfig struct {
+	Driver     string
+	DriverOpts map[string]string `yaml:"driver_opts"`
+	Ipam       IPAMConfig
+	External   External
+	Internal   bool
+	Labels     MappingWithEquals
+}
+
+// IPAMConfig is the IPAM configuration of a network
+type IPAMConfig struct {
+	Driver string
+	Config []IPAMPool
+}
+
+// IPAMPool is an IPAM pool of a network
+type IPAMPool struct {
+	Subnet string
+}
+
+// VolumeConfig is the configuration of a named volume of the stack
+type VolumeConfig struct {
+	Driver     string
+	DriverOpts map[string]string `yaml:"driver_opts"`
+	External   External
+	Labels     MappingWithEquals
+}
+
+// External marks a network or a volume as created outside of the stack. Name
+// is the name of the external object, if it differs from its name in the
+// compose file.
+type External struct {
+	Name     string
+	External bool
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. External is either a boolean or
+// a mapping with the name of the external object.
+func (e *External) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var external bool
+	if err := unmarshal(&external); err == nil {
+		e.External = external
+		return nil
+	}
+	var named struct {
+		Name string
+	}
+	if err := unmarshal(&named); err != nil {
+		return err
+	}
+	e.Name = named.Name
+	e.External = true
+	return nil
+}
+
+// Duration is a duration, written as a string such as "1m30s"
+type Duration time.Duration
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// ShellCommand is a command, written either as a list of arguments or as a
+// string which is split like a shell would
+type ShellCommand []string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (c *ShellCommand) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*c = list
+		return nil
+	}
+	var command string
+	if err := unmarshal(&command); err != nil {
+		return err
+	}
+	words, err := shellwords.Parse(command)
+	if err != nil {
+		return err
+	}
+	*c = words
+	return nil
+}
+
+// MappingWithEquals is a mapping, written either as a mapping or as a list
+// of "key=value" strings. A key without a value is mapped to nil.
+type MappingWithEquals map[string]*string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (m *MappingWithEquals) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	mapping := make(map[string]*string)
+
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		for _, item := range list {
+			parts := strings.SplitN(item, "=", 2)
+			if len(parts) == 1 {
+				mapping[parts[0]] = nil
+				continue
+			}
+			value := parts[1]
+			mapping[parts[0]] = &value
+		}
+		*m = mapping
+		return nil
+	}
+
+	var values map[string]*string
+	if err := unmarshal(&values); err != nil {
+		return err
+	}
+	for key, value := range values {
+		mapping[key] = value
+	}
+	*m = mapping
+	return nil
+}
+
+// Map returns the mapping with the keys which have no value mapped to the
+// empty string.
+func (m MappingWithEquals) Map() map[string]string {
+	if m == nil {
+		return nil
+	}
+	mapping := make(map[string]string, len(m))
+	for key, value := range m {
+		if value != nil {
+			mapping[key] = *value
+		} else {
+			mapping[key] = ""
+		}
+	}
+	return mapping
+}
+
+// List returns the mapping as a sorted list of "key=value" strings. Keys
+// which have no value are resolved with lookupEnv, and left out if they are
+// not set.
+func (m MappingWithEquals) List(lookupEnv func(string) (string, bool)) []string {
+	var list []string
+	for key, value := range m {
+		if value != nil {
+			list = append(list, fmt.Sprintf("%s=%s", key, *value))
+			continue
+		}
+		if envValue, ok := lookupEnv(key); ok {
+			list = append(list, fmt.Sprintf("%s=%s", key, envValue))
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Service networks are written
+// either as a list of network names, or as a mapping of network names to
+// their configuration.
+func (n *ServiceNetworks) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	networks := make(map[string]*ServiceNetworkConfig)
+
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		for _, name := range list {
+			networks[name] = nil
+		}
+		*n = networks
+		return nil
+	}
+
+	if err := unmarshal(&networks); err != nil {
+		return err
+	}
+	*n = networks
+	return nil
+}
@@ -0,0 +1,10 @@
+// +build experimental
+
+package container
+
+import "github.com/spf13/pflag"
+
+func addCheckpointFlag(flags *pflag.FlagSet, opts *startOptions) {
+	flags.StringVar(&opts.checkpoint, "checkpoint", "", "Restore from this checkpoint")
+	flags.StringVar(&opts.checkpointDir, "checkpoint-dir", "", "Use a custom checkpoint storage directory")
+}
@@ -0,0 +1,8 @@
+// +build !experimental
+
+package container
+
+import "github.com/spf13/pflag"
+
+func addCheckpointFlag(flags *pflag.FlagSet, opts *startOptions) {
+}
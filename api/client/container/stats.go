@@ -5,14 +5,13 @@ import (
 	"io"
 	"strings"
 	"sync"
-	"text/tabwriter"
 	"time"
 
 	"golang.org/x/net/context"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/client"
-	"github.com/docker/docker/api/client/system"
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/cli"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/engine-api/types/events"
@@ -23,6 +22,7 @@ import (
 type statsOptions struct {
 	all      bool
 	noStream bool
+	format   string
 
 	containers []string
 }
@@ -44,6 +44,7 @@ func NewStatsCommand(dockerCli *client.DockerCli) *cobra.Command {
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.all, "all", "a", false, "Show all containers (default shows just running)")
 	flags.BoolVar(&opts.noStream, "no-stream", false, "Disable streaming stats and only pull the first result")
+	flags.StringVar(&opts.format, "format", "", "Pretty-print stats using a Go template")
 	return cmd
 }
 
@@ -73,7 +74,7 @@ func runStats(dockerCli *client.DockerCli, opts *statsOptions) error {
 		}
 		defer resBody.Close()
 
-		system.DecodeEvents(resBody, func(event events.Message, err error) error {
+		client.DecodeEvents(resBody, func(event events.Message, err error) error {
 			if err != nil {
 				closeChan <- err
 				return nil
@@ -112,7 +113,7 @@ func runStats(dockerCli *client.DockerCli, opts *statsOptions) error {
 		// retrieving the list of running containers to avoid a race where we
 		// would "miss" a creation.
 		started := make(chan struct{})
-		eh := system.InitEventHandler()
+		eh := client.InitEventHandler()
 		eh.Handle("create", func(e events.Message) {
 			if opts.all {
 				s := &containerStats{Name: e.ID[:12]}
@@ -181,25 +182,36 @@ func runStats(dockerCli *client.DockerCli, opts *statsOptions) error {
 	// before print to screen, make sure each container get at least one valid stat data
 	waitFirst.Wait()
 
-	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
-	printHeader := func() {
-		if !opts.noStream {
-			fmt.Fprint(dockerCli.Out(), "\033[2J")
-			fmt.Fprint(dockerCli.Out(), "\033[H")
+	f := opts.format
+	if len(f) == 0 {
+		if len(dockerCli.StatsFormat()) > 0 {
+			f = dockerCli.StatsFormat()
+		} else {
+			f = "table"
 		}
-		io.WriteString(w, "CONTAINER\tCPU %\tMEM USAGE / LIMIT\tMEM %\tNET I/O\tBLOCK I/O\tPIDS\n")
 	}
 
 	for range time.Tick(500 * time.Millisecond) {
-		printHeader()
+		if !opts.noStream {
+			fmt.Fprint(dockerCli.Out(), "\033[2J")
+			fmt.Fprint(dockerCli.Out(), "\033[H")
+		}
+		statsCtx := formatter.StatsContext{
+			Context: formatter.Context{
+				Output: dockerCli.Out(),
+				Format: f,
+			},
+		}
 		cStats.mu.Lock()
 		for _, s := range cStats.cs {
-			if err := s.Display(w); err != nil && !opts.noStream {
+			cs, err := s.snapshot()
+			if err != nil && !opts.noStream {
 				logrus.Debugf("stats: got error for %s: %v", s.Name, err)
 			}
+			statsCtx.Stats = append(statsCtx.Stats, cs)
 		}
 		cStats.mu.Unlock()
-		w.Flush()
+		statsCtx.Write()
 		if opts.noStream {
 			break
 		}
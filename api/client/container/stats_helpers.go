@@ -3,16 +3,15 @@ package container
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/engine-api/client"
 	"github.com/docker/engine-api/types"
-	"github.com/docker/go-units"
 	"golang.org/x/net/context"
 )
 
@@ -171,29 +170,26 @@ func (s *containerStats) Collect(ctx context.Context, cli client.APIClient, stre
 	}
 }
 
-func (s *containerStats) Display(w io.Writer) error {
+// snapshot returns the last stats collected for the container, and the error
+// which prevented collecting them, if any.
+func (s *containerStats) snapshot() (formatter.ContainerStats, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	// NOTE: if you change this format, you must also change the err format below!
-	format := "%s\t%.2f%%\t%s / %s\t%.2f%%\t%s / %s\t%s / %s\t%d\n"
 	if s.err != nil {
-		format = "%s\t%s\t%s / %s\t%s\t%s / %s\t%s / %s\t%s\n"
-		errStr := "--"
-		fmt.Fprintf(w, format,
-			s.Name, errStr, errStr, errStr, errStr, errStr, errStr, errStr, errStr, errStr,
-		)
-		err := s.err
-		return err
+		return formatter.ContainerStats{Name: s.Name, IsInvalid: true}, s.err
 	}
-	fmt.Fprintf(w, format,
-		s.Name,
-		s.CPUPercentage,
-		units.BytesSize(s.Memory), units.BytesSize(s.MemoryLimit),
-		s.MemoryPercentage,
-		units.HumanSize(s.NetworkRx), units.HumanSize(s.NetworkTx),
-		units.HumanSize(s.BlockRead), units.HumanSize(s.BlockWrite),
-		s.PidsCurrent)
-	return nil
+	return formatter.ContainerStats{
+		Name:             s.Name,
+		CPUPercentage:    s.CPUPercentage,
+		Memory:           s.Memory,
+		MemoryLimit:      s.MemoryLimit,
+		MemoryPercentage: s.MemoryPercentage,
+		NetworkRx:        s.NetworkRx,
+		NetworkTx:        s.NetworkTx,
+		BlockRead:        s.BlockRead,
+		BlockWrite:       s.BlockWrite,
+		PidsCurrent:      s.PidsCurrent,
+	}, nil
 }
 
 func calculateCPUPercent(previousCPU, previousSystem uint64, v *types.StatsJSON) float64 {
@@ -1,33 +1,34 @@
 package container
 
 import (
-	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/docker/engine-api/types"
 )
 
-func TestDisplay(t *testing.T) {
+func TestSnapshot(t *testing.T) {
 	c := &containerStats{
-		Name:             "app",
-		CPUPercentage:    30.0,
-		Memory:           100 * 1024 * 1024.0,
-		MemoryLimit:      2048 * 1024 * 1024.0,
-		MemoryPercentage: 100.0 / 2048.0 * 100.0,
-		NetworkRx:        100 * 1024 * 1024,
-		NetworkTx:        800 * 1024 * 1024,
-		BlockRead:        100 * 1024 * 1024,
-		BlockWrite:       800 * 1024 * 1024,
-		PidsCurrent:      1,
-	}
-	var b bytes.Buffer
-	if err := c.Display(&b); err != nil {
-		t.Fatalf("c.Display() gave error: %s", err)
-	}
-	got := b.String()
-	want := "app\t30.00%\t100 MiB / 2 GiB\t4.88%\t104.9 MB / 838.9 MB\t104.9 MB / 838.9 MB\t1\n"
-	if got != want {
-		t.Fatalf("c.Display() = %q, want %q", got, want)
+		Name:          "app",
+		CPUPercentage: 30.0,
+		Memory:        100 * 1024 * 1024.0,
+		PidsCurrent:   1,
+	}
+	cs, err := c.snapshot()
+	if err != nil {
+		t.Fatalf("c.snapshot() gave error: %s", err)
+	}
+	if cs.Name != "app" || cs.CPUPercentage != 30.0 || cs.Memory != 100*1024*1024.0 || cs.PidsCurrent != 1 || cs.IsInvalid {
+		t.Fatalf("c.snapshot() = %+v", cs)
+	}
+
+	c.err = errors.New("timeout waiting for stats")
+	cs, err = c.snapshot()
+	if err == nil {
+		t.Fatal("c.snapshot() should have returned the collection error")
+	}
+	if !cs.IsInvalid || cs.Name != "app" {
+		t.Fatalf("c.snapshot() = %+v, want invalid stats", cs)
 	}
 }
 
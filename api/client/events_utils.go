@@ -1,4 +1,4 @@
-package system
+package client
 
 import (
 	"encoding/json"
@@ -48,6 +48,8 @@ func (w *eventHandler) Watch(c <-chan eventtypes.Message) {
 	}
 }
 
+type eventProcessor func(event eventtypes.Message, err error) error
+
 // DecodeEvents decodes event from input stream
 func DecodeEvents(input io.Reader, ep eventProcessor) error {
 	dec := json.NewDecoder(input)
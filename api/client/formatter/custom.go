@@ -119,23 +119,11 @@ func (c *containerContext) Size() string {
 
 func (c *containerContext) Labels() string {
 	c.addHeader(labelsHeader)
-	if c.c.Labels == nil {
-		return ""
-	}
-
-	var joinLabels []string
-	for k, v := range c.c.Labels {
-		joinLabels = append(joinLabels, fmt.Sprintf("%s=%s", k, v))
-	}
-	return strings.Join(joinLabels, ",")
+	return joinLabels(c.c.Labels)
 }
 
 func (c *containerContext) Label(name string) string {
-	n := strings.Split(name, ".")
-	r := strings.NewReplacer("-", " ", "_", " ")
-	h := r.Replace(n[len(n)-1])
-
-	c.addHeader(h)
+	c.addHeader(labelHeader(name))
 
 	if c.c.Labels == nil {
 		return ""
@@ -233,6 +221,27 @@ func (c *baseSubContext) addHeader(header string) {
 	c.header = append(c.header, strings.ToUpper(header))
 }
 
+// joinLabels returns labels as a comma-separated list of key=value pairs.
+func joinLabels(labels map[string]string) string {
+	if labels == nil {
+		return ""
+	}
+
+	var joinLabels []string
+	for k, v := range labels {
+		joinLabels = append(joinLabels, fmt.Sprintf("%s=%s", k, v))
+	}
+	return strings.Join(joinLabels, ",")
+}
+
+// labelHeader returns the header of the column of a label, made of the last
+// component of the name of the label.
+func labelHeader(name string) string {
+	n := strings.Split(name, ".")
+	r := strings.NewReplacer("-", " ", "_", " ")
+	return r.Replace(n[len(n)-1])
+}
+
 func stripNamePrefix(ss []string) []string {
 	sss := make([]string, len(ss))
 	for i, s := range ss {
@@ -94,6 +94,27 @@ func (c *Context) contextFormat(tmpl *template.Template, subContext subContext)
 	return nil
 }
 
+// write formats each of the sub-contexts with the format of the context and
+// writes the result to the output. empty is used to build the header of a
+// table without rows.
+func (c *Context) write(subContexts []subContext, empty subContext) {
+	c.buffer = bytes.NewBufferString("")
+	c.preformat()
+
+	tmpl, err := c.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, subContext := range subContexts {
+		if err := c.contextFormat(tmpl, subContext); err != nil {
+			return
+		}
+	}
+
+	c.postformat(tmpl, empty)
+}
+
 // ContainerContext contains container specific information required by the formater, encapsulate a Context struct.
 type ContainerContext struct {
 	Context
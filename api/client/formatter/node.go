@@ -0,0 +1,124 @@
+package formatter
+
+import (
+	"github.com/docker/docker/api/client"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/engine-api/types/swarm"
+)
+
+const (
+	defaultNodeTableFormat = "table {{.ID}}{{if .Self}} *{{end}}\t{{.Name}}\t{{.Membership}}\t{{.Status}}\t{{.Availability}}\t{{.ManagerStatus}}"
+
+	hostnameHeader      = "HOSTNAME"
+	membershipHeader    = "MEMBERSHIP"
+	availabilityHeader  = "AVAILABILITY"
+	managerStatusHeader = "MANAGER STATUS"
+)
+
+// NodeContext contains node specific information required by the formatter, encapsulate a Context struct.
+type NodeContext struct {
+	Context
+	// Nodes
+	Nodes []swarm.Node
+	// Info is the information of the daemon the nodes were listed from, used
+	// to tell which of the nodes is the current one.
+	Info types.Info
+}
+
+func (ctx NodeContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		if ctx.Quiet {
+			ctx.Format = defaultQuietFormat
+		} else {
+			ctx.Format = defaultNodeTableFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `node_id: {{.ID}}`
+		} else {
+			ctx.Format = `node_id: {{.ID}}\nhostname: {{.Hostname}}\nmembership: {{.Membership}}\nstatus: {{.Status}}\navailability: {{.Availability}}\nmanager_status: {{.ManagerStatus}}\n`
+		}
+	}
+
+	var nodes []subContext
+	for _, node := range ctx.Nodes {
+		nodes = append(nodes, &nodeContext{
+			n:    node,
+			self: node.ID == ctx.Info.Swarm.NodeID,
+		})
+	}
+	ctx.write(nodes, &nodeContext{})
+}
+
+type nodeContext struct {
+	baseSubContext
+	n    swarm.Node
+	self bool
+}
+
+func (c *nodeContext) ID() string {
+	c.addHeader(idHeader)
+	return c.n.ID
+}
+
+// Self returns whether the node is the one the daemon runs on. It is meant
+// to be used in conditions, and doesn't add a column to the header.
+func (c *nodeContext) Self() bool {
+	return c.self
+}
+
+func (c *nodeContext) Name() string {
+	c.addHeader(nameHeader)
+	if c.n.Spec.Name == "" {
+		return c.n.Description.Hostname
+	}
+	return c.n.Spec.Name
+}
+
+func (c *nodeContext) Hostname() string {
+	c.addHeader(hostnameHeader)
+	return c.n.Description.Hostname
+}
+
+func (c *nodeContext) Membership() string {
+	c.addHeader(membershipHeader)
+	return client.PrettyPrint(string(c.n.Spec.Membership))
+}
+
+func (c *nodeContext) Status() string {
+	c.addHeader(statusHeader)
+	return client.PrettyPrint(string(c.n.Status.State))
+}
+
+func (c *nodeContext) Availability() string {
+	c.addHeader(availabilityHeader)
+	return client.PrettyPrint(string(c.n.Spec.Availability))
+}
+
+func (c *nodeContext) ManagerStatus() string {
+	c.addHeader(managerStatusHeader)
+	reachability := ""
+	if c.n.ManagerStatus != nil {
+		if c.n.ManagerStatus.Leader {
+			reachability = "Leader"
+		} else {
+			reachability = string(c.n.ManagerStatus.Reachability)
+		}
+	}
+	return client.PrettyPrint(reachability)
+}
+
+func (c *nodeContext) Labels() string {
+	c.addHeader(labelsHeader)
+	return joinLabels(c.n.Spec.Labels)
+}
+
+func (c *nodeContext) Label(name string) string {
+	c.addHeader(labelHeader(name))
+
+	if c.n.Spec.Labels == nil {
+		return ""
+	}
+	return c.n.Spec.Labels[name]
+}
@@ -0,0 +1,84 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/engine-api/types"
+	"github.com/docker/engine-api/types/swarm"
+)
+
+func TestNodeContextWrite(t *testing.T) {
+	contexts := []struct {
+		context  NodeContext
+		expected string
+	}{
+		// Table format
+		{
+			NodeContext{
+				Context: Context{
+					Format: "table",
+				},
+			},
+			`ID                  NAME                MEMBERSHIP          STATUS              AVAILABILITY        MANAGER STATUS
+nodeID1 *           manager1            Accepted            Ready               Active              Leader
+nodeID2             worker1             Accepted            Down                Drain               
+`,
+		},
+		{
+			NodeContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  true,
+				},
+			},
+			"nodeID1\nnodeID2\n",
+		},
+		// Raw Format
+		{
+			NodeContext{
+				Context: Context{
+					Format: "raw",
+					Quiet:  true,
+				},
+			},
+			"node_id: nodeID1\nnode_id: nodeID2\n",
+		},
+		// Custom Format
+		{
+			NodeContext{
+				Context: Context{
+					Format: "{{.Hostname}}{{if .Self}} (self){{end}}: {{.Status}}",
+				},
+			},
+			"host1 (self): Ready\nworker1: Down\n",
+		},
+	}
+
+	for _, context := range contexts {
+		nodes := []swarm.Node{
+			{
+				ID:            "nodeID1",
+				Spec:          swarm.NodeSpec{Annotations: swarm.Annotations{Name: "manager1"}, Membership: swarm.NodeMembershipAccepted, Availability: swarm.NodeAvailabilityActive},
+				Description:   swarm.NodeDescription{Hostname: "host1"},
+				Status:        swarm.NodeStatus{State: swarm.NodeStateReady},
+				ManagerStatus: &swarm.ManagerStatus{Leader: true},
+			},
+			{
+				ID:          "nodeID2",
+				Spec:        swarm.NodeSpec{Membership: swarm.NodeMembershipAccepted, Availability: swarm.NodeAvailabilityDrain},
+				Description: swarm.NodeDescription{Hostname: "worker1"},
+				Status:      swarm.NodeStatus{State: swarm.NodeStateDown},
+			},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Nodes = nodes
+		context.context.Info = types.Info{Swarm: swarm.Info{NodeID: "nodeID1"}}
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+}
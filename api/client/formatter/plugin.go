@@ -0,0 +1,85 @@
+// +build experimental
+
+package formatter
+
+import (
+	"strconv"
+
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/engine-api/types"
+)
+
+const (
+	defaultPluginTableFormat = "table {{.Name}}\t{{.Tag}}\t{{.Active}}"
+
+	pluginIDHeader    = "PLUGIN ID"
+	descriptionHeader = "DESCRIPTION"
+	activeHeader      = "ACTIVE"
+)
+
+// PluginContext contains plugin specific information required by the formatter, encapsulate a Context struct.
+type PluginContext struct {
+	Context
+	// Plugins
+	Plugins []*types.Plugin
+}
+
+func (ctx PluginContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		if ctx.Quiet {
+			ctx.Format = defaultQuietFormat
+		} else {
+			ctx.Format = defaultPluginTableFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `plugin_id: {{.ID}}`
+		} else {
+			ctx.Format = `plugin_id: {{.ID}}\nname: {{.Name}}\ntag: {{.Tag}}\nactive: {{.Active}}\ndescription: {{.Description}}\n`
+		}
+	}
+
+	var plugins []subContext
+	for _, plugin := range ctx.Plugins {
+		plugins = append(plugins, &pluginContext{
+			trunc: ctx.Trunc,
+			p:     plugin,
+		})
+	}
+	ctx.write(plugins, &pluginContext{p: &types.Plugin{}})
+}
+
+type pluginContext struct {
+	baseSubContext
+	trunc bool
+	p     *types.Plugin
+}
+
+func (c *pluginContext) ID() string {
+	c.addHeader(pluginIDHeader)
+	if c.trunc {
+		return stringid.TruncateID(c.p.ID)
+	}
+	return c.p.ID
+}
+
+func (c *pluginContext) Name() string {
+	c.addHeader(nameHeader)
+	return c.p.Name
+}
+
+func (c *pluginContext) Tag() string {
+	c.addHeader(tagHeader)
+	return c.p.Tag
+}
+
+func (c *pluginContext) Active() string {
+	c.addHeader(activeHeader)
+	return strconv.FormatBool(c.p.Active)
+}
+
+func (c *pluginContext) Description() string {
+	c.addHeader(descriptionHeader)
+	return c.p.Manifest.Description
+}
@@ -0,0 +1,146 @@
+// +build experimental
+
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/engine-api/types"
+)
+
+func TestPluginContextWrite(t *testing.T) {
+	contexts := []struct {
+		context  PluginContext
+		expected string
+	}{
+		// Errors
+		{
+			PluginContext{
+				Context: Context{
+					Format: "{{InvalidFunction}}",
+				},
+			},
+			`Template parsing error: template: :1: function "InvalidFunction" not defined
+`,
+		},
+		// Table format
+		{
+			PluginContext{
+				Context: Context{
+					Format: "table",
+				},
+			},
+			`NAME                TAG                 ACTIVE
+foobar_baz          latest              true
+foobar_bar          1.0                 false
+`,
+		},
+		{
+			PluginContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  true,
+				},
+			},
+			"pluginid1\npluginid2\n",
+		},
+		{
+			PluginContext{
+				Context: Context{
+					Format: "table {{.Name}}\t{{.Description}}",
+				},
+			},
+			`NAME                DESCRIPTION
+foobar_baz          description 1
+foobar_bar          description 2
+`,
+		},
+		// Raw Format
+		{
+			PluginContext{
+				Context: Context{
+					Format: "raw",
+				},
+			},
+			`plugin_id: pluginid1
+name: foobar_baz
+tag: latest
+active: true
+description: description 1
+
+plugin_id: pluginid2
+name: foobar_bar
+tag: 1.0
+active: false
+description: description 2
+
+`,
+		},
+		{
+			PluginContext{
+				Context: Context{
+					Format: "raw",
+					Quiet:  true,
+				},
+			},
+			"plugin_id: pluginid1\nplugin_id: pluginid2\n",
+		},
+		// Custom Format
+		{
+			PluginContext{
+				Context: Context{
+					Format: "{{.Name}}:{{.Tag}}",
+				},
+			},
+			"foobar_baz:latest\nfoobar_bar:1.0\n",
+		},
+	}
+
+	for _, context := range contexts {
+		plugins := []*types.Plugin{
+			{ID: "pluginid1", Name: "foobar_baz", Tag: "latest", Active: true, Manifest: types.PluginManifest{Description: "description 1"}},
+			{ID: "pluginid2", Name: "foobar_bar", Tag: "1.0", Manifest: types.PluginManifest{Description: "description 2"}},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Plugins = plugins
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+}
+
+func TestPluginContextWriteTrunc(t *testing.T) {
+	out := bytes.NewBufferString("")
+	ctx := PluginContext{
+		Context: Context{
+			Format: "{{.ID}}",
+			Output: out,
+			Trunc:  true,
+		},
+		Plugins: []*types.Plugin{
+			{ID: "4f4c1b7bdb02d6c1d7b3cb59a5c3e8e1fd4fd4e30d2ab5d1a3f1cbf3d4d4ed5a", Name: "foobar_baz"},
+		},
+	}
+	ctx.Write()
+	if actual, expected := out.String(), "4f4c1b7bdb02\n"; actual != expected {
+		t.Fatalf("Expected \n%s, got \n%s", expected, actual)
+	}
+}
+
+func TestPluginContextWriteWithNoPlugins(t *testing.T) {
+	out := bytes.NewBufferString("")
+	ctx := PluginContext{
+		Context: Context{
+			Format: "table {{.Name}}\t{{.Tag}}",
+			Output: out,
+		},
+	}
+	ctx.Write()
+	if actual, expected := out.String(), "NAME                TAG\n"; actual != expected {
+		t.Fatalf("Expected \n%s, got \n%s", expected, actual)
+	}
+}
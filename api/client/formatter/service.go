@@ -0,0 +1,118 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/engine-api/types/swarm"
+)
+
+const (
+	defaultServiceTableFormat = "table {{.ID}}\t{{.Name}}\t{{.Replicas}}\t{{.Image}}\t{{.Command}}"
+
+	idHeader       = "ID"
+	modeHeader     = "MODE"
+	replicasHeader = "REPLICAS"
+)
+
+// ServiceContext contains service specific information required by the formatter, encapsulate a Context struct.
+type ServiceContext struct {
+	Context
+	// Services
+	Services []swarm.Service
+	// Running is the number of running tasks of each service, by service ID.
+	Running map[string]int
+}
+
+func (ctx ServiceContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		if ctx.Quiet {
+			ctx.Format = defaultQuietFormat
+		} else {
+			ctx.Format = defaultServiceTableFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `id: {{.ID}}`
+		} else {
+			ctx.Format = `id: {{.ID}}\nname: {{.Name}}\nmode: {{.Mode}}\nreplicas: {{.Replicas}}\nimage: {{.Image}}\ncommand: {{.Command}}\n`
+		}
+	}
+
+	var services []subContext
+	for _, service := range ctx.Services {
+		services = append(services, &serviceContext{
+			trunc:   ctx.Trunc,
+			s:       service,
+			running: ctx.Running[service.ID],
+		})
+	}
+	ctx.write(services, &serviceContext{})
+}
+
+type serviceContext struct {
+	baseSubContext
+	trunc   bool
+	s       swarm.Service
+	running int
+}
+
+func (c *serviceContext) ID() string {
+	c.addHeader(idHeader)
+	if c.trunc {
+		return stringid.TruncateID(c.s.ID)
+	}
+	return c.s.ID
+}
+
+func (c *serviceContext) Name() string {
+	c.addHeader(nameHeader)
+	return c.s.Spec.Name
+}
+
+func (c *serviceContext) Mode() string {
+	c.addHeader(modeHeader)
+	switch {
+	case c.s.Spec.Mode.Replicated != nil:
+		return "replicated"
+	case c.s.Spec.Mode.Global != nil:
+		return "global"
+	}
+	return ""
+}
+
+func (c *serviceContext) Replicas() string {
+	c.addHeader(replicasHeader)
+	if c.s.Spec.Mode.Replicated != nil && c.s.Spec.Mode.Replicated.Replicas != nil {
+		return fmt.Sprintf("%d/%d", c.running, *c.s.Spec.Mode.Replicated.Replicas)
+	} else if c.s.Spec.Mode.Global != nil {
+		return "global"
+	}
+	return ""
+}
+
+func (c *serviceContext) Image() string {
+	c.addHeader(imageHeader)
+	return c.s.Spec.TaskTemplate.ContainerSpec.Image
+}
+
+func (c *serviceContext) Command() string {
+	c.addHeader(commandHeader)
+	return strings.Join(c.s.Spec.TaskTemplate.ContainerSpec.Args, " ")
+}
+
+func (c *serviceContext) Labels() string {
+	c.addHeader(labelsHeader)
+	return joinLabels(c.s.Spec.Labels)
+}
+
+func (c *serviceContext) Label(name string) string {
+	c.addHeader(labelHeader(name))
+
+	if c.s.Spec.Labels == nil {
+		return ""
+	}
+	return c.s.Spec.Labels[name]
+}
@@ -0,0 +1,101 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/engine-api/types/swarm"
+)
+
+func TestServiceContextWrite(t *testing.T) {
+	contexts := []struct {
+		context  ServiceContext
+		expected string
+	}{
+		// Table format
+		{
+			ServiceContext{
+				Context: Context{
+					Format: "table",
+					Trunc:  true,
+				},
+			},
+			`ID                  NAME                REPLICAS            IMAGE               COMMAND
+0123456789ab        web                 2/3                 nginx               
+abcdef012345        agent               global              busybox             top
+`,
+		},
+		{
+			ServiceContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  true,
+				},
+			},
+			"0123456789abcdef\nabcdef0123456789\n",
+		},
+		// Raw Format
+		{
+			ServiceContext{
+				Context: Context{
+					Format: "raw",
+				},
+			},
+			`id: 0123456789abcdef
+name: web
+mode: replicated
+replicas: 2/3
+image: nginx
+command: 
+
+id: abcdef0123456789
+name: agent
+mode: global
+replicas: global
+image: busybox
+command: top
+
+`,
+		},
+		// Custom Format
+		{
+			ServiceContext{
+				Context: Context{
+					Format: "{{.Name}}: {{.Replicas}}",
+				},
+			},
+			"web: 2/3\nagent: global\n",
+		},
+	}
+
+	replicas := uint64(3)
+	for _, context := range contexts {
+		services := []swarm.Service{
+			{
+				ID: "0123456789abcdef",
+				Spec: swarm.ServiceSpec{
+					Annotations:  swarm.Annotations{Name: "web"},
+					TaskTemplate: swarm.TaskSpec{ContainerSpec: swarm.ContainerSpec{Image: "nginx"}},
+					Mode:         swarm.ServiceMode{Replicated: &swarm.ReplicatedService{Replicas: &replicas}},
+				},
+			},
+			{
+				ID: "abcdef0123456789",
+				Spec: swarm.ServiceSpec{
+					Annotations:  swarm.Annotations{Name: "agent"},
+					TaskTemplate: swarm.TaskSpec{ContainerSpec: swarm.ContainerSpec{Image: "busybox", Args: []string{"top"}}},
+					Mode:         swarm.ServiceMode{Global: &swarm.GlobalService{}},
+				},
+			},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Services = services
+		context.context.Running = map[string]int{"0123456789abcdef": 2}
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package formatter
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/docker/go-units"
+)
+
+const (
+	defaultStatsTableFormat = "table {{.Container}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDs}}"
+
+	containerHeader = "CONTAINER"
+	cpuPercHeader   = "CPU %"
+	memUsageHeader  = "MEM USAGE / LIMIT"
+	memPercHeader   = "MEM %"
+	netIOHeader     = "NET I/O"
+	blockIOHeader   = "BLOCK I/O"
+	pidsHeader      = "PIDS"
+
+	// statsUnavailable is displayed in place of the stats of a container
+	// which could not be collected.
+	statsUnavailable = "--"
+)
+
+// ContainerStats holds the resource usage statistics of a container, as
+// displayed by docker stats.
+type ContainerStats struct {
+	Name             string
+	CPUPercentage    float64
+	Memory           float64
+	MemoryLimit      float64
+	MemoryPercentage float64
+	NetworkRx        float64
+	NetworkTx        float64
+	BlockRead        float64
+	BlockWrite       float64
+	PidsCurrent      uint64
+	// IsInvalid is set when the stats of the container could not be
+	// collected.
+	IsInvalid bool
+}
+
+// StatsContext contains container stats specific information required by the formatter, encapsulate a Context struct.
+type StatsContext struct {
+	Context
+	// Stats
+	Stats []ContainerStats
+}
+
+func (ctx StatsContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		ctx.Format = defaultStatsTableFormat
+	case rawFormatKey:
+		ctx.Format = `container: {{.Container}}\ncpu: {{.CPUPerc}}\nmemory_usage: {{.MemUsage}}\nmemory: {{.MemPerc}}\nnetwork_io: {{.NetIO}}\nblock_io: {{.BlockIO}}\npids: {{.PIDs}}\n`
+	}
+
+	var stats []subContext
+	for _, s := range ctx.Stats {
+		stats = append(stats, &statsContext{
+			s: s,
+		})
+	}
+	ctx.write(stats, &statsContext{})
+}
+
+type statsContext struct {
+	baseSubContext
+	s ContainerStats
+}
+
+func (c *statsContext) Container() string {
+	c.addHeader(containerHeader)
+	return c.s.Name
+}
+
+func (c *statsContext) CPUPerc() string {
+	c.addHeader(cpuPercHeader)
+	if c.s.IsInvalid {
+		return statsUnavailable
+	}
+	return fmt.Sprintf("%.2f%%", c.s.CPUPercentage)
+}
+
+func (c *statsContext) MemUsage() string {
+	c.addHeader(memUsageHeader)
+	if c.s.IsInvalid {
+		return fmt.Sprintf("%s / %s", statsUnavailable, statsUnavailable)
+	}
+	return fmt.Sprintf("%s / %s", units.BytesSize(c.s.Memory), units.BytesSize(c.s.MemoryLimit))
+}
+
+func (c *statsContext) MemPerc() string {
+	c.addHeader(memPercHeader)
+	if c.s.IsInvalid {
+		return statsUnavailable
+	}
+	return fmt.Sprintf("%.2f%%", c.s.MemoryPercentage)
+}
+
+func (c *statsContext) NetIO() string {
+	c.addHeader(netIOHeader)
+	if c.s.IsInvalid {
+		return fmt.Sprintf("%s / %s", statsUnavailable, statsUnavailable)
+	}
+	return fmt.Sprintf("%s / %s", units.HumanSize(c.s.NetworkRx), units.HumanSize(c.s.NetworkTx))
+}
+
+func (c *statsContext) BlockIO() string {
+	c.addHeader(blockIOHeader)
+	if c.s.IsInvalid {
+		return fmt.Sprintf("%s / %s", statsUnavailable, statsUnavailable)
+	}
+	return fmt.Sprintf("%s / %s", units.HumanSize(c.s.BlockRead), units.HumanSize(c.s.BlockWrite))
+}
+
+func (c *statsContext) PIDs() string {
+	c.addHeader(pidsHeader)
+	if c.s.IsInvalid {
+		return statsUnavailable
+	}
+	return strconv.FormatUint(c.s.PidsCurrent, 10)
+}
@@ -0,0 +1,84 @@
+package formatter
+
+import (
+	"github.com/docker/engine-api/types"
+)
+
+const (
+	defaultVolumeQuietFormat = "{{.Name}}"
+	defaultVolumeTableFormat = "table {{.Driver}}\t{{.Name}}"
+
+	volumeNameHeader = "VOLUME NAME"
+	mountpointHeader = "MOUNTPOINT"
+)
+
+// VolumeContext contains volume specific information required by the formatter, encapsulate a Context struct.
+type VolumeContext struct {
+	Context
+	// Volumes
+	Volumes []*types.Volume
+}
+
+func (ctx VolumeContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		if ctx.Quiet {
+			ctx.Format = defaultVolumeQuietFormat
+		} else {
+			ctx.Format = defaultVolumeTableFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `name: {{.Name}}`
+		} else {
+			ctx.Format = `name: {{.Name}}\ndriver: {{.Driver}}\n`
+		}
+	}
+
+	var volumes []subContext
+	for _, volume := range ctx.Volumes {
+		volumes = append(volumes, &volumeContext{
+			v: volume,
+		})
+	}
+	ctx.write(volumes, &volumeContext{v: &types.Volume{}})
+}
+
+type volumeContext struct {
+	baseSubContext
+	v *types.Volume
+}
+
+func (c *volumeContext) Name() string {
+	c.addHeader(volumeNameHeader)
+	return c.v.Name
+}
+
+func (c *volumeContext) Driver() string {
+	c.addHeader(driverHeader)
+	return c.v.Driver
+}
+
+func (c *volumeContext) Scope() string {
+	c.addHeader(scopeHeader)
+	return c.v.Scope
+}
+
+func (c *volumeContext) Mountpoint() string {
+	c.addHeader(mountpointHeader)
+	return c.v.Mountpoint
+}
+
+func (c *volumeContext) Labels() string {
+	c.addHeader(labelsHeader)
+	return joinLabels(c.v.Labels)
+}
+
+func (c *volumeContext) Label(name string) string {
+	c.addHeader(labelHeader(name))
+
+	if c.v.Labels == nil {
+		return ""
+	}
+	return c.v.Labels[name]
+}
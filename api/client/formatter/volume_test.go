@@ -0,0 +1,111 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/engine-api/types"
+)
+
+func TestVolumeContextWrite(t *testing.T) {
+	contexts := []struct {
+		context  VolumeContext
+		expected string
+	}{
+		// Errors
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "{{InvalidFunction}}",
+				},
+			},
+			`Template parsing error: template: :1: function "InvalidFunction" not defined
+`,
+		},
+		// Table format
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "table",
+				},
+			},
+			`DRIVER              VOLUME NAME
+foo                 foobar_baz
+bar                 foobar_bar
+`,
+		},
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  true,
+				},
+			},
+			"foobar_baz\nfoobar_bar\n",
+		},
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "table {{.Name}}\t{{.Mountpoint}}",
+				},
+			},
+			`VOLUME NAME         MOUNTPOINT
+foobar_baz          /baz
+foobar_bar          /bar
+`,
+		},
+		// Raw Format
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "raw",
+				},
+			},
+			`name: foobar_baz
+driver: foo
+
+name: foobar_bar
+driver: bar
+
+`,
+		},
+		// Custom Format
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "{{.Name}} {{.Label \"com.example.owner\"}}",
+				},
+			},
+			"foobar_baz me\nfoobar_bar \n",
+		},
+	}
+
+	for _, context := range contexts {
+		volumes := []*types.Volume{
+			{Name: "foobar_baz", Driver: "foo", Mountpoint: "/baz", Labels: map[string]string{"com.example.owner": "me"}},
+			{Name: "foobar_bar", Driver: "bar", Mountpoint: "/bar"},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Volumes = volumes
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+}
+
+func TestVolumeContextWriteWithNoVolumes(t *testing.T) {
+	out := bytes.NewBufferString("")
+	ctx := VolumeContext{
+		Context: Context{
+			Format: "table {{.Driver}}\t{{.Name}}",
+			Output: out,
+		},
+	}
+	ctx.Write()
+	if actual, expected := out.String(), "DRIVER              VOLUME NAME\n"; actual != expected {
+		t.Fatalf("Expected \n%s, got \n%s", expected, actual)
+	}
+}
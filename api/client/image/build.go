@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strings"
 
 	"golang.org/x/net/context"
 
@@ -50,6 +51,8 @@ type buildOptions struct {
 	cpuSetMems     string
 	cgroupParent   string
 	isolation      string
+	target         string
+	cacheFrom      []string
 	quiet          bool
 	noCache        bool
 	rm             bool
@@ -93,6 +96,8 @@ func NewBuildCommand(dockerCli *client.DockerCli) *cobra.Command {
 	flags.StringVar(&options.cgroupParent, "cgroup-parent", "", "Optional parent cgroup for the container")
 	flags.StringVar(&options.isolation, "isolation", "", "Container isolation technology")
 	flags.StringSliceVar(&options.labels, "label", []string{}, "Set metadata for an image")
+	flags.StringVar(&options.target, "target", "", "Set the target build stage to build")
+	flags.StringSliceVar(&options.cacheFrom, "cache-from", []string{}, "Images to consider as cache sources")
 	flags.BoolVar(&options.noCache, "no-cache", false, "Do not use cache when building the image")
 	flags.BoolVar(&options.rm, "rm", true, "Remove intermediate containers after a successful build")
 	flags.BoolVar(&options.forceRm, "force-rm", false, "Always remove intermediate containers")
@@ -265,6 +270,8 @@ func runBuild(dockerCli *client.DockerCli, options buildOptions) error {
 		BuildArgs:      runconfigopts.ConvertKVStringsToMap(options.buildArgs.GetAll()),
 		AuthConfigs:    dockerCli.RetrieveAuthConfigs(),
 		Labels:         runconfigopts.ConvertKVStringsToMap(options.labels),
+		Target:         options.target,
+		CacheFrom:      options.cacheFrom,
 	}
 
 	response, err := dockerCli.Client().ImageBuild(ctx, body, buildOptions)
@@ -324,7 +331,7 @@ func validateTag(rawRepo string) (string, error) {
 	return rawRepo, nil
 }
 
-var dockerfileFromLinePattern = regexp.MustCompile(`(?i)^[\s]*FROM[ \f\r\t\v]+(?P<image>[^ \f\r\t\v\n#]+)`)
+var dockerfileFromLinePattern = regexp.MustCompile(`(?i)^[\s]*FROM[ \f\r\t\v]+(?P<image>[^ \f\r\t\v\n#]+)(?:[ \f\r\t\v]+AS[ \f\r\t\v]+(?P<stage>[^ \f\r\t\v\n#]+))?`)
 
 // resolvedTag records the repository, tag, and resolved digest reference
 // from a Dockerfile rewrite.
@@ -340,13 +347,15 @@ type resolvedTag struct {
 func rewriteDockerfileFrom(ctx context.Context, dockerfile io.Reader, translator translatorFunc) (newDockerfile []byte, resolvedTags []*resolvedTag, err error) {
 	scanner := bufio.NewScanner(dockerfile)
 	buf := bytes.NewBuffer(nil)
+	// names of earlier build stages, which must not be resolved as images
+	stages := make(map[string]bool)
 
 	// Scan the lines of the Dockerfile, looking for a "FROM" line.
 	for scanner.Scan() {
 		line := scanner.Text()
 
 		matches := dockerfileFromLinePattern.FindStringSubmatch(line)
-		if matches != nil && matches[1] != api.NoBaseImageSpecifier {
+		if matches != nil && matches[1] != api.NoBaseImageSpecifier && !stages[strings.ToLower(matches[1])] {
 			// Replace the line with a resolved "FROM repo@digest"
 			ref, err := reference.ParseNamed(matches[1])
 			if err != nil {
@@ -359,13 +368,20 @@ func rewriteDockerfileFrom(ctx context.Context, dockerfile io.Reader, translator
 					return nil, nil, err
 				}
 
-				line = dockerfileFromLinePattern.ReplaceAllLiteralString(line, fmt.Sprintf("FROM %s", trustedRef.String()))
+				from := fmt.Sprintf("FROM %s", trustedRef.String())
+				if matches[2] != "" {
+					from += " AS " + matches[2]
+				}
+				line = dockerfileFromLinePattern.ReplaceAllLiteralString(line, from)
 				resolvedTags = append(resolvedTags, &resolvedTag{
 					digestRef: trustedRef,
 					tagRef:    ref,
 				})
 			}
 		}
+		if matches != nil && matches[2] != "" {
+			stages[strings.ToLower(matches[2])] = true
+		}
 
 		_, err := fmt.Fprintln(buf, line)
 		if err != nil {
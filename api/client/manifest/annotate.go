@@ -0,0 +1,79 @@
+package manifest
+
+import (
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/spf13/cobra"
+)
+
+type annotateOptions struct {
+	list       string
+	manifest   string
+	os         string
+	arch       string
+	variant    string
+	osFeatures []string
+	features   []string
+}
+
+func newAnnotateCommand(dockerCli *client.DockerCli) *cobra.Command {
+	opts := annotateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "annotate [OPTIONS] MANIFEST_LIST MANIFEST",
+		Short: "Set the platform of an image manifest in a local manifest list",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.list = args[0]
+			opts.manifest = args[1]
+			return runAnnotate(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.os, "os", "", "Set the operating system")
+	flags.StringVar(&opts.arch, "arch", "", "Set the architecture")
+	flags.StringVar(&opts.variant, "variant", "", "Set the architecture variant")
+	flags.StringSliceVar(&opts.osFeatures, "os-features", []string{}, "Set the operating system features")
+	flags.StringSliceVar(&opts.features, "features", []string{}, "Set the architecture features")
+	return cmd
+}
+
+func runAnnotate(cmd *cobra.Command, opts annotateOptions) error {
+	listRef, err := parseListReference(opts.list)
+	if err != nil {
+		return err
+	}
+	ref, err := parseReference(opts.manifest)
+	if err != nil {
+		return err
+	}
+
+	if _, err := loadList(listRef); err != nil {
+		return err
+	}
+	m, err := loadManifest(listRef, ref.String())
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	platform := &m.Descriptor.Platform
+	if flags.Changed("os") {
+		platform.OS = opts.os
+	}
+	if flags.Changed("arch") {
+		platform.Architecture = opts.arch
+	}
+	if flags.Changed("variant") {
+		platform.Variant = opts.variant
+	}
+	if flags.Changed("os-features") {
+		platform.OSFeatures = opts.osFeatures
+	}
+	if flags.Changed("features") {
+		platform.Features = opts.features
+	}
+
+	return saveManifest(listRef, m)
+}
@@ -0,0 +1,76 @@
+package manifest
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	list      string
+	manifests []string
+	amend     bool
+	insecure  bool
+}
+
+func newCreateCommand(dockerCli *client.DockerCli) *cobra.Command {
+	opts := createOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create [OPTIONS] MANIFEST_LIST MANIFEST [MANIFEST...]",
+		Short: "Create a local manifest list from image manifests in a registry",
+		Args:  cli.RequiresMinArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.list = args[0]
+			opts.manifests = args[1:]
+			return runCreate(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.amend, "amend", "a", false, "Amend an existing manifest list")
+	flags.BoolVar(&opts.insecure, "insecure", false, "Allow communication with an insecure registry")
+	return cmd
+}
+
+func runCreate(dockerCli *client.DockerCli, opts createOptions) error {
+	listRef, err := parseListReference(opts.list)
+	if err != nil {
+		return err
+	}
+
+	_, err = loadList(listRef)
+	switch {
+	case err == nil && !opts.amend:
+		return fmt.Errorf("manifest list %s already exists, use --amend to add manifests to it", listRef.String())
+	case err != nil && err != errNoList:
+		return err
+	}
+
+	ctx := context.Background()
+
+	var manifests []imageManifest
+	for _, name := range opts.manifests {
+		ref, err := parseReference(name)
+		if err != nil {
+			return err
+		}
+		m, err := fetchImageManifest(ctx, dockerCli, ref, opts.insecure)
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, m)
+	}
+
+	for _, m := range manifests {
+		if err := saveManifest(listRef, m); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(dockerCli.Out(), "Created manifest list %s\n", listRef.String())
+	return nil
+}
@@ -0,0 +1,99 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/registry"
+	"github.com/spf13/cobra"
+)
+
+type inspectOptions struct {
+	name     string
+	insecure bool
+}
+
+func newInspectCommand(dockerCli *client.DockerCli) *cobra.Command {
+	opts := inspectOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "inspect [OPTIONS] NAME[:TAG|@DIGEST]",
+		Short: "Display a manifest list or an image manifest",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.name = args[0]
+			return runInspect(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.insecure, "insecure", false, "Allow communication with an insecure registry")
+	return cmd
+}
+
+func runInspect(dockerCli *client.DockerCli, opts inspectOptions) error {
+	ref, err := parseReference(opts.name)
+	if err != nil {
+		return err
+	}
+
+	// Manifest lists which have not been pushed yet are shown as they will
+	// be pushed.
+	if _, isTagged := ref.(reference.NamedTagged); isTagged {
+		manifests, err := loadList(ref)
+		switch err {
+		case nil:
+			var descriptors []manifestlist.ManifestDescriptor
+			for _, m := range manifests {
+				descriptors = append(descriptors, m.Descriptor)
+			}
+			list, err := manifestlist.FromDescriptors(descriptors)
+			if err != nil {
+				return err
+			}
+			_, payload, err := list.Payload()
+			if err != nil {
+				return err
+			}
+			return printJSON(dockerCli, payload)
+		case errNoList:
+		default:
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	repoInfo, err := registry.ParseRepositoryInfo(ref)
+	if err != nil {
+		return err
+	}
+	repo, err := getRepository(ctx, dockerCli, repoInfo, opts.insecure, false)
+	if err != nil {
+		return err
+	}
+	m, err := getManifest(ctx, repo, ref)
+	if err != nil {
+		return err
+	}
+	_, payload, err := m.Payload()
+	if err != nil {
+		return err
+	}
+	return printJSON(dockerCli, payload)
+}
+
+func printJSON(dockerCli *client.DockerCli, payload []byte) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, payload, "", "    "); err != nil {
+		return err
+	}
+	fmt.Fprintln(dockerCli.Out(), buf.String())
+	return nil
+}
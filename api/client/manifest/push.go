@@ -0,0 +1,93 @@
+package manifest
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/registry"
+	"github.com/spf13/cobra"
+)
+
+type pushOptions struct {
+	list     string
+	purge    bool
+	insecure bool
+}
+
+func newPushCommand(dockerCli *client.DockerCli) *cobra.Command {
+	opts := pushOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "push [OPTIONS] MANIFEST_LIST",
+		Short: "Push a manifest list to a registry",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.list = args[0]
+			return runPush(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.purge, "purge", "p", false, "Remove the local manifest list after a successful push")
+	flags.BoolVar(&opts.insecure, "insecure", false, "Allow communication with an insecure registry")
+	return cmd
+}
+
+func runPush(dockerCli *client.DockerCli, opts pushOptions) error {
+	listRef, err := parseListReference(opts.list)
+	if err != nil {
+		return err
+	}
+	manifests, err := loadList(listRef)
+	if err == errNoList || (err == nil && len(manifests) == 0) {
+		return fmt.Errorf("%s not found, create it with docker manifest create", listRef.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	repoInfo, err := registry.ParseRepositoryInfo(listRef)
+	if err != nil {
+		return err
+	}
+	repo, err := getRepository(ctx, dockerCli, repoInfo, opts.insecure, true)
+	if err != nil {
+		return err
+	}
+
+	var descriptors []manifestlist.ManifestDescriptor
+	for _, m := range manifests {
+		if m.Descriptor.Platform.OS == "" || m.Descriptor.Platform.Architecture == "" {
+			return fmt.Errorf("manifest %s has no platform, set it with docker manifest annotate", m.Ref)
+		}
+		if err := pushImageManifest(ctx, dockerCli, repo, listRef, m, opts.insecure); err != nil {
+			return err
+		}
+		descriptors = append(descriptors, m.Descriptor)
+	}
+
+	list, err := manifestlist.FromDescriptors(descriptors)
+	if err != nil {
+		return err
+	}
+	ms, err := repo.Manifests(ctx)
+	if err != nil {
+		return err
+	}
+	dgst, err := ms.Put(ctx, list, distribution.WithTag(listRef.Tag()))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(dockerCli.Out(), "%s: digest: %s\n", listRef.Tag(), dgst)
+
+	if opts.purge {
+		return removeList(listRef)
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema2"
+	distreference "github.com/docker/distribution/reference"
+	distclient "github.com/docker/distribution/registry/client"
+	"github.com/docker/docker/api/client"
+	dockerdistribution "github.com/docker/docker/distribution"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/registry"
+	"golang.org/x/net/context"
+)
+
+// getRepository returns a client for the repository of repoInfo, from the
+// first v2 endpoint of its registry which can be reached. If insecure is
+// set, the registry may use plain HTTP or a certificate from an unknown CA.
+func getRepository(ctx context.Context, dockerCli *client.DockerCli, repoInfo *registry.RepositoryInfo, insecure, push bool) (distribution.Repository, error) {
+	options := registry.ServiceOptions{V2Only: true}
+	if insecure {
+		options.InsecureRegistries = []string{repoInfo.Index.Name}
+	}
+	registryService := registry.NewService(options)
+
+	var (
+		endpoints []registry.APIEndpoint
+		actions   = []string{"pull"}
+		err       error
+	)
+	if push {
+		endpoints, err = registryService.LookupPushEndpoints(repoInfo.Hostname())
+		actions = []string{"push", "pull"}
+	} else {
+		endpoints, err = registryService.LookupPullEndpoints(repoInfo.Hostname())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	authConfig := dockerCli.ResolveAuthConfig(ctx, repoInfo.Index)
+	var lastErr error
+	for _, endpoint := range endpoints {
+		repo, _, err := dockerdistribution.NewV2Repository(ctx, repoInfo, endpoint, nil, &authConfig, actions...)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return repo, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no endpoints found for %s", repoInfo.FullName())
+	}
+	return nil, lastErr
+}
+
+// getManifest fetches the manifest of ref, which must be tagged or have a
+// digest, from repo.
+func getManifest(ctx context.Context, repo distribution.Repository, ref reference.Named) (distribution.Manifest, error) {
+	ms, err := repo.Manifests(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch r := ref.(type) {
+	case reference.Canonical:
+		return ms.Get(ctx, r.Digest())
+	case reference.NamedTagged:
+		return ms.Get(ctx, "", distribution.WithTag(r.Tag()))
+	}
+	return nil, fmt.Errorf("%s has neither a tag nor a digest", ref.String())
+}
+
+// fetchImageManifest fetches the image manifest of ref from its registry,
+// along with the platform of the image, so that it can be added to a
+// manifest list.
+func fetchImageManifest(ctx context.Context, dockerCli *client.DockerCli, ref reference.Named, insecure bool) (imageManifest, error) {
+	repoInfo, err := registry.ParseRepositoryInfo(ref)
+	if err != nil {
+		return imageManifest{}, err
+	}
+	repo, err := getRepository(ctx, dockerCli, repoInfo, insecure, false)
+	if err != nil {
+		return imageManifest{}, err
+	}
+	m, err := getManifest(ctx, repo, ref)
+	if err != nil {
+		return imageManifest{}, err
+	}
+
+	var platform manifestlist.PlatformSpec
+	switch v := m.(type) {
+	case *schema2.DeserializedManifest:
+		platform, err = fetchPlatform(ctx, repo, v.Config)
+		if err != nil {
+			return imageManifest{}, err
+		}
+	case *manifestlist.DeserializedManifestList:
+		return imageManifest{}, fmt.Errorf("%s is a manifest list, it cannot be added to another manifest list", ref.String())
+	default:
+		return imageManifest{}, fmt.Errorf("%s has a schema1 manifest, only schema2 image manifests can be added to a manifest list", ref.String())
+	}
+
+	mediaType, payload, err := m.Payload()
+	if err != nil {
+		return imageManifest{}, err
+	}
+	return imageManifest{
+		Ref: ref.String(),
+		Descriptor: manifestlist.ManifestDescriptor{
+			Descriptor: distribution.Descriptor{
+				MediaType: mediaType,
+				Size:      int64(len(payload)),
+				Digest:    digest.FromBytes(payload),
+			},
+			Platform: platform,
+		},
+		Payload: payload,
+	}, nil
+}
+
+// fetchPlatform returns the platform of an image, read from its
+// configuration.
+func fetchPlatform(ctx context.Context, repo distribution.Repository, config distribution.Descriptor) (manifestlist.PlatformSpec, error) {
+	var platform manifestlist.PlatformSpec
+	b, err := repo.Blobs(ctx).Get(ctx, config.Digest)
+	if err != nil {
+		return platform, err
+	}
+	var imageConfig struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+	}
+	if err := json.Unmarshal(b, &imageConfig); err != nil {
+		return platform, fmt.Errorf("error reading image configuration %s: %v", config.Digest, err)
+	}
+	platform.Architecture = imageConfig.Architecture
+	platform.OS = imageConfig.OS
+	return platform, nil
+}
+
+// pushImageManifest makes sure the image manifest m is in repo, the
+// repository of the manifest list listRef. Image manifests from another
+// repository of the same registry are copied, along with their blobs.
+func pushImageManifest(ctx context.Context, dockerCli *client.DockerCli, repo distribution.Repository, listRef reference.Named, m imageManifest, insecure bool) error {
+	ref, err := reference.ParseNamed(m.Ref)
+	if err != nil {
+		return err
+	}
+	if ref.Name() == listRef.Name() {
+		return nil
+	}
+	if ref.Hostname() != listRef.Hostname() {
+		return fmt.Errorf("cannot push manifest %s: it must be in the same registry as manifest list %s", m.Ref, listRef.String())
+	}
+
+	manifest, _, err := distribution.UnmarshalManifest(m.Descriptor.MediaType, m.Payload)
+	if err != nil {
+		return err
+	}
+	imgManifest, ok := manifest.(*schema2.DeserializedManifest)
+	if !ok {
+		return fmt.Errorf("unexpected manifest type %s for %s", m.Descriptor.MediaType, m.Ref)
+	}
+
+	srcInfo, err := registry.ParseRepositoryInfo(ref)
+	if err != nil {
+		return err
+	}
+	var srcRepo distribution.Repository
+	getSrcRepo := func() (distribution.Repository, error) {
+		if srcRepo != nil {
+			return srcRepo, nil
+		}
+		var err error
+		srcRepo, err = getRepository(ctx, dockerCli, srcInfo, insecure, false)
+		return srcRepo, err
+	}
+
+	blobs := append([]distribution.Descriptor{imgManifest.Config}, imgManifest.Layers...)
+	for _, desc := range blobs {
+		if desc.MediaType == schema2.MediaTypeForeignLayer {
+			continue
+		}
+		if err := copyBlob(ctx, repo, srcInfo, getSrcRepo, desc); err != nil {
+			return fmt.Errorf("error copying blob %s of %s: %v", desc.Digest, m.Ref, err)
+		}
+	}
+
+	ms, err := repo.Manifests(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = ms.Put(ctx, manifest)
+	return err
+}
+
+// copyBlob copies the blob desc to repo from the repository of srcInfo. The
+// registry is asked to mount the blob first, and the blob is only uploaded
+// if it could not be mounted.
+func copyBlob(ctx context.Context, repo distribution.Repository, srcInfo *registry.RepositoryInfo, getSrcRepo func() (distribution.Repository, error), desc distribution.Descriptor) error {
+	bs := repo.Blobs(ctx)
+	if _, err := bs.Stat(ctx, desc.Digest); err == nil {
+		return nil
+	}
+
+	srcName, err := distreference.ParseNamed(srcInfo.RemoteName())
+	if err != nil {
+		return err
+	}
+	canonical, err := distreference.WithDigest(srcName, desc.Digest)
+	if err != nil {
+		return err
+	}
+	w, err := bs.Create(ctx, distclient.WithMountFrom(canonical))
+	if _, mounted := err.(distribution.ErrBlobMounted); mounted {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	srcRepo, err := getSrcRepo()
+	if err != nil {
+		w.Cancel(ctx)
+		return err
+	}
+	rc, err := srcRepo.Blobs(ctx).Open(ctx, desc.Digest)
+	if err != nil {
+		w.Cancel(ctx)
+		return err
+	}
+	defer rc.Close()
+
+	if _, err := io.Copy(w, rc); err != nil {
+		w.Cancel(ctx)
+		return err
+	}
+	_, err = w.Commit(ctx, desc)
+	return err
+}
@@ -0,0 +1,131 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/docker/cliconfig"
+	"github.com/docker/docker/reference"
+)
+
+// errNoList is returned when there is no manifest list stored for a
+// reference.
+var errNoList = errors.New("no such manifest list")
+
+// imageManifest is an image manifest added to a manifest list. It is stored
+// locally, in the configuration directory of the client, until the manifest
+// list is pushed.
+type imageManifest struct {
+	// Ref is the reference the image manifest was fetched from.
+	Ref string `json:"ref"`
+	// Descriptor describes the image manifest and the platform it runs on,
+	// as it appears in the manifest list.
+	Descriptor manifestlist.ManifestDescriptor `json:"descriptor"`
+	// Payload is the raw image manifest.
+	Payload []byte `json:"payload"`
+}
+
+// parseReference parses name, adding the default tag if it has neither a
+// tag nor a digest.
+func parseReference(name string) (reference.Named, error) {
+	ref, err := reference.ParseNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	return reference.WithDefaultTag(ref), nil
+}
+
+// parseListReference parses the name of a manifest list. Manifest lists are
+// pushed by tag, so the name must not have a digest.
+func parseListReference(name string) (reference.NamedTagged, error) {
+	ref, err := parseReference(name)
+	if err != nil {
+		return nil, err
+	}
+	tagged, ok := ref.(reference.NamedTagged)
+	if !ok {
+		return nil, fmt.Errorf("invalid manifest list name %s: it must be tagged, not referenced by digest", name)
+	}
+	return tagged, nil
+}
+
+// listDir returns the directory in which the image manifests of the manifest
+// list listRef are stored.
+func listDir(listRef reference.Named) string {
+	return filepath.Join(cliconfig.ConfigDir(), "manifests", url.QueryEscape(listRef.String()))
+}
+
+func manifestPath(listRef reference.Named, ref string) string {
+	return filepath.Join(listDir(listRef), url.QueryEscape(ref)+".json")
+}
+
+// loadList returns the image manifests of the manifest list listRef, or
+// errNoList if there is no such list.
+func loadList(listRef reference.Named) ([]imageManifest, error) {
+	files, err := ioutil.ReadDir(listDir(listRef))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errNoList
+		}
+		return nil, err
+	}
+
+	var manifests []imageManifest
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		m, err := loadManifestFile(filepath.Join(listDir(listRef), f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, m)
+	}
+	return manifests, nil
+}
+
+// loadManifest returns the image manifest ref of the manifest list listRef.
+func loadManifest(listRef reference.Named, ref string) (imageManifest, error) {
+	m, err := loadManifestFile(manifestPath(listRef, ref))
+	if os.IsNotExist(err) {
+		return m, fmt.Errorf("manifest %s is not in manifest list %s", ref, listRef.String())
+	}
+	return m, err
+}
+
+func loadManifestFile(path string) (imageManifest, error) {
+	var m imageManifest
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return m, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	return m, nil
+}
+
+// saveManifest adds or replaces the image manifest m in the manifest list
+// listRef.
+func saveManifest(listRef reference.Named, m imageManifest) error {
+	if err := os.MkdirAll(listDir(listRef), 0700); err != nil {
+		return err
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(manifestPath(listRef, m.Ref), b, 0600)
+}
+
+// removeList removes the manifest list listRef from the local store.
+func removeList(listRef reference.Named) error {
+	return os.RemoveAll(listDir(listRef))
+}
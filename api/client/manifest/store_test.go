@@ -0,0 +1,82 @@
+package manifest
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/docker/cliconfig"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifest-store-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer cliconfig.SetConfigDir(cliconfig.ConfigDir())
+	cliconfig.SetConfigDir(dir)
+
+	listRef, err := parseListReference("localhost:5000/busybox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if listRef.String() != "localhost:5000/busybox:latest" {
+		t.Fatalf("Expected the default tag to be added, got %s", listRef.String())
+	}
+	if _, err := parseListReference("busybox@sha256:7cc4b5aefd1d0cadf8d97d4350462ba51c694ebca145b08d7d41b41acc8db5aa"); err == nil {
+		t.Fatal("Expected an error for a manifest list name with a digest")
+	}
+
+	if _, err := loadList(listRef); err != errNoList {
+		t.Fatalf("Expected errNoList, got %v", err)
+	}
+
+	for _, ref := range []string{"localhost:5000/busybox:amd64", "localhost:5000/busybox:armhf"} {
+		m := imageManifest{
+			Ref: ref,
+			Descriptor: manifestlist.ManifestDescriptor{
+				Platform: manifestlist.PlatformSpec{OS: "linux", Architecture: "amd64"},
+			},
+			Payload: []byte("{}"),
+		}
+		if err := saveManifest(listRef, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := loadManifest(listRef, "localhost:5000/busybox:armhf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Descriptor.Platform.Architecture = "arm"
+	if err := saveManifest(listRef, m); err != nil {
+		t.Fatal(err)
+	}
+
+	manifests, err := loadList(listRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifests) != 2 {
+		t.Fatalf("Expected 2 manifests, got %d", len(manifests))
+	}
+	if manifests[1].Ref != "localhost:5000/busybox:armhf" || manifests[1].Descriptor.Platform.Architecture != "arm" {
+		t.Fatalf("Unexpected manifest %+v", manifests[1])
+	}
+	if string(manifests[1].Payload) != "{}" {
+		t.Fatalf("Unexpected payload %q", manifests[1].Payload)
+	}
+
+	if _, err := loadManifest(listRef, "localhost:5000/busybox:ppc64le"); err == nil {
+		t.Fatal("Expected an error for a manifest not in the list")
+	}
+
+	if err := removeList(listRef); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadList(listRef); err != errNoList {
+		t.Fatalf("Expected errNoList after removal, got %v", err)
+	}
+}
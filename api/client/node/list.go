@@ -1,26 +1,19 @@
 package node
 
 import (
-	"fmt"
-	"io"
-	"text/tabwriter"
-
 	"golang.org/x/net/context"
 
 	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/opts"
 	"github.com/docker/engine-api/types"
-	"github.com/docker/engine-api/types/swarm"
 	"github.com/spf13/cobra"
 )
 
-const (
-	listItemFmt = "%s\t%s\t%s\t%s\t%s\t%s\n"
-)
-
 type listOptions struct {
 	quiet  bool
+	format string
 	filter opts.FilterOpt
 }
 
@@ -38,6 +31,7 @@ func newListCommand(dockerCli *client.DockerCli) *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display IDs")
+	flags.StringVar(&opts.format, "format", "", "Pretty-print nodes using a Go template")
 	flags.VarP(&opts.filter, "filter", "f", "Filter output based on conditions provided")
 
 	return cmd
@@ -59,59 +53,27 @@ func runList(dockerCli *client.DockerCli, opts listOptions) error {
 		return err
 	}
 
-	out := dockerCli.Out()
-	if opts.quiet {
-		printQuiet(out, nodes)
-	} else {
-		printTable(out, nodes, info)
-	}
-	return nil
-}
-
-func printTable(out io.Writer, nodes []swarm.Node, info types.Info) {
-	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
-
-	// Ignore flushing errors
-	defer writer.Flush()
-
-	fmt.Fprintf(writer, listItemFmt, "ID", "NAME", "MEMBERSHIP", "STATUS", "AVAILABILITY", "MANAGER STATUS")
-	for _, node := range nodes {
-		name := node.Spec.Name
-		availability := string(node.Spec.Availability)
-		membership := string(node.Spec.Membership)
-
-		if name == "" {
-			name = node.Description.Hostname
+	f := opts.format
+	if len(f) == 0 {
+		if len(dockerCli.NodesFormat()) > 0 && !opts.quiet {
+			f = dockerCli.NodesFormat()
+		} else {
+			f = "table"
 		}
+	}
 
-		reachability := ""
-		if node.ManagerStatus != nil {
-			if node.ManagerStatus.Leader {
-				reachability = "Leader"
-			} else {
-				reachability = string(node.ManagerStatus.Reachability)
-			}
-		}
+	nodesCtx := formatter.NodeContext{
+		Context: formatter.Context{
+			Output: dockerCli.Out(),
+			Format: f,
+			Quiet:  opts.quiet,
+		},
+		Nodes: nodes,
+		Info:  info,
+	}
 
-		ID := node.ID
-		if node.ID == info.Swarm.NodeID {
-			ID = ID + " *"
-		}
+	nodesCtx.Write()
 
-		fmt.Fprintf(
-			writer,
-			listItemFmt,
-			ID,
-			name,
-			client.PrettyPrint(membership),
-			client.PrettyPrint(string(node.Status.State)),
-			client.PrettyPrint(availability),
-			client.PrettyPrint(reachability))
-	}
+	return nil
 }
 
-func printQuiet(out io.Writer, nodes []swarm.Node) {
-	for _, node := range nodes {
-		fmt.Fprintln(out, node.ID)
-	}
-}
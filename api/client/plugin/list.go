@@ -3,42 +3,66 @@
 package plugin
 
 import (
-	"fmt"
-	"text/tabwriter"
-
 	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/cli"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
 )
 
+type listOptions struct {
+	quiet   bool
+	noTrunc bool
+	format  string
+}
+
 func newListCommand(dockerCli *client.DockerCli) *cobra.Command {
+	var opts listOptions
+
 	cmd := &cobra.Command{
 		Use:     "ls",
 		Short:   "List plugins",
 		Aliases: []string{"list"},
 		Args:    cli.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runList(dockerCli)
+			return runList(dockerCli, opts)
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display plugin IDs")
+	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Do not truncate the output")
+	flags.StringVar(&opts.format, "format", "", "Pretty-print plugins using a Go template")
+
 	return cmd
 }
 
-func runList(dockerCli *client.DockerCli) error {
+func runList(dockerCli *client.DockerCli, opts listOptions) error {
 	plugins, err := dockerCli.Client().PluginList(context.Background())
 	if err != nil {
 		return err
 	}
 
-	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
-	fmt.Fprintf(w, "NAME \tTAG \tACTIVE")
-	fmt.Fprintf(w, "\n")
+	f := opts.format
+	if len(f) == 0 {
+		if len(dockerCli.PluginsFormat()) > 0 && !opts.quiet {
+			f = dockerCli.PluginsFormat()
+		} else {
+			f = "table"
+		}
+	}
 
-	for _, p := range plugins {
-		fmt.Fprintf(w, "%s\t%s\t%v\n", p.Name, p.Tag, p.Active)
+	pluginsCtx := formatter.PluginContext{
+		Context: formatter.Context{
+			Output: dockerCli.Out(),
+			Format: f,
+			Quiet:  opts.quiet,
+			Trunc:  !opts.noTrunc,
+		},
+		Plugins: plugins,
 	}
-	w.Flush()
+
+	pluginsCtx.Write()
+
 	return nil
 }
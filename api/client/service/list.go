@@ -1,15 +1,10 @@
 package service
 
 import (
-	"fmt"
-	"io"
-	"strings"
-	"text/tabwriter"
-
 	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/opts"
-	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/engine-api/types/filters"
 	"github.com/docker/engine-api/types/swarm"
@@ -17,12 +12,9 @@ import (
 	"golang.org/x/net/context"
 )
 
-const (
-	listItemFmt = "%s\t%s\t%s\t%s\t%s\n"
-)
-
 type listOptions struct {
 	quiet  bool
+	format string
 	filter opts.FilterOpt
 }
 
@@ -41,6 +33,7 @@ func newListCommand(dockerCli *client.DockerCli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display IDs")
+	flags.StringVar(&opts.format, "format", "", "Pretty-print services using a Go template")
 	flags.VarP(&opts.filter, "filter", "f", "Filter output based on conditions provided")
 
 	return cmd
@@ -55,10 +48,8 @@ func runList(dockerCli *client.DockerCli, opts listOptions) error {
 		return err
 	}
 
-	out := dockerCli.Out()
-	if opts.quiet {
-		printQuiet(out, services)
-	} else {
+	running := map[string]int{}
+	if !opts.quiet {
 		taskFilter := filters.NewArgs()
 		for _, service := range services {
 			taskFilter.Add("service", service.ID)
@@ -80,45 +71,35 @@ func runList(dockerCli *client.DockerCli, opts listOptions) error {
 			}
 		}
 
-		running := map[string]int{}
 		for _, task := range tasks {
 			if _, nodeActive := activeNodes[task.NodeID]; nodeActive && task.Status.State == "running" {
 				running[task.ServiceID]++
 			}
 		}
-
-		printTable(out, services, running)
 	}
-	return nil
-}
-
-func printTable(out io.Writer, services []swarm.Service, running map[string]int) {
-	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
 
-	// Ignore flushing errors
-	defer writer.Flush()
-
-	fmt.Fprintf(writer, listItemFmt, "ID", "NAME", "REPLICAS", "IMAGE", "COMMAND")
-	for _, service := range services {
-		replicas := ""
-		if service.Spec.Mode.Replicated != nil && service.Spec.Mode.Replicated.Replicas != nil {
-			replicas = fmt.Sprintf("%d/%d", running[service.ID], *service.Spec.Mode.Replicated.Replicas)
-		} else if service.Spec.Mode.Global != nil {
-			replicas = "global"
+	f := opts.format
+	if len(f) == 0 {
+		if len(dockerCli.ServicesFormat()) > 0 && !opts.quiet {
+			f = dockerCli.ServicesFormat()
+		} else {
+			f = "table"
 		}
-		fmt.Fprintf(
-			writer,
-			listItemFmt,
-			stringid.TruncateID(service.ID),
-			service.Spec.Name,
-			replicas,
-			service.Spec.TaskTemplate.ContainerSpec.Image,
-			strings.Join(service.Spec.TaskTemplate.ContainerSpec.Args, " "))
 	}
-}
 
-func printQuiet(out io.Writer, services []swarm.Service) {
-	for _, service := range services {
-		fmt.Fprintln(out, service.ID)
+	servicesCtx := formatter.ServiceContext{
+		Context: formatter.Context{
+			Output: dockerCli.Out(),
+			Format: f,
+			Quiet:  opts.quiet,
+			Trunc:  !opts.quiet,
+		},
+		Services: services,
+		Running:  running,
 	}
+
+	servicesCtx.Write()
+
+	return nil
 }
+
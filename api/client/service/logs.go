@@ -0,0 +1,224 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/api/client/idresolver"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/docker/pkg/stringid"
+	apiclient "github.com/docker/engine-api/client"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/engine-api/types/swarm"
+	"github.com/spf13/cobra"
+)
+
+// logContextPrefix is the prefix of the details the daemon attaches to each
+// line of the logs of a service.
+const logContextPrefix = "com.docker.swarm"
+
+type logsOptions struct {
+	noResolve  bool
+	follow     bool
+	since      string
+	timestamps bool
+	details    bool
+	tail       string
+
+	service string
+}
+
+func newLogsCommand(dockerCli *client.DockerCli) *cobra.Command {
+	var opts logsOptions
+
+	cmd := &cobra.Command{
+		Use:   "logs [OPTIONS] SERVICE",
+		Short: "Fetch the logs of a service",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.service = args[0]
+			return runLogs(dockerCli, &opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.noResolve, "no-resolve", false, "Do not map IDs to Names")
+	flags.BoolVarP(&opts.follow, "follow", "f", false, "Follow log output")
+	flags.StringVar(&opts.since, "since", "", "Show logs since timestamp")
+	flags.BoolVarP(&opts.timestamps, "timestamps", "t", false, "Show timestamps")
+	flags.BoolVar(&opts.details, "details", false, "Show the task context provided to logs")
+	flags.StringVar(&opts.tail, "tail", "all", "Number of lines to show from the end of the logs")
+	return cmd
+}
+
+func runLogs(dockerCli *client.DockerCli, opts *logsOptions) error {
+	ctx := context.Background()
+
+	options := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Since:      opts.since,
+		Timestamps: opts.timestamps,
+		Follow:     opts.follow,
+		Tail:       opts.tail,
+	}
+
+	client := dockerCli.Client()
+	responseBody, err := client.ServiceLogs(ctx, opts.service, options)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	names := &taskNames{
+		client:   client,
+		resolver: idresolver.New(client, opts.noResolve),
+		tasks:    make(map[string]string),
+	}
+
+	stdout := &logWriter{ctx: ctx, opts: opts, names: names, w: dockerCli.Out()}
+	stderr := &logWriter{ctx: ctx, opts: opts, names: names, w: dockerCli.Err()}
+
+	_, err = stdcopy.StdCopy(stdout, stderr, responseBody)
+	return err
+}
+
+// logWriter rewrites the context the daemon attaches to each line of the
+// logs of a service into a human readable prefix identifying the task.
+type logWriter struct {
+	ctx   context.Context
+	opts  *logsOptions
+	names *taskNames
+	w     io.Writer
+}
+
+func (lw *logWriter) Write(buf []byte) (int, error) {
+	contextIndex := 0
+	numParts := 2
+	if lw.opts.timestamps {
+		contextIndex++
+		numParts++
+	}
+
+	parts := bytes.SplitN(buf, []byte(" "), numParts)
+	if len(parts) != numParts {
+		return 0, fmt.Errorf("invalid context in log message: %v", string(buf))
+	}
+
+	logCtx, err := parseContext(string(parts[contextIndex]))
+	if err != nil {
+		return 0, err
+	}
+
+	output := []byte{}
+	for i, part := range parts {
+		// First part doesn't get space separation.
+		if i > 0 {
+			output = append(output, []byte(" ")...)
+		}
+
+		if i == contextIndex {
+			prefix, err := lw.names.prefix(lw.ctx, logCtx, lw.opts.noResolve)
+			if err != nil {
+				return 0, err
+			}
+			output = append(output, []byte(prefix+" |")...)
+			if lw.opts.details {
+				output = append(output, []byte(" "+string(part))...)
+			}
+		} else {
+			output = append(output, part...)
+		}
+	}
+
+	if _, err := lw.w.Write(output); err != nil {
+		return 0, err
+	}
+
+	return len(buf), nil
+}
+
+// taskNames resolves and caches the names of the services, tasks and nodes
+// found in the logs of a service. It is shared by the stdout and stderr
+// writers.
+type taskNames struct {
+	client   apiclient.APIClient
+	resolver *idresolver.IDResolver
+	tasks    map[string]string
+}
+
+// prefix returns the prefix identifying the task which produced a line, in
+// the form SERVICE.SLOT.TASK@NODE.
+func (n *taskNames) prefix(ctx context.Context, logCtx logContext, noResolve bool) (string, error) {
+	serviceName, err := n.resolver.Resolve(ctx, swarm.Service{}, logCtx.serviceID)
+	if err != nil {
+		return "", err
+	}
+
+	nodeName, err := n.resolver.Resolve(ctx, swarm.Node{}, logCtx.nodeID)
+	if err != nil {
+		return "", err
+	}
+
+	taskName, ok := n.tasks[logCtx.taskID]
+	if !ok {
+		taskName = stringid.TruncateID(logCtx.taskID)
+		if !noResolve {
+			task, _, err := n.client.TaskInspectWithRaw(ctx, logCtx.taskID)
+			if err == nil && task.Slot != 0 {
+				taskName = fmt.Sprintf("%d.%s", task.Slot, taskName)
+			}
+		}
+		n.tasks[logCtx.taskID] = taskName
+	}
+
+	return fmt.Sprintf("%s.%s@%s", serviceName, taskName, nodeName), nil
+}
+
+type logContext struct {
+	nodeID    string
+	serviceID string
+	taskID    string
+}
+
+// parseContext parses the comma separated list of key=value pairs the daemon
+// attaches to each line of the logs of a service.
+func parseContext(input string) (logContext, error) {
+	context := make(map[string]string)
+
+	components := strings.Split(input, ",")
+	for _, component := range components {
+		parts := strings.SplitN(component, "=", 2)
+		if len(parts) != 2 {
+			return logContext{}, fmt.Errorf("invalid context: %s", input)
+		}
+		context[parts[0]] = parts[1]
+	}
+
+	nodeID, ok := context[logContextPrefix+".node.id"]
+	if !ok {
+		return logContext{}, fmt.Errorf("missing node id in context: %s", input)
+	}
+
+	serviceID, ok := context[logContextPrefix+".service.id"]
+	if !ok {
+		return logContext{}, fmt.Errorf("missing service id in context: %s", input)
+	}
+
+	taskID, ok := context[logContextPrefix+".task.id"]
+	if !ok {
+		return logContext{}, fmt.Errorf("missing task id in context: %s", input)
+	}
+
+	return logContext{
+		nodeID:    nodeID,
+		serviceID: serviceID,
+		taskID:    taskID,
+	}, nil
+}
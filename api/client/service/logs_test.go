@@ -0,0 +1,23 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestParseContext(t *testing.T) {
+	logCtx, err := parseContext("com.docker.swarm.node.id=node1,com.docker.swarm.service.id=service1,com.docker.swarm.task.id=task1")
+	assert.NilError(t, err)
+	assert.Equal(t, logCtx.nodeID, "node1")
+	assert.Equal(t, logCtx.serviceID, "service1")
+	assert.Equal(t, logCtx.taskID, "task1")
+}
+
+func TestParseContextInvalid(t *testing.T) {
+	_, err := parseContext("com.docker.swarm.node.id")
+	assert.Error(t, err, "invalid context")
+
+	_, err = parseContext("com.docker.swarm.node.id=node1,com.docker.swarm.service.id=service1")
+	assert.Error(t, err, "missing task id")
+}
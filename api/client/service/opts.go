@@ -253,9 +253,82 @@ func (m *MountOpt) Value() []swarm.Mount {
 	return m.values
 }
 
+// SecretOpt is a Value type for parsing secret references
+type SecretOpt struct {
+	values []*swarm.SecretReference
+}
+
+// Set a new secret value
+func (o *SecretOpt) Set(value string) error {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	ref := &swarm.SecretReference{}
+
+	for i, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) == 1 && i == 0 {
+			ref.SecretName = field
+			continue
+		}
+
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+
+		key, value := parts[0], parts[1]
+		switch strings.ToLower(key) {
+		case "source", "src":
+			ref.SecretName = value
+		case "target":
+			ref.Target = value
+		default:
+			return fmt.Errorf("unexpected key '%s' in '%s'", key, field)
+		}
+	}
+
+	if ref.SecretName == "" {
+		return fmt.Errorf("source is required")
+	}
+	if ref.Target == "" {
+		ref.Target = ref.SecretName
+	}
+	if strings.Contains(ref.Target, "/") {
+		return fmt.Errorf("invalid target '%s', it must be a file name", ref.Target)
+	}
+
+	o.values = append(o.values, ref)
+	return nil
+}
+
+// Type returns the type of this option
+func (o *SecretOpt) Type() string {
+	return "secret"
+}
+
+// String returns a string repr of this option
+func (o *SecretOpt) String() string {
+	secrets := []string{}
+	for _, secret := range o.values {
+		repr := fmt.Sprintf("%s -> %s", secret.SecretName, secret.Target)
+		secrets = append(secrets, repr)
+	}
+	return strings.Join(secrets, ", ")
+}
+
+// Value returns the secret references
+func (o *SecretOpt) Value() []*swarm.SecretReference {
+	return o.values
+}
+
 type updateOptions struct {
 	parallelism uint64
 	delay       time.Duration
+	monitor     time.Duration
+	onFailure   string
 }
 
 type resourceOptions struct {
@@ -361,6 +434,7 @@ type serviceOptions struct {
 	workdir string
 	user    string
 	mounts  MountOpt
+	secrets SecretOpt
 
 	resources resourceOptions
 	stopGrace DurationOpt
@@ -402,6 +476,7 @@ func (opts *serviceOptions) ToService() (swarm.ServiceSpec, error) {
 				Dir:             opts.workdir,
 				User:            opts.user,
 				Mounts:          opts.mounts.Value(),
+				Secrets:         opts.secrets.Value(),
 				StopGracePeriod: opts.stopGrace.Value(),
 			},
 			Resources:     opts.resources.ToResourceRequirements(),
@@ -412,8 +487,10 @@ func (opts *serviceOptions) ToService() (swarm.ServiceSpec, error) {
 		},
 		Mode: swarm.ServiceMode{},
 		UpdateConfig: &swarm.UpdateConfig{
-			Parallelism: opts.update.parallelism,
-			Delay:       opts.update.delay,
+			Parallelism:   opts.update.parallelism,
+			Delay:         opts.update.delay,
+			Monitor:       opts.update.monitor,
+			FailureAction: opts.update.onFailure,
 		},
 		Networks:     convertNetworks(opts.networks),
 		EndpointSpec: opts.endpoint.ToEndpointSpec(),
@@ -447,6 +524,7 @@ func addServiceFlags(cmd *cobra.Command, opts *serviceOptions) {
 	flags.StringVarP(&opts.workdir, "workdir", "w", "", "Working directory inside the container")
 	flags.StringVarP(&opts.user, flagUser, "u", "", "Username or UID")
 	flags.VarP(&opts.mounts, flagMount, "m", "Attach a mount to the service")
+	flags.Var(&opts.secrets, flagSecret, "Specify secrets to expose to the service")
 
 	flags.Var(&opts.resources.limitCPU, flagLimitCPU, "Limit CPUs")
 	flags.Var(&opts.resources.limitMemBytes, flagLimitMemory, "Limit Memory")
@@ -465,6 +543,8 @@ func addServiceFlags(cmd *cobra.Command, opts *serviceOptions) {
 
 	flags.Uint64Var(&opts.update.parallelism, flagUpdateParallelism, 0, "Maximum number of tasks updated simultaneously")
 	flags.DurationVar(&opts.update.delay, flagUpdateDelay, time.Duration(0), "Delay between updates")
+	flags.DurationVar(&opts.update.monitor, flagUpdateMonitor, time.Duration(0), "Duration after each task update to monitor for failure (default 30s)")
+	flags.StringVar(&opts.update.onFailure, flagUpdateFailureAction, "pause", "Action on update failure (pause|continue|rollback)")
 
 	flags.StringSliceVar(&opts.networks, flagNetwork, []string{}, "Network attachments")
 	flags.StringVar(&opts.endpoint.mode, flagEndpointMode, "", "Endpoint mode(Valid values: vip, dnsrr)")
@@ -472,25 +552,29 @@ func addServiceFlags(cmd *cobra.Command, opts *serviceOptions) {
 }
 
 const (
-	flagConstraint         = "constraint"
-	flagEndpointMode       = "endpoint-mode"
-	flagLabel              = "label"
-	flagLimitCPU           = "limit-cpu"
-	flagLimitMemory        = "limit-memory"
-	flagMode               = "mode"
-	flagMount              = "mount"
-	flagName               = "name"
-	flagNetwork            = "network"
-	flagPublish            = "publish"
-	flagReplicas           = "replicas"
-	flagReserveCPU         = "reserve-cpu"
-	flagReserveMemory      = "reserve-memory"
-	flagRestartCondition   = "restart-condition"
-	flagRestartDelay       = "restart-delay"
-	flagRestartMaxAttempts = "restart-max-attempts"
-	flagRestartWindow      = "restart-window"
-	flagStopGracePeriod    = "stop-grace-period"
-	flagUpdateDelay        = "update-delay"
-	flagUpdateParallelism  = "update-parallelism"
-	flagUser               = "user"
+	flagConstraint          = "constraint"
+	flagEndpointMode        = "endpoint-mode"
+	flagLabel               = "label"
+	flagLimitCPU            = "limit-cpu"
+	flagLimitMemory         = "limit-memory"
+	flagMode                = "mode"
+	flagMount               = "mount"
+	flagName                = "name"
+	flagNetwork             = "network"
+	flagPublish             = "publish"
+	flagReplicas            = "replicas"
+	flagReserveCPU          = "reserve-cpu"
+	flagReserveMemory       = "reserve-memory"
+	flagRestartCondition    = "restart-condition"
+	flagRestartDelay        = "restart-delay"
+	flagRestartMaxAttempts  = "restart-max-attempts"
+	flagRestartWindow       = "restart-window"
+	flagRollback            = "rollback"
+	flagSecret              = "secret"
+	flagStopGracePeriod     = "stop-grace-period"
+	flagUpdateDelay         = "update-delay"
+	flagUpdateFailureAction = "update-failure-action"
+	flagUpdateMonitor       = "update-monitor"
+	flagUpdateParallelism   = "update-parallelism"
+	flagUser                = "user"
 )
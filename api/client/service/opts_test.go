@@ -113,3 +113,38 @@ func TestMountOptSetErrorInvalidWritable(t *testing.T) {
 	var mount MountOpt
 	assert.Error(t, mount.Set("type=VOLUME,writable=yes"), "invalid value for writable: yes")
 }
+
+func TestSecretOptSetSimple(t *testing.T) {
+	var opt SecretOpt
+	assert.NilError(t, opt.Set("foo"))
+
+	secrets := opt.Value()
+	assert.Equal(t, len(secrets), 1)
+	assert.Equal(t, secrets[0].SecretName, "foo")
+	assert.Equal(t, secrets[0].Target, "foo")
+}
+
+func TestSecretOptSetWithTarget(t *testing.T) {
+	var opt SecretOpt
+	assert.NilError(t, opt.Set("source=foo,target=bar"))
+
+	secrets := opt.Value()
+	assert.Equal(t, len(secrets), 1)
+	assert.Equal(t, secrets[0].SecretName, "foo")
+	assert.Equal(t, secrets[0].Target, "bar")
+}
+
+func TestSecretOptSetErrorNoSource(t *testing.T) {
+	var opt SecretOpt
+	assert.Error(t, opt.Set("target=bar"), "source is required")
+}
+
+func TestSecretOptSetErrorInvalidTarget(t *testing.T) {
+	var opt SecretOpt
+	assert.Error(t, opt.Set("source=foo,target=/etc/bar"), "invalid target")
+}
+
+func TestSecretOptSetErrorInvalidKey(t *testing.T) {
+	var opt SecretOpt
+	assert.Error(t, opt.Set("source=foo,bogus=bar"), "unexpected key 'bogus'")
+}
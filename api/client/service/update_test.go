@@ -2,6 +2,7 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/docker/docker/pkg/testutil/assert"
 	"github.com/docker/engine-api/types/swarm"
@@ -24,3 +25,18 @@ func TestUpdateServiceCommandAndArgs(t *testing.T) {
 	assert.EqualStringSlice(t, cspec.Command, []string{"the", "new", "command"})
 	assert.EqualStringSlice(t, cspec.Args, []string{"the", "new args"})
 }
+
+func TestUpdateServiceUpdateConfig(t *testing.T) {
+	flags := newUpdateCommand(nil).Flags()
+	flags.Set("update-monitor", "10s")
+	flags.Set("update-failure-action", "rollback")
+
+	spec := &swarm.ServiceSpec{
+		UpdateConfig: &swarm.UpdateConfig{Parallelism: 2},
+	}
+
+	updateService(flags, spec)
+	assert.Equal(t, spec.UpdateConfig.Parallelism, uint64(2))
+	assert.Equal(t, spec.UpdateConfig.Monitor, 10*time.Second)
+	assert.Equal(t, spec.UpdateConfig.FailureAction, swarm.UpdateFailureActionRollback)
+}
@@ -21,8 +21,9 @@ const (
 )
 
 type deployOptions struct {
-	bundlefile string
-	namespace  string
+	bundlefile  string
+	composefile string
+	namespace   string
 }
 
 func newDeployCommand(dockerCli *client.DockerCli) *cobra.Command {
@@ -31,7 +32,7 @@ func newDeployCommand(dockerCli *client.DockerCli) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "deploy [OPTIONS] STACK",
 		Aliases: []string{"up"},
-		Short:   "Create and update a stack from a Distributed Application Bundle (DAB)",
+		Short:   "Create and update a stack from a Distributed Application Bundle (DAB) or a Compose file",
 		Args:    cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.namespace = args[0]
@@ -41,22 +42,42 @@ func newDeployCommand(dockerCli *client.DockerCli) *cobra.Command {
 
 	flags := cmd.Flags()
 	addBundlefileFlag(&opts.bundlefile, flags)
+	addComposefileFlag(&opts.composefile, flags)
 	return cmd
 }
 
 func runDeploy(dockerCli *client.DockerCli, opts deployOptions) error {
+	if opts.bundlefile != "" && opts.composefile != "" {
+		return fmt.Errorf("You cannot specify both a bundle file and a Compose file.")
+	}
+
+	ctx := context.Background()
+	if opts.composefile != "" {
+		return deployCompose(ctx, dockerCli, opts)
+	}
+	return deployBundle(ctx, dockerCli, opts)
+}
+
+func deployBundle(ctx context.Context, dockerCli *client.DockerCli, opts deployOptions) error {
 	bundle, err := loadBundlefile(dockerCli.Err(), opts.namespace, opts.bundlefile)
 	if err != nil {
 		return err
 	}
 
-	networks := getUniqueNetworkNames(bundle.Services)
-	ctx := context.Background()
+	networks := make(map[string]types.NetworkCreate)
+	for _, name := range getUniqueNetworkNames(bundle.Services) {
+		networks[name] = types.NetworkCreate{
+			Labels: getStackLabels(opts.namespace, nil),
+			Driver: defaultNetworkDriver,
+			// TODO: remove when engine-api uses omitempty for IPAM
+			IPAM: network.IPAM{Driver: "default"},
+		}
+	}
 
-	if err := updateNetworks(ctx, dockerCli, networks, opts.namespace); err != nil {
+	if err := createNetworks(ctx, dockerCli, networks, opts.namespace); err != nil {
 		return err
 	}
-	return deployServices(ctx, dockerCli, bundle.Services, opts.namespace)
+	return deployServices(ctx, dockerCli, convertBundleServices(bundle.Services, opts.namespace), opts.namespace)
 }
 
 func getUniqueNetworkNames(services map[string]bundlefile.Service) []string {
@@ -74,10 +95,12 @@ func getUniqueNetworkNames(services map[string]bundlefile.Service) []string {
 	return networks
 }
 
-func updateNetworks(
+// createNetworks creates the networks of the stack which do not exist yet.
+// The networks are keyed by their name in the stack, without the namespace.
+func createNetworks(
 	ctx context.Context,
 	dockerCli *client.DockerCli,
-	networks []string,
+	networks map[string]types.NetworkCreate,
 	namespace string,
 ) error {
 	client := dockerCli.Client()
@@ -92,14 +115,7 @@ func updateNetworks(
 		existingNetworkMap[network.Name] = network
 	}
 
-	createOpts := types.NetworkCreate{
-		Labels: getStackLabels(namespace, nil),
-		Driver: defaultNetworkDriver,
-		// TODO: remove when engine-api uses omitempty for IPAM
-		IPAM: network.IPAM{Driver: "default"},
-	}
-
-	for _, internalName := range networks {
+	for internalName, createOpts := range networks {
 		name := fmt.Sprintf("%s_%s", namespace, internalName)
 
 		if _, exists := existingNetworkMap[name]; exists {
@@ -124,25 +140,8 @@ func convertNetworks(networks []string, namespace string, name string) []swarm.N
 	return nets
 }
 
-func deployServices(
-	ctx context.Context,
-	dockerCli *client.DockerCli,
-	services map[string]bundlefile.Service,
-	namespace string,
-) error {
-	apiClient := dockerCli.Client()
-	out := dockerCli.Out()
-
-	existingServices, err := getServices(ctx, apiClient, namespace)
-	if err != nil {
-		return err
-	}
-
-	existingServiceMap := make(map[string]swarm.Service)
-	for _, service := range existingServices {
-		existingServiceMap[service.Spec.Name] = service
-	}
-
+func convertBundleServices(services map[string]bundlefile.Service, namespace string) map[string]swarm.ServiceSpec {
+	specs := make(map[string]swarm.ServiceSpec)
 	for internalName, service := range services {
 		name := fmt.Sprintf("%s_%s", namespace, internalName)
 
@@ -180,6 +179,34 @@ func deployServices(
 		if service.User != nil {
 			cspec.User = *service.User
 		}
+		specs[internalName] = serviceSpec
+	}
+	return specs
+}
+
+// deployServices creates or updates the services of the stack. The services
+// are keyed by their name in the stack, without the namespace.
+func deployServices(
+	ctx context.Context,
+	dockerCli *client.DockerCli,
+	services map[string]swarm.ServiceSpec,
+	namespace string,
+) error {
+	apiClient := dockerCli.Client()
+	out := dockerCli.Out()
+
+	existingServices, err := getServices(ctx, apiClient, namespace)
+	if err != nil {
+		return err
+	}
+
+	existingServiceMap := make(map[string]swarm.Service)
+	for _, service := range existingServices {
+		existingServiceMap[service.Spec.Name] = service
+	}
+
+	for internalName, serviceSpec := range services {
+		name := fmt.Sprintf("%s_%s", namespace, internalName)
 
 		if service, exists := existingServiceMap[name]; exists {
 			fmt.Fprintf(out, "Updating service %s (id: %s)\n", name, service.ID)
@@ -0,0 +1,410 @@
+// +build experimental
+
+package stack
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/api/client/composefile"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/engine-api/types/network"
+	"github.com/docker/engine-api/types/swarm"
+	"github.com/docker/go-connections/nat"
+	units "github.com/docker/go-units"
+)
+
+const (
+	defaultNetwork = "default"
+)
+
+func deployCompose(ctx context.Context, dockerCli *client.DockerCli, opts deployOptions) error {
+	config, err := loadComposefile(dockerCli.Err(), opts.composefile)
+	if err != nil {
+		return err
+	}
+
+	if len(config.Ignored) > 0 {
+		fmt.Fprintf(dockerCli.Err(), "Ignoring unsupported options: %s\n\n", strings.Join(config.Ignored, ", "))
+	}
+
+	networks, externalNetworks, err := convertComposeNetworks(config, opts.namespace)
+	if err != nil {
+		return err
+	}
+	if err := validateExternalNetworks(ctx, dockerCli, externalNetworks); err != nil {
+		return err
+	}
+	if err := createNetworks(ctx, dockerCli, networks, opts.namespace); err != nil {
+		return err
+	}
+
+	services, err := convertComposeServices(config, opts.namespace)
+	if err != nil {
+		return err
+	}
+	return deployServices(ctx, dockerCli, services, opts.namespace)
+}
+
+// serviceNetworks returns the networks a service is attached to, which is
+// the default network if none is given.
+func serviceNetworks(service composefile.ServiceConfig) composefile.ServiceNetworks {
+	if len(service.Networks) == 0 {
+		return composefile.ServiceNetworks{defaultNetwork: nil}
+	}
+	return service.Networks
+}
+
+// convertComposeNetworks returns the networks used by the services which
+// must be created, keyed by their name in the stack, and the names of the
+// external networks, which must already exist. Only the default network may
+// be used without being declared.
+func convertComposeNetworks(config *composefile.Config, namespace string) (map[string]types.NetworkCreate, []string, error) {
+	used := make(map[string]bool)
+	for serviceName, service := range config.Services {
+		for name := range serviceNetworks(service) {
+			if _, ok := config.Networks[name]; !ok && name != defaultNetwork {
+				return nil, nil, fmt.Errorf("Service %s: undefined network %q", serviceName, name)
+			}
+			used[name] = true
+		}
+	}
+
+	networks := make(map[string]types.NetworkCreate)
+	var externalNetworks []string
+	for name := range used {
+		networkConfig := config.Networks[name]
+		if networkConfig.External.External {
+			externalNetworks = append(externalNetworks, externalName(name, networkConfig.External))
+			continue
+		}
+
+		createOpts := types.NetworkCreate{
+			Labels:   getStackLabels(namespace, networkConfig.Labels.Map()),
+			Driver:   networkConfig.Driver,
+			Options:  networkConfig.DriverOpts,
+			Internal: networkConfig.Internal,
+			// TODO: remove when engine-api uses omitempty for IPAM
+			IPAM: network.IPAM{Driver: "default"},
+		}
+		if createOpts.Driver == "" {
+			createOpts.Driver = defaultNetworkDriver
+		}
+		if networkConfig.Ipam.Driver != "" {
+			createOpts.IPAM.Driver = networkConfig.Ipam.Driver
+		}
+		for _, pool := range networkConfig.Ipam.Config {
+			createOpts.IPAM.Config = append(createOpts.IPAM.Config, network.IPAMConfig{
+				Subnet: pool.Subnet,
+			})
+		}
+		networks[name] = createOpts
+	}
+	sort.Strings(externalNetworks)
+	return networks, externalNetworks, nil
+}
+
+func validateExternalNetworks(ctx context.Context, dockerCli *client.DockerCli, externalNetworks []string) error {
+	client := dockerCli.Client()
+
+	for _, name := range externalNetworks {
+		network, err := client.NetworkInspect(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Network %s could not be found: %v", name, err)
+		}
+		if network.Scope != "swarm" {
+			return fmt.Errorf("Network %s is not in the swarm scope, but %s", name, network.Scope)
+		}
+	}
+	return nil
+}
+
+func externalName(name string, external composefile.External) string {
+	if external.Name != "" {
+		return external.Name
+	}
+	return name
+}
+
+func convertComposeServices(config *composefile.Config, namespace string) (map[string]swarm.ServiceSpec, error) {
+	specs := make(map[string]swarm.ServiceSpec)
+	for name, service := range config.Services {
+		spec, err := convertComposeService(config, namespace, name, service)
+		if err != nil {
+			return nil, fmt.Errorf("Service %s: %v", name, err)
+		}
+		specs[name] = spec
+	}
+	return specs, nil
+}
+
+func convertComposeService(
+	config *composefile.Config,
+	namespace string,
+	name string,
+	service composefile.ServiceConfig,
+) (swarm.ServiceSpec, error) {
+	mounts, err := convertVolumes(config, namespace, service.Volumes)
+	if err != nil {
+		return swarm.ServiceSpec{}, err
+	}
+	endpoint, err := convertPorts(service.Ports)
+	if err != nil {
+		return swarm.ServiceSpec{}, err
+	}
+	mode, err := convertServiceMode(service.Deploy)
+	if err != nil {
+		return swarm.ServiceSpec{}, err
+	}
+	resources, err := convertResources(service.Deploy.Resources)
+	if err != nil {
+		return swarm.ServiceSpec{}, err
+	}
+	restartPolicy := convertRestartPolicy(service.Deploy.RestartPolicy)
+
+	spec := swarm.ServiceSpec{
+		Annotations: swarm.Annotations{
+			Name:   namespace + "_" + name,
+			Labels: getStackLabels(namespace, service.Deploy.Labels.Map()),
+		},
+		TaskTemplate: swarm.TaskSpec{
+			ContainerSpec: swarm.ContainerSpec{
+				Image:   service.Image,
+				Command: service.Entrypoint,
+				Args:    service.Command,
+				Env:     service.Environment.List(os.LookupEnv),
+				Labels:  getStackLabels(namespace, service.Labels.Map()),
+				Dir:     service.WorkingDir,
+				User:    service.User,
+				Mounts:  mounts,
+			},
+			Resources:     resources,
+			RestartPolicy: restartPolicy,
+			Placement: &swarm.Placement{
+				Constraints: service.Deploy.Placement.Constraints,
+			},
+		},
+		Mode:         mode,
+		EndpointSpec: endpoint,
+		Networks:     convertServiceNetworks(config, namespace, name, service),
+	}
+
+	if service.StopGracePeriod != nil {
+		stopGracePeriod := time.Duration(*service.StopGracePeriod)
+		spec.TaskTemplate.ContainerSpec.StopGracePeriod = &stopGracePeriod
+	}
+	if service.Deploy.UpdateConfig != nil {
+		spec.UpdateConfig = &swarm.UpdateConfig{
+			Parallelism: service.Deploy.UpdateConfig.Parallelism,
+			Delay:       time.Duration(service.Deploy.UpdateConfig.Delay),
+		}
+	}
+	return spec, nil
+}
+
+func convertServiceNetworks(
+	config *composefile.Config,
+	namespace string,
+	name string,
+	service composefile.ServiceConfig,
+) []swarm.NetworkAttachmentConfig {
+	nets := []swarm.NetworkAttachmentConfig{}
+	for networkName, networkConfig := range serviceNetworks(service) {
+		aliases := []string{name}
+		if networkConfig != nil {
+			aliases = append(aliases, networkConfig.Aliases...)
+		}
+
+		target := namespace + "_" + networkName
+		if external := config.Networks[networkName].External; external.External {
+			target = externalName(networkName, external)
+		}
+		nets = append(nets, swarm.NetworkAttachmentConfig{
+			Target:  target,
+			Aliases: aliases,
+		})
+	}
+	sort.Sort(byNetworkTarget(nets))
+	return nets
+}
+
+type byNetworkTarget []swarm.NetworkAttachmentConfig
+
+func (n byNetworkTarget) Len() int           { return len(n) }
+func (n byNetworkTarget) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+func (n byNetworkTarget) Less(i, j int) bool { return n[i].Target < n[j].Target }
+
+// convertVolumes converts the volumes of a service, written as
+// [SOURCE:]TARGET[:MODE], to mounts. A source which is an absolute path is
+// bind mounted, any other source is a named volume of the stack.
+func convertVolumes(config *composefile.Config, namespace string, volumes []string) ([]swarm.Mount, error) {
+	var mounts []swarm.Mount
+	for _, volumeSpec := range volumes {
+		parts := strings.Split(volumeSpec, ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid volume %q", volumeSpec)
+		}
+
+		mount := swarm.Mount{
+			Type:     swarm.MountTypeVolume,
+			Target:   parts[len(parts)-1],
+			Writable: true,
+		}
+		if len(parts) > 1 {
+			mount.Source = parts[0]
+			mount.Target = parts[1]
+		}
+		if len(parts) == 3 {
+			switch parts[2] {
+			case "ro":
+				mount.Writable = false
+			case "rw":
+			default:
+				return nil, fmt.Errorf("invalid mode %q for volume %q", parts[2], volumeSpec)
+			}
+		}
+
+		switch {
+		case mount.Source == "":
+		case strings.HasPrefix(mount.Source, "/"):
+			mount.Type = swarm.MountTypeBind
+		case strings.HasPrefix(mount.Source, "."), strings.HasPrefix(mount.Source, "~"):
+			return nil, fmt.Errorf("relative path %q is not supported for volume %q", mount.Source, volumeSpec)
+		default:
+			volumeConfig, ok := config.Volumes[mount.Source]
+			if !ok {
+				return nil, fmt.Errorf("undefined volume %q", mount.Source)
+			}
+			if volumeConfig.External.External {
+				mount.Source = externalName(mount.Source, volumeConfig.External)
+				break
+			}
+			mount.Source = namespace + "_" + mount.Source
+			mount.VolumeOptions = &swarm.VolumeOptions{
+				Labels: getStackLabels(namespace, volumeConfig.Labels.Map()),
+			}
+			if volumeConfig.Driver != "" {
+				mount.VolumeOptions.DriverConfig = &swarm.Driver{
+					Name:    volumeConfig.Driver,
+					Options: volumeConfig.DriverOpts,
+				}
+			}
+		}
+		mounts = append(mounts, mount)
+	}
+	return mounts, nil
+}
+
+func convertPorts(portSpecs []string) (*swarm.EndpointSpec, error) {
+	ports, portBindings, err := nat.ParsePortSpecs(portSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	portConfigs := []swarm.PortConfig{}
+	for port := range ports {
+		for _, binding := range portBindings[port] {
+			if binding.HostIP != "" {
+				return nil, fmt.Errorf("HostIP is not supported by a service.")
+			}
+			portConfig := swarm.PortConfig{
+				Protocol:   swarm.PortConfigProtocol(strings.ToLower(port.Proto())),
+				TargetPort: uint32(port.Int()),
+			}
+			if binding.HostPort != "" {
+				hostPort, err := strconv.ParseUint(binding.HostPort, 10, 16)
+				if err != nil {
+					return nil, err
+				}
+				portConfig.PublishedPort = uint32(hostPort)
+			}
+			portConfigs = append(portConfigs, portConfig)
+		}
+	}
+	return &swarm.EndpointSpec{Ports: portConfigs}, nil
+}
+
+func convertServiceMode(deploy composefile.DeployConfig) (swarm.ServiceMode, error) {
+	switch deploy.Mode {
+	case "global":
+		if deploy.Replicas != nil {
+			return swarm.ServiceMode{}, fmt.Errorf("replicas can only be used with replicated mode")
+		}
+		return swarm.ServiceMode{Global: &swarm.GlobalService{}}, nil
+	case "", "replicated":
+		return swarm.ServiceMode{Replicated: &swarm.ReplicatedService{Replicas: deploy.Replicas}}, nil
+	default:
+		return swarm.ServiceMode{}, fmt.Errorf("unknown mode %q", deploy.Mode)
+	}
+}
+
+func convertResources(resources composefile.Resources) (*swarm.ResourceRequirements, error) {
+	var (
+		requirements swarm.ResourceRequirements
+		err          error
+	)
+	if requirements.Limits, err = convertResource(resources.Limits); err != nil {
+		return nil, err
+	}
+	if requirements.Reservations, err = convertResource(resources.Reservations); err != nil {
+		return nil, err
+	}
+	return &requirements, nil
+}
+
+func convertResource(resource *composefile.Resource) (*swarm.Resources, error) {
+	if resource == nil {
+		return nil, nil
+	}
+
+	var converted swarm.Resources
+	if resource.CPUs != "" {
+		cpus, ok := new(big.Rat).SetString(resource.CPUs)
+		if !ok {
+			return nil, fmt.Errorf("Failed to parse %v as a rational number", resource.CPUs)
+		}
+		nano := cpus.Mul(cpus, big.NewRat(1e9, 1))
+		if !nano.IsInt() {
+			return nil, fmt.Errorf("value is too precise")
+		}
+		converted.NanoCPUs = nano.Num().Int64()
+	}
+	if resource.Memory != "" {
+		memory, err := units.RAMInBytes(resource.Memory)
+		if err != nil {
+			return nil, err
+		}
+		converted.MemoryBytes = memory
+	}
+	return &converted, nil
+}
+
+func convertRestartPolicy(policy *composefile.RestartPolicy) *swarm.RestartPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	converted := &swarm.RestartPolicy{
+		// Compose files write the conditions with dashes, such as
+		// on-failure, where swarm uses underscores.
+		Condition:   swarm.RestartPolicyCondition(strings.Replace(policy.Condition, "-", "_", -1)),
+		MaxAttempts: policy.MaxAttempts,
+	}
+	if policy.Delay != nil {
+		delay := time.Duration(*policy.Delay)
+		converted.Delay = &delay
+	}
+	if policy.Window != nil {
+		window := time.Duration(*policy.Window)
+		converted.Window = &window
+	}
+	return converted
+}
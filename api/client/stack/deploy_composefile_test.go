@@ -0,0 +1,229 @@
+// +build experimental
+
+package stack
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/client/composefile"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/engine-api/types/network"
+	"github.com/docker/engine-api/types/swarm"
+)
+
+func TestConvertComposeNetworks(t *testing.T) {
+	front := "front"
+	config := &composefile.Config{
+		Services: map[string]composefile.ServiceConfig{
+			"web": {Networks: composefile.ServiceNetworks{"front": nil, "outside": nil}},
+			"db":  {},
+		},
+		Networks: map[string]composefile.NetworkConfig{
+			"front":   {Driver: "bridge", Labels: composefile.MappingWithEquals{"tier": &front}},
+			"outside": {External: composefile.External{External: true, Name: "public"}},
+			"unused":  {},
+		},
+	}
+
+	networks, externalNetworks, err := convertComposeNetworks(config, "stack")
+	assert.NilError(t, err)
+	expected := map[string]types.NetworkCreate{
+		"default": {
+			Labels: map[string]string{labelNamespace: "stack"},
+			Driver: defaultNetworkDriver,
+			IPAM:   network.IPAM{Driver: "default"},
+		},
+		"front": {
+			Labels: map[string]string{labelNamespace: "stack", "tier": "front"},
+			Driver: "bridge",
+			IPAM:   network.IPAM{Driver: "default"},
+		},
+	}
+	if !reflect.DeepEqual(networks, expected) {
+		t.Fatalf("Expected networks %+v, got %+v", expected, networks)
+	}
+	assert.EqualStringSlice(t, externalNetworks, []string{"public"})
+}
+
+func TestConvertComposeNetworksUndefined(t *testing.T) {
+	config := &composefile.Config{
+		Services: map[string]composefile.ServiceConfig{
+			"web": {Networks: composefile.ServiceNetworks{"back": nil}},
+		},
+	}
+
+	_, _, err := convertComposeNetworks(config, "stack")
+	assert.Error(t, err, "Service web: undefined network \"back\"")
+}
+
+func TestConvertServiceNetworks(t *testing.T) {
+	config := &composefile.Config{
+		Networks: map[string]composefile.NetworkConfig{
+			"front":   {},
+			"outside": {External: composefile.External{External: true, Name: "public"}},
+		},
+	}
+
+	cases := []struct {
+		service  composefile.ServiceConfig
+		expected []swarm.NetworkAttachmentConfig
+	}{
+		{
+			service: composefile.ServiceConfig{},
+			expected: []swarm.NetworkAttachmentConfig{
+				{Target: "stack_default", Aliases: []string{"web"}},
+			},
+		},
+		{
+			service: composefile.ServiceConfig{
+				Networks: composefile.ServiceNetworks{
+					"front":   &composefile.ServiceNetworkConfig{Aliases: []string{"www"}},
+					"outside": nil,
+				},
+			},
+			expected: []swarm.NetworkAttachmentConfig{
+				{Target: "public", Aliases: []string{"web"}},
+				{Target: "stack_front", Aliases: []string{"web", "www"}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		nets := convertServiceNetworks(config, "stack", "web", c.service)
+		if !reflect.DeepEqual(nets, c.expected) {
+			t.Fatalf("Expected networks %+v for %+v, got %+v", c.expected, c.service.Networks, nets)
+		}
+	}
+}
+
+func TestConvertVolumes(t *testing.T) {
+	config := &composefile.Config{
+		Volumes: map[string]composefile.VolumeConfig{
+			"data":   {Driver: "nfs", DriverOpts: map[string]string{"share": "data"}},
+			"shared": {External: composefile.External{External: true, Name: "global"}},
+		},
+	}
+
+	cases := []struct {
+		volume   string
+		expected swarm.Mount
+		err      string
+	}{
+		{
+			volume:   "/var/lib/data",
+			expected: swarm.Mount{Type: swarm.MountTypeVolume, Target: "/var/lib/data", Writable: true},
+		},
+		{
+			volume:   "/srv:/var/www:ro",
+			expected: swarm.Mount{Type: swarm.MountTypeBind, Source: "/srv", Target: "/var/www"},
+		},
+		{
+			volume: "data:/data",
+			expected: swarm.Mount{
+				Type:     swarm.MountTypeVolume,
+				Source:   "stack_data",
+				Target:   "/data",
+				Writable: true,
+				VolumeOptions: &swarm.VolumeOptions{
+					Labels:       map[string]string{labelNamespace: "stack"},
+					DriverConfig: &swarm.Driver{Name: "nfs", Options: map[string]string{"share": "data"}},
+				},
+			},
+		},
+		{
+			volume:   "shared:/shared:rw",
+			expected: swarm.Mount{Type: swarm.MountTypeVolume, Source: "global", Target: "/shared", Writable: true},
+		},
+		{volume: "./data:/data", err: "relative path \"./data\" is not supported"},
+		{volume: "other:/data", err: "undefined volume \"other\""},
+		{volume: "/srv:/var/www:z", err: "invalid mode \"z\""},
+		{volume: "a:b:c:d", err: "invalid volume \"a:b:c:d\""},
+	}
+
+	for _, c := range cases {
+		mounts, err := convertVolumes(config, "stack", []string{c.volume})
+		if c.err != "" {
+			assert.Error(t, err, c.err)
+			continue
+		}
+		assert.NilError(t, err)
+		if len(mounts) != 1 || !reflect.DeepEqual(mounts[0], c.expected) {
+			t.Fatalf("Expected mount %+v for %q, got %+v", c.expected, c.volume, mounts)
+		}
+	}
+}
+
+func TestConvertPorts(t *testing.T) {
+	cases := []struct {
+		port     string
+		expected swarm.PortConfig
+		err      string
+	}{
+		{port: "80", expected: swarm.PortConfig{Protocol: swarm.PortConfigProtocolTCP, TargetPort: 80}},
+		{port: "8080:80", expected: swarm.PortConfig{Protocol: swarm.PortConfigProtocolTCP, TargetPort: 80, PublishedPort: 8080}},
+		{port: "53:53/udp", expected: swarm.PortConfig{Protocol: swarm.PortConfigProtocolUDP, TargetPort: 53, PublishedPort: 53}},
+		{port: "127.0.0.1:8080:80", err: "HostIP is not supported"},
+		{port: "http", err: "Invalid containerPort"},
+	}
+
+	for _, c := range cases {
+		endpoint, err := convertPorts([]string{c.port})
+		if c.err != "" {
+			assert.Error(t, err, c.err)
+			continue
+		}
+		assert.NilError(t, err)
+		if len(endpoint.Ports) != 1 || endpoint.Ports[0] != c.expected {
+			t.Fatalf("Expected port %+v for %q, got %+v", c.expected, c.port, endpoint.Ports)
+		}
+	}
+}
+
+func TestConvertResources(t *testing.T) {
+	cases := []struct {
+		resources composefile.Resources
+		expected  swarm.ResourceRequirements
+		err       string
+	}{
+		{
+			resources: composefile.Resources{},
+			expected:  swarm.ResourceRequirements{},
+		},
+		{
+			resources: composefile.Resources{
+				Limits:       &composefile.Resource{CPUs: "0.5", Memory: "512m"},
+				Reservations: &composefile.Resource{CPUs: "2"},
+			},
+			expected: swarm.ResourceRequirements{
+				Limits:       &swarm.Resources{NanoCPUs: 5e8, MemoryBytes: 512 * 1024 * 1024},
+				Reservations: &swarm.Resources{NanoCPUs: 2e9},
+			},
+		},
+		{
+			resources: composefile.Resources{Limits: &composefile.Resource{CPUs: "half"}},
+			err:       "Failed to parse half as a rational number",
+		},
+		{
+			resources: composefile.Resources{Limits: &composefile.Resource{CPUs: "0.0000000001"}},
+			err:       "value is too precise",
+		},
+		{
+			resources: composefile.Resources{Reservations: &composefile.Resource{Memory: "lots"}},
+			err:       "invalid size: 'lots'",
+		},
+	}
+
+	for _, c := range cases {
+		requirements, err := convertResources(c.resources)
+		if c.err != "" {
+			assert.Error(t, err, c.err)
+			continue
+		}
+		assert.NilError(t, err)
+		if !reflect.DeepEqual(*requirements, c.expected) {
+			t.Fatalf("Expected resources %+v, got %+v", c.expected, *requirements)
+		}
+	}
+}
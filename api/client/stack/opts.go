@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/docker/docker/api/client/bundlefile"
+	"github.com/docker/docker/api/client/composefile"
 	"github.com/spf13/pflag"
 )
 
@@ -42,3 +43,25 @@ func loadBundlefile(stderr io.Writer, namespace string, path string) (*bundlefil
 	}
 	return bundle, err
 }
+
+func addComposefileFlag(opt *string, flags *pflag.FlagSet) {
+	flags.StringVarP(
+		opt,
+		"compose-file", "c", "",
+		"Path to a Compose file")
+}
+
+func loadComposefile(stderr io.Writer, path string) (*composefile.Config, error) {
+	fmt.Fprintf(stderr, "Loading Compose file from %s\n", path)
+	reader, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	config, err := composefile.LoadFile(reader, os.LookupEnv)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading %s: %v\n", path, err)
+	}
+	return config, nil
+}
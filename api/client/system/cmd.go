@@ -0,0 +1,29 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+)
+
+// NewSystemCommand returns a cobra command for `system` subcommands
+func NewSystemCommand(dockerCli *client.DockerCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "system",
+		Short: "Manage Docker",
+		Args:  cli.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprint(dockerCli.Err(), "\n"+cmd.UsageString())
+		},
+	}
+	cmd.AddCommand(
+		NewEventsCommand(dockerCli),
+		NewInfoCommand(dockerCli),
+		newDiskUsageCommand(dockerCli),
+		newPruneCommand(dockerCli),
+	)
+	return cmd
+}
@@ -0,0 +1,192 @@
+package system
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/pkg/stringutils"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+type diskUsageOptions struct {
+	verbose bool
+}
+
+func newDiskUsageCommand(dockerCli *client.DockerCli) *cobra.Command {
+	var opts diskUsageOptions
+
+	cmd := &cobra.Command{
+		Use:   "df [OPTIONS]",
+		Short: "Show docker disk usage",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiskUsage(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.verbose, "verbose", "v", false, "Show detailed information on space usage")
+
+	return cmd
+}
+
+func runDiskUsage(dockerCli *client.DockerCli, opts diskUsageOptions) error {
+	du, err := dockerCli.Client().DiskUsage(context.Background())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	if opts.verbose {
+		printVerboseDiskUsage(w, du)
+	} else {
+		printDiskUsage(w, du)
+	}
+	w.Flush()
+	return nil
+}
+
+func printDiskUsage(w io.Writer, du types.DiskUsage) {
+	fmt.Fprintf(w, "TYPE\tTOTAL\tACTIVE\tSIZE\tRECLAIMABLE\n")
+
+	var activeImages int
+	var usedImagesSize int64
+	for _, i := range du.Images {
+		if i.Containers > 0 {
+			activeImages++
+			if i.SharedSize != -1 {
+				usedImagesSize += i.Size - i.SharedSize
+			}
+		}
+	}
+	fmt.Fprintf(w, "Images\t%d\t%d\t%s\t%s\n",
+		len(du.Images), activeImages,
+		units.HumanSize(float64(du.LayersSize)),
+		reclaimableSize(du.LayersSize-usedImagesSize, du.LayersSize))
+
+	var activeContainers int
+	var containersSize, reclaimableContainersSize int64
+	for _, c := range du.Containers {
+		containersSize += c.SizeRw
+		if c.State == "running" || c.State == "paused" || c.State == "restarting" {
+			activeContainers++
+		} else {
+			reclaimableContainersSize += c.SizeRw
+		}
+	}
+	fmt.Fprintf(w, "Containers\t%d\t%d\t%s\t%s\n",
+		len(du.Containers), activeContainers,
+		units.HumanSize(float64(containersSize)),
+		reclaimableSize(reclaimableContainersSize, containersSize))
+
+	var activeVolumes int
+	var volumesSize, reclaimableVolumesSize int64
+	for _, v := range du.Volumes {
+		if v.UsageData == nil {
+			continue
+		}
+		if v.UsageData.RefCount > 0 {
+			activeVolumes++
+		}
+		if v.UsageData.Size == -1 {
+			continue
+		}
+		volumesSize += v.UsageData.Size
+		if v.UsageData.RefCount == 0 {
+			reclaimableVolumesSize += v.UsageData.Size
+		}
+	}
+	fmt.Fprintf(w, "Local Volumes\t%d\t%d\t%s\t%s\n",
+		len(du.Volumes), activeVolumes,
+		units.HumanSize(float64(volumesSize)),
+		reclaimableSize(reclaimableVolumesSize, volumesSize))
+
+	// The layers of the build cache are shared with the images built from
+	// them, and are only removed with those images.
+	fmt.Fprintf(w, "Build Cache\t\t\t%s\t%s\n",
+		units.HumanSize(float64(du.BuilderSize)),
+		reclaimableSize(du.BuilderReclaimable, du.BuilderSize))
+}
+
+// reclaimableSize formats the reclaimable part of total as a human readable
+// size followed by its percentage of total.
+func reclaimableSize(reclaimable, total int64) string {
+	if total <= 0 {
+		return units.HumanSize(float64(reclaimable))
+	}
+	return fmt.Sprintf("%s (%d%%)", units.HumanSize(float64(reclaimable)), reclaimable*100/total)
+}
+
+func printVerboseDiskUsage(w io.Writer, du types.DiskUsage) {
+	fmt.Fprintf(w, "Images space usage:\n\n")
+	fmt.Fprintf(w, "REPOSITORY\tTAG\tIMAGE ID\tCREATED\tSIZE\tSHARED SIZE\tUNIQUE SIZE\tCONTAINERS\n")
+	for _, i := range du.Images {
+		repo, tag := "<none>", "<none>"
+		if len(i.RepoTags) > 0 {
+			if idx := strings.LastIndex(i.RepoTags[0], ":"); idx != -1 {
+				repo, tag = i.RepoTags[0][:idx], i.RepoTags[0][idx+1:]
+			}
+		}
+		sharedSize, uniqueSize := "N/A", "N/A"
+		if i.SharedSize != -1 {
+			sharedSize = units.HumanSize(float64(i.SharedSize))
+			uniqueSize = units.HumanSize(float64(i.Size - i.SharedSize))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s ago\t%s\t%s\t%s\t%d\n",
+			repo, tag,
+			stringid.TruncateID(i.ID),
+			units.HumanDuration(time.Now().UTC().Sub(time.Unix(i.Created, 0))),
+			units.HumanSize(float64(i.Size)),
+			sharedSize, uniqueSize,
+			i.Containers)
+	}
+
+	fmt.Fprintf(w, "\nContainers space usage:\n\n")
+	fmt.Fprintf(w, "CONTAINER ID\tIMAGE\tCOMMAND\tLOCAL VOLUMES\tSIZE\tCREATED\tSTATUS\tNAMES\n")
+	for _, c := range du.Containers {
+		var localVolumes int
+		for _, m := range c.Mounts {
+			if m.Driver == "local" {
+				localVolumes++
+			}
+		}
+		var name string
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%q\t%d\t%s\t%s ago\t%s\t%s\n",
+			stringid.TruncateID(c.ID),
+			c.Image,
+			stringutils.Truncate(c.Command, 20),
+			localVolumes,
+			units.HumanSize(float64(c.SizeRw)),
+			units.HumanDuration(time.Now().UTC().Sub(time.Unix(c.Created, 0))),
+			c.Status,
+			name)
+	}
+
+	fmt.Fprintf(w, "\nLocal Volumes space usage:\n\n")
+	fmt.Fprintf(w, "VOLUME NAME\tLINKS\tSIZE\n")
+	for _, v := range du.Volumes {
+		links, size := "N/A", "N/A"
+		if v.UsageData != nil {
+			links = fmt.Sprintf("%d", v.UsageData.RefCount)
+			if v.UsageData.Size != -1 {
+				size = units.HumanSize(float64(v.UsageData.Size))
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", v.Name, links, size)
+	}
+
+	fmt.Fprintf(w, "\nBuild cache usage: %s\n", units.HumanSize(float64(du.BuilderSize)))
+}
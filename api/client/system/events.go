@@ -75,7 +75,7 @@ func runEvents(dockerCli *client.DockerCli, opts *eventsOptions) error {
 
 // streamEvents decodes prints the incoming events in the provided output.
 func streamEvents(input io.Reader, output io.Writer) error {
-	return DecodeEvents(input, func(event eventtypes.Message, err error) error {
+	return client.DecodeEvents(input, func(event eventtypes.Message, err error) error {
 		if err != nil {
 			return err
 		}
@@ -84,8 +84,6 @@ func streamEvents(input io.Reader, output io.Writer) error {
 	})
 }
 
-type eventProcessor func(event eventtypes.Message, err error) error
-
 // printOutput prints all types of event information.
 // Each output includes the event type, actor id, name and action.
 // Actor attributes are printed at the end if the actor has any.
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPromptForConfirmation(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected bool
+	}{
+		{"y\n", true},
+		{"Y\n", true},
+		{"n\n", false},
+		{"yes\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		out := new(bytes.Buffer)
+		if actual := PromptForConfirmation(strings.NewReader(c.input), out, ""); actual != c.expected {
+			t.Fatalf("Expected %v for input %q, got %v", c.expected, c.input, actual)
+		}
+		if expected := "Are you sure you want to proceed? [y/N] "; out.String() != expected {
+			t.Fatalf("Expected prompt %q, got %q", expected, out.String())
+		}
+	}
+}
@@ -3,11 +3,11 @@ package volume
 import (
 	"fmt"
 	"sort"
-	"text/tabwriter"
 
 	"golang.org/x/net/context"
 
 	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/cli"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/engine-api/types/filters"
@@ -24,6 +24,7 @@ func (r byVolumeName) Less(i, j int) bool {
 
 type listOptions struct {
 	quiet  bool
+	format string
 	filter []string
 }
 
@@ -42,6 +43,7 @@ func newListCommand(dockerCli *client.DockerCli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display volume names")
+	flags.StringVar(&opts.format, "format", "", "Pretty-print volumes using a Go template")
 	flags.StringSliceVarP(&opts.filter, "filter", "f", []string{}, "Provide filter values (i.e. 'dangling=true')")
 
 	return cmd
@@ -64,23 +66,33 @@ func runList(dockerCli *client.DockerCli, opts listOptions) error {
 		return err
 	}
 
-	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
 	if !opts.quiet {
 		for _, warn := range volumes.Warnings {
 			fmt.Fprintln(dockerCli.Err(), warn)
 		}
-		fmt.Fprintf(w, "DRIVER \tVOLUME NAME")
-		fmt.Fprintf(w, "\n")
 	}
 
-	sort.Sort(byVolumeName(volumes.Volumes))
-	for _, vol := range volumes.Volumes {
-		if opts.quiet {
-			fmt.Fprintln(w, vol.Name)
-			continue
+	f := opts.format
+	if len(f) == 0 {
+		if len(dockerCli.VolumesFormat()) > 0 && !opts.quiet {
+			f = dockerCli.VolumesFormat()
+		} else {
+			f = "table"
 		}
-		fmt.Fprintf(w, "%s\t%s\n", vol.Driver, vol.Name)
 	}
-	w.Flush()
+
+	sort.Sort(byVolumeName(volumes.Volumes))
+
+	volumeCtx := formatter.VolumeContext{
+		Context: formatter.Context{
+			Output: dockerCli.Out(),
+			Format: f,
+			Quiet:  opts.quiet,
+		},
+		Volumes: volumes.Volumes,
+	}
+
+	volumeCtx.Write()
+
 	return nil
 }
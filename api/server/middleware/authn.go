@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/pkg/authentication"
+	"golang.org/x/net/context"
+)
+
+type unauthorizedError struct {
+	error
+}
+
+func (unauthorizedError) HTTPErrorStatusCode() int {
+	return http.StatusUnauthorized
+}
+
+// AuthNMiddleware is a middleware that authenticates the user
+// making a request, and stores its identity in the request context.
+type AuthNMiddleware struct {
+	authenticators []authentication.Authenticator
+}
+
+// NewAuthNMiddleware creates a new AuthNMiddleware with a list of
+// authenticators, tried in order. Requests not authenticated by any
+// of them are identified by their TLS client certificate, if any.
+func NewAuthNMiddleware(authenticators []authentication.Authenticator) AuthNMiddleware {
+	return AuthNMiddleware{
+		authenticators: authenticators,
+	}
+}
+
+// WrapHandler returns a new handler function wrapping the previous one in the request chain.
+func (m AuthNMiddleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		id, err := m.authenticate(r)
+		if err != nil {
+			logrus.Warnf("Authentication of %s %s from %s failed: %v", r.Method, r.RequestURI, r.RemoteAddr, err)
+			return unauthorizedError{err}
+		}
+		if id != nil {
+			ctx = authentication.WithIdentity(ctx, id)
+		}
+		return handler(ctx, w, r, vars)
+	}
+}
+
+// authenticate returns the identity of the user making r, or nil if the user
+// is anonymous.
+func (m AuthNMiddleware) authenticate(r *http.Request) (*authentication.Identity, error) {
+	for _, a := range m.authenticators {
+		id, err := a.Authenticate(r)
+		if err != nil {
+			return nil, fmt.Errorf("authentication failed: %v", err)
+		}
+		if id != nil {
+			logrus.Debugf("Request %s %s authenticated by %s as %s", r.Method, r.RequestURI, a.Name(), id.User)
+			return id, nil
+		}
+	}
+
+	// Credentials that no authenticator recognizes are rejected rather than
+	// ignored, so that invalid tokens aren't silently treated as anonymous.
+	if len(m.authenticators) > 0 && r.Header.Get("Authorization") != "" {
+		return nil, fmt.Errorf("authentication failed: invalid credentials")
+	}
+	return authentication.TLSIdentity(r), nil
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/docker/docker/pkg/authentication"
+	"golang.org/x/net/context"
+)
+
+type fakeAuthenticator struct {
+	token string
+	user  string
+}
+
+func (a fakeAuthenticator) Name() string {
+	return "fake"
+}
+
+func (a fakeAuthenticator) Authenticate(r *http.Request) (*authentication.Identity, error) {
+	switch r.Header.Get("Authorization") {
+	case "":
+		return nil, nil
+	case "Bearer " + a.token:
+		return &authentication.Identity{User: a.user, Method: "fake"}, nil
+	case "Bearer rejected":
+		return nil, fmt.Errorf("rejected")
+	}
+	return nil, nil
+}
+
+func TestAuthNMiddleware(t *testing.T) {
+	var user string
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		user = ""
+		if id := authentication.IdentityFromContext(ctx); id != nil {
+			user = id.User
+		}
+		return nil
+	}
+
+	m := NewAuthNMiddleware([]authentication.Authenticator{
+		fakeAuthenticator{token: "a", user: "alice"},
+		fakeAuthenticator{token: "b", user: "bob"},
+	})
+	h := m.WrapHandler(handler)
+
+	cases := []struct {
+		authorization string
+		user          string
+		unauthorized  bool
+	}{
+		{"", "", false},
+		{"Bearer a", "alice", false},
+		{"Bearer b", "bob", false},
+		{"Bearer rejected", "", true},
+		{"Bearer unknown", "", true},
+	}
+	for _, c := range cases {
+		req, _ := http.NewRequest("GET", "/containers/json", nil)
+		if c.authorization != "" {
+			req.Header.Set("Authorization", c.authorization)
+		}
+		user = ""
+		err := h(context.Background(), httptest.NewRecorder(), req, map[string]string{})
+		if c.unauthorized {
+			if _, ok := err.(unauthorizedError); !ok {
+				t.Fatalf("Expected an unauthorized error for %q, got %v", c.authorization, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user != c.user {
+			t.Fatalf("Expected user %q for %q, got %q", c.user, c.authorization, user)
+		}
+	}
+}
+
+func TestAuthNMiddlewareWithoutAuthenticators(t *testing.T) {
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		if id := authentication.IdentityFromContext(ctx); id != nil {
+			t.Fatalf("Expected an anonymous request, got %v", id)
+		}
+		return nil
+	}
+
+	h := NewAuthNMiddleware(nil).WrapHandler(handler)
+
+	// Without authenticators, credentials are left to the API endpoints.
+	req, _ := http.NewRequest("GET", "/containers/json", nil)
+	req.Header.Set("Authorization", "Bearer unknown")
+	if err := h(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,57 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/docker/docker/api/server/httputils"
+	"github.com/docker/engine-api/types"
+	"golang.org/x/net/context"
+)
+
+func (r *checkpointRouter) postContainerCheckpoint(ctx context.Context, w http.ResponseWriter, req *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(req); err != nil {
+		return err
+	}
+	if err := httputils.CheckForJSON(req); err != nil {
+		return err
+	}
+
+	var options types.CheckpointCreateOptions
+	if err := json.NewDecoder(req.Body).Decode(&options); err != nil {
+		return err
+	}
+
+	if err := r.backend.CheckpointCreate(vars["name"], options); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return nil
+}
+
+func (r *checkpointRouter) getContainerCheckpoints(ctx context.Context, w http.ResponseWriter, req *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(req); err != nil {
+		return err
+	}
+
+	checkpoints, err := r.backend.CheckpointList(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, checkpoints)
+}
+
+func (r *checkpointRouter) deleteContainerCheckpoint(ctx context.Context, w http.ResponseWriter, req *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(req); err != nil {
+		return err
+	}
+
+	if err := r.backend.CheckpointDelete(vars["name"], vars["checkpoint"]); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
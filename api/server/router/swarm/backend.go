@@ -1,8 +1,10 @@
 package swarm
 
 import (
+	"github.com/docker/docker/api/types/backend"
 	basictypes "github.com/docker/engine-api/types"
 	types "github.com/docker/engine-api/types/swarm"
+	"golang.org/x/net/context"
 )
 
 // Backend abstracts an swarm commands manager.
@@ -17,10 +19,15 @@ type Backend interface {
 	CreateService(types.ServiceSpec) (string, error)
 	UpdateService(string, uint64, types.ServiceSpec) error
 	RemoveService(string) error
+	ServiceLogs(context.Context, string, *backend.ContainerLogsConfig, chan struct{}) error
 	GetNodes(basictypes.NodeListOptions) ([]types.Node, error)
 	GetNode(string) (types.Node, error)
 	UpdateNode(string, uint64, types.NodeSpec) error
 	RemoveNode(string) error
 	GetTasks(basictypes.TaskListOptions) ([]types.Task, error)
 	GetTask(string) (types.Task, error)
+	GetSecrets(basictypes.SecretListOptions) ([]types.Secret, error)
+	CreateSecret(types.SecretSpec) (string, error)
+	RemoveSecret(string) error
+	GetSecret(string) (types.Secret, error)
 }
@@ -30,6 +30,7 @@ func (sr *swarmRouter) initRoutes() {
 		router.NewGetRoute("/swarm", sr.inspectCluster),
 		router.NewPostRoute("/swarm/update", sr.updateCluster),
 		router.NewGetRoute("/services", sr.getServices),
+		router.NewGetRoute("/services/{id:.*}/logs", sr.getServiceLogs),
 		router.NewGetRoute("/services/{id:.*}", sr.getService),
 		router.NewPostRoute("/services/create", sr.createService),
 		router.NewPostRoute("/services/{id:.*}/update", sr.updateService),
@@ -40,5 +41,9 @@ func (sr *swarmRouter) initRoutes() {
 		router.NewPostRoute("/nodes/{id:.*}/update", sr.updateNode),
 		router.NewGetRoute("/tasks", sr.getTasks),
 		router.NewGetRoute("/tasks/{id:.*}", sr.getTask),
+		router.NewGetRoute("/secrets", sr.getSecrets),
+		router.NewPostRoute("/secrets/create", sr.createSecret),
+		router.NewDeleteRoute("/secrets/{id:.*}", sr.removeSecret),
+		router.NewGetRoute("/secrets/{id:.*}", sr.getSecret),
 	}
 }
@@ -71,6 +71,8 @@ type Builder struct {
 	disableCommit    bool
 	cacheBusted      bool
 	allowedBuildArgs map[string]bool // list of build-time args that are allowed for expansion/substitution and passing to commands in 'run'.
+	stages           buildStages     // stages of a multi-stage Dockerfile, one per FROM
+	imageCache       builder.ImageCache
 
 	// TODO: remove once docker.Commit can receive a tag
 	id string
@@ -131,6 +133,11 @@ func NewBuilder(clientCtx context.Context, config *types.ImageBuildOptions, back
 		id:               stringid.GenerateNonCryptoID(),
 		allowedBuildArgs: make(map[string]bool),
 	}
+	if icb, ok := backend.(builder.ImageCacheBuilder); ok {
+		b.imageCache = icb.MakeImageCache(config.CacheFrom)
+	} else if c, ok := backend.(builder.ImageCache); ok {
+		b.imageCache = c
+	}
 	if dockerfile != nil {
 		b.dockerfile, err = parser.Parse(dockerfile)
 		if err != nil {
@@ -213,6 +220,12 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		return "", err
 	}
 
+	if b.options.Target != "" {
+		if err := truncateToTarget(b.dockerfile, b.options.Target); err != nil {
+			return "", err
+		}
+	}
+
 	if len(b.options.Labels) > 0 {
 		line := "LABEL "
 		for k, v := range b.options.Labels {
@@ -225,6 +238,8 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		b.dockerfile.Children = append(b.dockerfile.Children, node)
 	}
 
+	defer b.stages.unmount()
+
 	var shortImgID string
 	for i, n := range b.dockerfile.Children {
 		select {
@@ -235,12 +250,14 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		default:
 			// Not cancelled yet, keep going...
 		}
+
 		if err := b.dispatch(i, n); err != nil {
 			if b.options.ForceRemove {
 				b.clearTmp()
 			}
 			return "", err
 		}
+		b.stages.update(b.image)
 
 		shortImgID = stringid.TruncateID(b.image)
 		fmt.Fprintf(b.Stdout, " ---> %s\n", shortImgID)
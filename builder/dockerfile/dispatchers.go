@@ -152,38 +152,67 @@ func add(b *Builder, args []string, attributes map[string]bool, original string)
 		return err
 	}
 
-	return b.runContextCommand(args, true, true, "ADD")
+	return b.runContextCommand(args, true, true, "ADD", b.context)
 }
 
 // COPY foo /path
+// COPY --from=stage foo /path
 //
-// Same as 'ADD' but without the tar and remote url handling.
+// Same as 'ADD' but without the tar and remote url handling. With --from,
+// the sources are taken from an earlier build stage or an image instead of
+// the build context.
 //
 func dispatchCopy(b *Builder, args []string, attributes map[string]bool, original string) error {
 	if len(args) < 2 {
 		return errAtLeastOneArgument("COPY")
 	}
 
+	flFrom := b.flags.AddString("from", "")
+
 	if err := b.flags.Parse(); err != nil {
 		return err
 	}
 
-	return b.runContextCommand(args, false, false, "COPY")
+	srcContext := b.context
+	if flFrom.IsUsed() {
+		if flFrom.Value == "" {
+			return fmt.Errorf("COPY --from requires a build stage or image name")
+		}
+		var err error
+		srcContext, err = b.copySource(flFrom.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.runContextCommand(args, false, false, "COPY", srcContext)
 }
 
 // FROM imagename
+// FROM imagename AS stagename
 //
-// This sets the image the dockerfile will build on top of.
+// This sets the image the dockerfile will build on top of. Every FROM starts
+// a new build stage with a fresh configuration; naming the stage lets later
+// stages refer to it with `FROM stagename` or `COPY --from=stagename`.
 //
 func from(b *Builder, args []string, attributes map[string]bool, original string) error {
-	if len(args) != 1 {
-		return errExactlyOneArgument("FROM")
+	var stageName string
+	switch {
+	case len(args) == 3 && strings.EqualFold(args[1], "as"):
+		stageName = args[2]
+	case len(args) != 1:
+		return fmt.Errorf("FROM requires either one or three arguments")
 	}
 
 	if err := b.flags.Parse(); err != nil {
 		return err
 	}
 
+	if err := b.stages.add(stageName); err != nil {
+		return err
+	}
+	b.resetStage()
+
 	name := args[0]
 
 	var (
@@ -191,6 +220,19 @@ func from(b *Builder, args []string, attributes map[string]bool, original string
 		err   error
 	)
 
+	if stage, ok := b.stages.getByName(name); ok {
+		// Build on top of the result of an earlier stage.
+		if stage.image == "" {
+			b.noBaseImage = true
+			return b.processImageFrom(nil)
+		}
+		image, err = b.docker.GetImageOnBuild(stage.image)
+		if err != nil {
+			return err
+		}
+		return b.processImageFrom(image)
+	}
+
 	// Windows cannot support a container with no base image.
 	if name == api.NoBaseImageSpecifier {
 		if runtime.GOOS == "windows" {
@@ -485,8 +527,18 @@ func healthcheck(b *Builder, args []string, attributes map[string]bool, original
 			}
 
 			healthcheck.Test = strslice.StrSlice(append([]string{typ}, cmdSlice...))
+		case "HTTP", "TCP":
+			target := handleJSONArgs(args, attributes)
+			if len(target) != 1 {
+				return fmt.Errorf("HEALTHCHECK %s requires exactly one argument", typ)
+			}
+
+			healthcheck.Test = strslice.StrSlice{typ, target[0]}
+			if err := runconfigopts.ValidateHealthcheck(&healthcheck); err != nil {
+				return err
+			}
 		default:
-			return fmt.Errorf("Unknown type %#v in HEALTHCHECK (try CMD)", typ)
+			return fmt.Errorf("Unknown type %#v in HEALTHCHECK (try CMD, HTTP or TCP)", typ)
 		}
 
 		interval, err := parseOptInterval(flInterval)
@@ -17,7 +17,6 @@ type commandWithFunction struct {
 func TestCommandsExactlyOneArgument(t *testing.T) {
 	commands := []commandWithFunction{
 		{"MAINTAINER", func(args []string) error { return maintainer(nil, args, nil, "") }},
-		{"FROM", func(args []string) error { return from(nil, args, nil, "") }},
 		{"WORKDIR", func(args []string) error { return workdir(nil, args, nil, "") }},
 		{"USER", func(args []string) error { return user(nil, args, nil, "") }}}
 
@@ -170,6 +169,89 @@ func TestFrom(t *testing.T) {
 	}
 }
 
+func TestFromArguments(t *testing.T) {
+	invalid := [][]string{
+		{},
+		{"busybox", "AS"},
+		{"busybox", "foo", "bar"},
+		{"busybox", "AS", "build", "extra"},
+	}
+
+	for _, args := range invalid {
+		err := from(nil, args, nil, "")
+
+		if err == nil {
+			t.Fatalf("Error should be present for FROM %v", args)
+		}
+
+		expectedError := "FROM requires either one or three arguments"
+
+		if err.Error() != expectedError {
+			t.Fatalf("Wrong error message for FROM %v. Got: %s. Should be: %s", args, err.Error(), expectedError)
+		}
+	}
+}
+
+func TestFromStages(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows does not support FROM scratch")
+	}
+
+	b := &Builder{flags: &BFlags{}, runConfig: &container.Config{}, disableCommit: true}
+
+	if err := from(b, []string{"scratch", "AS", "Build"}, nil, ""); err != nil {
+		t.Fatalf("Error when executing from: %s", err.Error())
+	}
+	b.runConfig.Env = []string{"FOO=bar"}
+	b.stages.update("sha256:build")
+
+	if err := from(b, []string{"scratch", "as", "build"}, nil, ""); err == nil || !strings.Contains(err.Error(), "duplicate name") {
+		t.Fatalf("Expected duplicate stage name error, got: %v", err)
+	}
+
+	b = &Builder{flags: &BFlags{}, runConfig: &container.Config{}, disableCommit: true}
+	if err := from(b, []string{"scratch", "AS", "build"}, nil, ""); err != nil {
+		t.Fatalf("Error when executing from: %s", err.Error())
+	}
+	b.runConfig.Env = []string{"FOO=bar"}
+	b.stages.update("sha256:build")
+
+	if err := from(b, []string{"scratch"}, nil, ""); err != nil {
+		t.Fatalf("Error when executing from: %s", err.Error())
+	}
+
+	for _, env := range b.runConfig.Env {
+		if env == "FOO=bar" {
+			t.Fatalf("Configuration of the previous stage should have been reset, got env: %v", b.runConfig.Env)
+		}
+	}
+
+	for _, ref := range []string{"build", "BUILD", "0"} {
+		stage, ok := b.stages.get(ref)
+		if !ok {
+			t.Fatalf("Stage %q should be found", ref)
+		}
+		if stage.image != "sha256:build" {
+			t.Fatalf("Wrong image for stage %q. Got: %s", ref, stage.image)
+		}
+	}
+
+	if _, ok := b.stages.get("1"); ok {
+		t.Fatalf("The current stage should not be referenced")
+	}
+}
+
+func TestCopyFromRequiresValue(t *testing.T) {
+	b := &Builder{flags: NewBFlags(), runConfig: &container.Config{}, disableCommit: true}
+	b.flags.Args = []string{"--from="}
+
+	err := dispatchCopy(b, []string{"foo", "/bar"}, nil, "")
+
+	if err == nil || !strings.Contains(err.Error(), "requires a build stage or image name") {
+		t.Fatalf("Expected error for empty --from, got: %v", err)
+	}
+}
+
 func TestOnbuildIllegalTriggers(t *testing.T) {
 	triggers := []struct{ command, expectedError string }{
 		{"ONBUILD", "Chaining ONBUILD via `ONBUILD ONBUILD` isn't allowed"},
@@ -206,3 +288,28 @@ func TestOnbuild(t *testing.T) {
 		t.Fatalf("Wrong ONBUILD command. Expected: %s, got: %s", expectedOnbuild, b.runConfig.OnBuild[0])
 	}
 }
+
+func TestHealthcheckHTTPAndTCPHosts(t *testing.T) {
+	cases := []struct {
+		args  []string
+		valid bool
+	}{
+		{[]string{"HTTP", "http://localhost:8080/health"}, true},
+		{[]string{"HTTP", "http://127.0.0.1/"}, true},
+		{[]string{"HTTP", "http://web:8080/health"}, false},
+		{[]string{"TCP", "localhost:6379"}, true},
+		{[]string{"TCP", "[::1]:6379"}, true},
+		{[]string{"TCP", "redis:6379"}, false},
+	}
+
+	for _, c := range cases {
+		b := &Builder{flags: NewBFlags(), runConfig: &container.Config{}, disableCommit: true}
+		err := healthcheck(b, c.args, nil, "")
+		if c.valid && err != nil {
+			t.Fatalf("Expected HEALTHCHECK %v to be valid, got %v", c.args, err)
+		}
+		if !c.valid && (err == nil || !strings.Contains(err.Error(), "must be an IP address or localhost")) {
+			t.Fatalf("Expected HEALTHCHECK %v to be rejected, got %v", c.args, err)
+		}
+	}
+}
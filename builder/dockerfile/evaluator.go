@@ -22,6 +22,7 @@ package dockerfile
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/builder/dockerfile/command"
 	"github.com/docker/docker/builder/dockerfile/parser"
@@ -196,7 +197,12 @@ func (b *Builder) dispatch(stepN int, ast *parser.Node) error {
 	if f, ok := evaluateTable[cmd]; ok {
 		b.flags = NewBFlags()
 		b.flags.Args = flags
-		return f(b, strList, attrs, original)
+		start := time.Now()
+		if err := f(b, strList, attrs, original); err != nil {
+			return err
+		}
+		buildSteps.WithLabelValues(upperCasedCmd).Observe(time.Since(start).Seconds())
+		return nil
 	}
 
 	return fmt.Errorf("Unknown instruction: %s", upperCasedCmd)
@@ -0,0 +1,175 @@
+package dockerfile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/builder/dockerfile/command"
+	"github.com/docker/docker/builder/dockerfile/parser"
+)
+
+// buildStage is a single `FROM` section of a Dockerfile. Its image is the
+// last image committed while the stage was the current one.
+type buildStage struct {
+	name  string
+	image string
+}
+
+// buildStages tracks the stages of a multi-stage Dockerfile so that later
+// stages can refer to earlier ones by name or index, either in `FROM` or
+// with `COPY --from`.
+type buildStages struct {
+	list   []*buildStage
+	byName map[string]*buildStage
+	mounts map[string]builder.Context // image ID -> mounted copy source
+}
+
+// add starts a new stage, optionally named, and returns an error if the
+// name is already used by an earlier stage.
+func (s *buildStages) add(name string) error {
+	name = strings.ToLower(name)
+	if name != "" {
+		if _, err := strconv.Atoi(name); err == nil {
+			return fmt.Errorf("invalid name for build stage: %q, name can't be a number", name)
+		}
+		if _, ok := s.byName[name]; ok {
+			return fmt.Errorf("duplicate name %s", name)
+		}
+	}
+	stage := &buildStage{name: name}
+	s.list = append(s.list, stage)
+	if name != "" {
+		if s.byName == nil {
+			s.byName = make(map[string]*buildStage)
+		}
+		s.byName[name] = stage
+	}
+	return nil
+}
+
+// update records imageID as the current result of the last stage.
+func (s *buildStages) update(imageID string) {
+	if len(s.list) == 0 {
+		return
+	}
+	s.list[len(s.list)-1].image = imageID
+}
+
+// getByName returns the stage with the given name. Only stages that
+// precede the current one can be referenced.
+func (s *buildStages) getByName(name string) (*buildStage, bool) {
+	stage, ok := s.byName[strings.ToLower(name)]
+	if !ok || stage == s.current() {
+		return nil, false
+	}
+	return stage, true
+}
+
+// get returns the stage referenced by a name or index. Only stages that
+// precede the current one can be referenced.
+func (s *buildStages) get(nameOrIndex string) (*buildStage, bool) {
+	if stage, ok := s.getByName(nameOrIndex); ok {
+		return stage, true
+	}
+	if i, err := strconv.Atoi(nameOrIndex); err == nil && i >= 0 && i < len(s.list)-1 {
+		return s.list[i], true
+	}
+	return nil, false
+}
+
+// current returns the stage being built, or nil before the first `FROM`.
+func (s *buildStages) current() *buildStage {
+	if len(s.list) == 0 {
+		return nil
+	}
+	return s.list[len(s.list)-1]
+}
+
+// truncateToTarget removes every instruction that follows the build stage
+// named target, so that the build stops once that stage is complete.
+func truncateToTarget(dockerfile *parser.Node, target string) error {
+	target = strings.ToLower(target)
+	inTarget := false
+	for i, n := range dockerfile.Children {
+		if n.Value != command.From {
+			continue
+		}
+		if inTarget {
+			dockerfile.Children = dockerfile.Children[:i]
+			return nil
+		}
+		inTarget = strings.ToLower(stageName(n)) == target
+	}
+	if !inTarget {
+		return fmt.Errorf("failed to reach build target %s in Dockerfile", target)
+	}
+	return nil
+}
+
+// stageName returns the name given to a stage by a `FROM image AS name`
+// instruction, or an empty string if the stage is not named.
+func stageName(n *parser.Node) string {
+	var args []string
+	for next := n.Next; next != nil; next = next.Next {
+		args = append(args, next.Value)
+	}
+	if len(args) == 3 && strings.EqualFold(args[1], "as") {
+		return args[2]
+	}
+	return ""
+}
+
+// unmount releases every image mounted as a copy source.
+func (s *buildStages) unmount() {
+	for id, ctx := range s.mounts {
+		if err := ctx.Close(); err != nil {
+			logrus.Errorf("Failed to unmount copy source %s: %v", id, err)
+		}
+	}
+	s.mounts = nil
+}
+
+// copySource returns a read-only context holding the filesystem of the
+// earlier stage or image referenced by `COPY --from`. Images that are not
+// present locally are pulled, the same way `FROM` would.
+func (b *Builder) copySource(from string) (builder.Context, error) {
+	var imageID string
+	if stage, ok := b.stages.get(from); ok {
+		if stage.image == "" {
+			return nil, fmt.Errorf("build stage %s did not produce an image to copy from", from)
+		}
+		imageID = stage.image
+	} else {
+		var (
+			image builder.Image
+			err   error
+		)
+		if !b.options.PullParent {
+			image, _ = b.docker.GetImageOnBuild(from)
+		}
+		if image == nil {
+			image, err = b.docker.PullOnBuild(b.clientCtx, from, b.options.AuthConfigs, b.Output)
+			if err != nil {
+				return nil, err
+			}
+		}
+		imageID = image.ImageID()
+	}
+
+	if ctx, ok := b.stages.mounts[imageID]; ok {
+		return ctx, nil
+	}
+	root, release, err := b.docker.MountImage(imageID)
+	if err != nil {
+		return nil, err
+	}
+	ctx := builder.NewMountContext(root, release)
+	if b.stages.mounts == nil {
+		b.stages.mounts = make(map[string]builder.Context)
+	}
+	b.stages.mounts[imageID] = ctx
+	return ctx, nil
+}
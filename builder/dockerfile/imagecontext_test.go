@@ -0,0 +1,72 @@
+package dockerfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/builder/dockerfile/parser"
+)
+
+func TestTruncateToTarget(t *testing.T) {
+	dockerfile := `FROM busybox AS base
+RUN echo base
+FROM base AS build
+RUN echo build
+FROM busybox
+COPY --from=build /foo /foo
+`
+	testCases := []struct {
+		target   string
+		expected []string
+	}{
+		{"base", []string{"from", "run"}},
+		{"BUILD", []string{"from", "run", "from", "run"}},
+	}
+
+	for _, tc := range testCases {
+		ast, err := parser.Parse(strings.NewReader(dockerfile))
+		if err != nil {
+			t.Fatalf("Error when parsing Dockerfile: %s", err)
+		}
+
+		if err := truncateToTarget(ast, tc.target); err != nil {
+			t.Fatalf("Error when truncating to %s: %s", tc.target, err)
+		}
+
+		var got []string
+		for _, n := range ast.Children {
+			got = append(got, n.Value)
+		}
+
+		if strings.Join(got, " ") != strings.Join(tc.expected, " ") {
+			t.Fatalf("Wrong instructions for target %s. Got: %v. Should be: %v", tc.target, got, tc.expected)
+		}
+	}
+}
+
+func TestTruncateToMissingTarget(t *testing.T) {
+	ast, err := parser.Parse(strings.NewReader("FROM busybox AS base\nRUN echo base\n"))
+	if err != nil {
+		t.Fatalf("Error when parsing Dockerfile: %s", err)
+	}
+
+	err = truncateToTarget(ast, "nosuchstage")
+
+	expectedError := "failed to reach build target nosuchstage in Dockerfile"
+
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Wrong error message. Got: %v. Should be: %s", err, expectedError)
+	}
+}
+
+func TestBuildStageNames(t *testing.T) {
+	var s buildStages
+
+	if err := s.add("1stage"); err != nil {
+		t.Fatalf("Stage names starting with a number should be allowed, got: %s", err)
+	}
+
+	if err := s.add("42"); err == nil {
+		t.Fatalf("Numeric stage names should be rejected")
+	}
+}
@@ -92,8 +92,8 @@ type copyInfo struct {
 	decompress bool
 }
 
-func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalDecompression bool, cmdName string) error {
-	if b.context == nil {
+func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalDecompression bool, cmdName string, srcContext builder.Context) error {
+	if srcContext == nil {
 		return fmt.Errorf("No context given. Impossible to use %s", cmdName)
 	}
 
@@ -129,7 +129,7 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 			continue
 		}
 		// not a URL
-		subInfos, err := b.calcCopyInfo(cmdName, orig, allowLocalDecompression, true)
+		subInfos, err := calcCopyInfo(srcContext, cmdName, orig, allowLocalDecompression, true)
 		if err != nil {
 			return err
 		}
@@ -181,7 +181,7 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 		return nil
 	}
 
-	container, err := b.docker.ContainerCreate(types.ContainerCreateConfig{Config: b.runConfig})
+	container, err := b.docker.ContainerCreate(b.clientCtx, types.ContainerCreateConfig{Config: b.runConfig})
 	if err != nil {
 		return err
 	}
@@ -293,7 +293,7 @@ func (b *Builder) download(srcURL string) (fi builder.FileInfo, err error) {
 	return &builder.HashedFileInfo{FileInfo: builder.PathFileInfo{FileInfo: tmpFileSt, FilePath: tmpFileName}, FileHash: hash}, nil
 }
 
-func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression, allowWildcards bool) ([]copyInfo, error) {
+func calcCopyInfo(srcContext builder.Context, cmdName, origPath string, allowLocalDecompression, allowWildcards bool) ([]copyInfo, error) {
 
 	// Work in daemon-specific OS filepath semantics
 	origPath = filepath.FromSlash(origPath)
@@ -306,7 +306,7 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 	// Deal with wildcards
 	if allowWildcards && containsWildcards(origPath) {
 		var copyInfos []copyInfo
-		if err := b.context.Walk("", func(path string, info builder.FileInfo, err error) error {
+		if err := srcContext.Walk("", func(path string, info builder.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
@@ -320,7 +320,7 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 
 			// Note we set allowWildcards to false in case the name has
 			// a * in it
-			subInfos, err := b.calcCopyInfo(cmdName, path, allowLocalDecompression, false)
+			subInfos, err := calcCopyInfo(srcContext, cmdName, path, allowLocalDecompression, false)
 			if err != nil {
 				return err
 			}
@@ -334,7 +334,7 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 
 	// Must be a dir or a file
 
-	statPath, fi, err := b.context.Stat(origPath)
+	statPath, fi, err := srcContext.Stat(origPath)
 	if err != nil {
 		return nil, err
 	}
@@ -353,7 +353,7 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 	}
 	// Must be a dir
 	var subfiles []string
-	err = b.context.Walk(statPath, func(path string, info builder.FileInfo, err error) error {
+	err = srcContext.Walk(statPath, func(path string, info builder.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -385,6 +385,17 @@ func containsWildcards(name string) bool {
 	return false
 }
 
+// resetStage clears the state carried over from a previous build stage so
+// that every FROM starts from the configuration of its own base image.
+func (b *Builder) resetStage() {
+	b.runConfig = new(container.Config)
+	b.image = ""
+	b.noBaseImage = false
+	b.maintainer = ""
+	b.cmdSet = false
+	b.cacheBusted = false
+}
+
 func (b *Builder) processImageFrom(img builder.Image) error {
 	if img != nil {
 		b.image = img.ImageID()
@@ -449,18 +460,17 @@ func (b *Builder) processImageFrom(img builder.Image) error {
 	return nil
 }
 
-// probeCache checks if `b.docker` implements builder.ImageCache and image-caching
-// is enabled (`b.UseCache`).
-// If so attempts to look up the current `b.image` and `b.runConfig` pair with `b.docker`.
+// probeCache checks if the backend provided an image cache (`b.imageCache`)
+// and image-caching is enabled (`b.UseCache`).
+// If so attempts to look up the current `b.image` and `b.runConfig` pair in `b.imageCache`.
 // If an image is found, probeCache returns `(true, nil)`.
 // If no image is found, it returns `(false, nil)`.
 // If there is any error, it returns `(false, err)`.
 func (b *Builder) probeCache() (bool, error) {
-	c, ok := b.docker.(builder.ImageCache)
-	if !ok || b.options.NoCache || b.cacheBusted {
+	if b.imageCache == nil || b.options.NoCache || b.cacheBusted {
 		return false, nil
 	}
-	cache, err := c.GetCachedImageOnBuild(b.image, b.runConfig)
+	cache, err := b.imageCache.GetCachedImageOnBuild(b.image, b.runConfig)
 	if err != nil {
 		return false, err
 	}
@@ -505,7 +515,7 @@ func (b *Builder) create() (string, error) {
 	config := *b.runConfig
 
 	// Create the container
-	c, err := b.docker.ContainerCreate(types.ContainerCreateConfig{
+	c, err := b.docker.ContainerCreate(b.clientCtx, types.ContainerCreateConfig{
 		Config:     b.runConfig,
 		HostConfig: hostConfig,
 	})
@@ -544,7 +554,7 @@ func (b *Builder) run(cID string) (err error) {
 		select {
 		case <-b.clientCtx.Done():
 			logrus.Debugln("Build cancelled, killing and removing container:", cID)
-			b.docker.ContainerKill(cID, 0)
+			b.docker.ContainerKill(b.clientCtx, cID, 0)
 			b.removeContainer(cID)
 			cancelErrCh <- errCancelled
 		case <-finished:
@@ -552,7 +562,7 @@ func (b *Builder) run(cID string) (err error) {
 		}
 	}()
 
-	if err := b.docker.ContainerStart(cID, nil); err != nil {
+	if err := b.docker.ContainerStart(b.clientCtx, cID, nil, "", ""); err != nil {
 		return err
 	}
 
@@ -577,7 +587,7 @@ func (b *Builder) removeContainer(c string) error {
 		ForceRemove:  true,
 		RemoveVolume: true,
 	}
-	if err := b.docker.ContainerRm(c, rmConfig); err != nil {
+	if err := b.docker.ContainerRm(b.clientCtx, c, rmConfig); err != nil {
 		fmt.Fprintf(b.Stdout, "Error removing intermediate container %s: %v\n", stringid.TruncateID(c), err)
 		return err
 	}
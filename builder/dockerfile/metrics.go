@@ -0,0 +1,15 @@
+package dockerfile
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var buildSteps = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "engine",
+	Subsystem: "builder",
+	Name:      "steps_seconds",
+	Help:      "The number of seconds it takes to run each Dockerfile instruction",
+	Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+}, []string{"instruction"})
+
+func init() {
+	prometheus.MustRegister(buildSteps)
+}
@@ -0,0 +1,156 @@
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/pkg/symlink"
+)
+
+// mountContext is a read-only Context backed by an already mounted
+// filesystem, such as the root filesystem of an image used as the source
+// of `COPY --from`. Unlike tarSumContext nothing is extracted up front, so
+// file hashes are only calculated from the file contents when they are
+// requested.
+type mountContext struct {
+	root    string
+	release func() error
+}
+
+// NewMountContext returns a build Context for the filesystem mounted at root.
+//
+// release is called when the Context is closed and is expected to unmount
+// the filesystem.
+func NewMountContext(root string, release func() error) Context {
+	return &mountContext{root: root, release: release}
+}
+
+func (c *mountContext) Close() error {
+	if c.release == nil {
+		return nil
+	}
+	return c.release()
+}
+
+func (c *mountContext) Open(path string) (io.ReadCloser, error) {
+	cleanpath, fullpath, err := c.normalize(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := os.Open(fullpath)
+	if err != nil {
+		return nil, convertPathError(err, cleanpath)
+	}
+	return r, nil
+}
+
+func (c *mountContext) Stat(path string) (string, FileInfo, error) {
+	cleanpath, fullpath, err := c.normalize(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	st, err := os.Lstat(fullpath)
+	if err != nil {
+		return "", nil, convertPathError(err, cleanpath)
+	}
+
+	rel, err := filepath.Rel(c.root, fullpath)
+	if err != nil {
+		return "", nil, convertPathError(err, cleanpath)
+	}
+
+	fi := &lazyHashedFileInfo{PathFileInfo: PathFileInfo{st, fullpath, filepath.Base(cleanpath)}, rel: rel}
+	return rel, fi, nil
+}
+
+func (c *mountContext) Walk(root string, walkFn WalkFunc) error {
+	root = filepath.Join(c.root, filepath.Join(string(filepath.Separator), root))
+	return filepath.Walk(root, func(fullpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(c.root, fullpath)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		fi := &lazyHashedFileInfo{PathFileInfo: PathFileInfo{FileInfo: info, FilePath: fullpath}, rel: rel}
+		return walkFn(rel, fi, nil)
+	})
+}
+
+func (c *mountContext) normalize(path string) (cleanpath, fullpath string, err error) {
+	cleanpath = filepath.Clean(string(os.PathSeparator) + path)[1:]
+	fullpath, err = symlink.FollowSymlinkInScope(filepath.Join(c.root, path), c.root)
+	if err != nil {
+		return "", "", fmt.Errorf("Forbidden path outside the image: %s (%s)", path, fullpath)
+	}
+	_, err = os.Lstat(fullpath)
+	if err != nil {
+		return "", "", convertPathError(err, path)
+	}
+	return
+}
+
+// lazyHashedFileInfo is a FileInfo whose hash is calculated the first time
+// it is requested.
+type lazyHashedFileInfo struct {
+	PathFileInfo
+	rel  string
+	hash string
+}
+
+// Hash returns the hash of a file.
+func (fi *lazyHashedFileInfo) Hash() string {
+	if fi.hash == "" {
+		sum, err := hashPath(fi.FilePath, fi.rel, fi.FileInfo)
+		if err != nil {
+			// Like tarSumContext, fall back to the path for files that
+			// cannot be read.
+			logrus.Debugf("[BUILDER] failed to hash %s: %v", fi.rel, err)
+			sum = fi.rel
+		}
+		fi.hash = sum
+	}
+	return fi.hash
+}
+
+// SetHash sets the hash of a file.
+func (fi *lazyHashedFileInfo) SetHash(h string) {
+	fi.hash = h
+}
+
+// hashPath returns a digest of the file at fullpath covering its relative
+// name, mode and, for regular files and symlinks, its content.
+func hashPath(fullpath, rel string, fi os.FileInfo) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%o\x00", filepath.ToSlash(rel), fi.Mode())
+
+	switch {
+	case fi.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(fullpath)
+		if err != nil {
+			return "", err
+		}
+		io.WriteString(h, target)
+	case fi.Mode().IsRegular():
+		f, err := os.Open(fullpath)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		if _, err := io.Copy(h, f); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,105 @@
+package builder
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMountContextStatAndHash(t *testing.T) {
+	root, cleanup := createTestTempDir(t, "", "builder-mount-context-test")
+	defer cleanup()
+
+	createTestTempFile(t, root, "foo", "foo contents", 0644)
+	createTestTempFile(t, root, "bar", "bar contents", 0644)
+
+	released := false
+	ctx := NewMountContext(root, func() error {
+		released = true
+		return nil
+	})
+
+	rel, fi, err := ctx.Stat("/foo")
+	if err != nil {
+		t.Fatalf("Error when executing Stat: %s", err)
+	}
+	if rel != "foo" {
+		t.Fatalf("Relative path should be foo, got: %s", rel)
+	}
+
+	hfi, ok := fi.(Hashed)
+	if !ok {
+		t.Fatalf("FileInfo returned by Stat should be Hashed")
+	}
+
+	_, barInfo, err := ctx.Stat("bar")
+	if err != nil {
+		t.Fatalf("Error when executing Stat: %s", err)
+	}
+	if hfi.Hash() == "" || hfi.Hash() == barInfo.(Hashed).Hash() {
+		t.Fatalf("Files with different contents should have different hashes")
+	}
+
+	if err := ctx.Close(); err != nil {
+		t.Fatalf("Error when closing context: %s", err)
+	}
+	if !released {
+		t.Fatalf("Closing the context should release the mount")
+	}
+}
+
+func TestMountContextWalk(t *testing.T) {
+	root, cleanup := createTestTempDir(t, "", "builder-mount-context-test")
+	defer cleanup()
+
+	if err := os.MkdirAll(filepath.Join(root, "dir"), 0755); err != nil {
+		t.Fatalf("Error when creating directory: %s", err)
+	}
+	createTestTempFile(t, filepath.Join(root, "dir"), "file", "contents", 0644)
+
+	ctx := NewMountContext(root, nil)
+	defer ctx.Close()
+
+	var paths []string
+	err := ctx.Walk("dir", func(path string, fi FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if _, ok := fi.(Hashed); !ok {
+			t.Fatalf("FileInfo for %s should be Hashed", path)
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error when walking context: %s", err)
+	}
+
+	if len(paths) != 2 || paths[0] != "dir" || paths[1] != filepath.Join("dir", "file") {
+		t.Fatalf("Wrong paths walked, got: %v", paths)
+	}
+}
+
+func TestMountContextForbidsEscape(t *testing.T) {
+	root, cleanup := createTestTempDir(t, "", "builder-mount-context-test")
+	defer cleanup()
+
+	outside, err := ioutil.TempFile("", "builder-mount-context-outside")
+	if err != nil {
+		t.Fatalf("Error when creating file: %s", err)
+	}
+	outside.Close()
+	defer os.Remove(outside.Name())
+
+	if err := os.Symlink(outside.Name(), filepath.Join(root, "escape")); err != nil {
+		t.Fatalf("Error when creating symlink: %s", err)
+	}
+
+	ctx := NewMountContext(root, nil)
+	defer ctx.Close()
+
+	if _, _, err := ctx.Stat("escape"); err == nil {
+		t.Fatalf("Symlinks pointing outside of the mount should be resolved inside of it")
+	}
+}
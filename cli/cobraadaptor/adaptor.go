@@ -2,12 +2,15 @@ package cobraadaptor
 
 import (
 	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/api/client/checkpoint"
 	"github.com/docker/docker/api/client/container"
 	"github.com/docker/docker/api/client/image"
+	"github.com/docker/docker/api/client/manifest"
 	"github.com/docker/docker/api/client/network"
 	"github.com/docker/docker/api/client/node"
 	"github.com/docker/docker/api/client/plugin"
 	"github.com/docker/docker/api/client/registry"
+	"github.com/docker/docker/api/client/secret"
 	"github.com/docker/docker/api/client/service"
 	"github.com/docker/docker/api/client/stack"
 	"github.com/docker/docker/api/client/swarm"
@@ -43,9 +46,13 @@ func NewCobraAdaptor(clientFlags *cliflags.ClientFlags) CobraAdaptor {
 	rootCmd.AddCommand(
 		node.NewNodeCommand(dockerCli),
 		service.NewServiceCommand(dockerCli),
+		secret.NewSecretCommand(dockerCli),
+		manifest.NewManifestCommand(dockerCli),
 		stack.NewStackCommand(dockerCli),
 		stack.NewTopLevelDeployCommand(dockerCli),
 		swarm.NewSwarmCommand(dockerCli),
+		checkpoint.NewCheckpointCommand(dockerCli),
+		container.NewContainerCommand(dockerCli),
 		container.NewAttachCommand(dockerCli),
 		container.NewCommitCommand(dockerCli),
 		container.NewCopyCommand(dockerCli),
@@ -68,6 +75,7 @@ func NewCobraAdaptor(clientFlags *cliflags.ClientFlags) CobraAdaptor {
 		container.NewUnpauseCommand(dockerCli),
 		container.NewUpdateCommand(dockerCli),
 		container.NewWaitCommand(dockerCli),
+		image.NewImageCommand(dockerCli),
 		image.NewBuildCommand(dockerCli),
 		image.NewHistoryCommand(dockerCli),
 		image.NewImagesCommand(dockerCli),
@@ -86,6 +94,7 @@ func NewCobraAdaptor(clientFlags *cliflags.ClientFlags) CobraAdaptor {
 		system.NewVersionCommand(dockerCli),
 		volume.NewVolumeCommand(dockerCli),
 		system.NewInfoCommand(dockerCli),
+		system.NewSystemCommand(dockerCli),
 	)
 	plugin.NewPluginCommand(rootCmd, dockerCli)
 
@@ -26,6 +26,12 @@ type ConfigFile struct {
 	HTTPHeaders      map[string]string           `json:"HttpHeaders,omitempty"`
 	PsFormat         string                      `json:"psFormat,omitempty"`
 	ImagesFormat     string                      `json:"imagesFormat,omitempty"`
+	StatsFormat      string                      `json:"statsFormat,omitempty"`
+	NetworksFormat   string                      `json:"networksFormat,omitempty"`
+	VolumesFormat    string                      `json:"volumesFormat,omitempty"`
+	ServicesFormat   string                      `json:"servicesFormat,omitempty"`
+	NodesFormat      string                      `json:"nodesFormat,omitempty"`
+	PluginsFormat    string                      `json:"pluginsFormat,omitempty"`
 	DetachKeys       string                      `json:"detachKeys,omitempty"`
 	CredentialsStore string                      `json:"credsStore,omitempty"`
 	Filename         string                      `json:"-"` // Note: for internal use only
@@ -27,12 +27,13 @@ import (
 	cliflags "github.com/docker/docker/cli/flags"
 	"github.com/docker/docker/cliconfig"
 	"github.com/docker/docker/daemon"
+	"github.com/docker/docker/daemon/audit"
 	"github.com/docker/docker/daemon/cluster"
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/opts"
-	"github.com/docker/docker/pkg/authorization"
+	"github.com/docker/docker/pkg/authentication"
 	"github.com/docker/docker/pkg/jsonlog"
 	"github.com/docker/docker/pkg/listeners"
 	flag "github.com/docker/docker/pkg/mflag"
@@ -246,6 +247,12 @@ func (cli *DaemonCli) start() (err error) {
 		api.Accept(protoAddrParts[1], ls...)
 	}
 
+	if cli.Config.MetricsAddress != "" {
+		if err := startMetricsServer(cli.Config.MetricsAddress); err != nil {
+			return err
+		}
+	}
+
 	if err := migrateKey(); err != nil {
 		return err
 	}
@@ -290,7 +297,9 @@ func (cli *DaemonCli) start() (err error) {
 		"graphdriver": d.GraphDriverName(),
 	}).Info("Docker daemon")
 
-	cli.initMiddlewares(api, serverConfig)
+	if err := cli.initMiddlewares(api, serverConfig, d); err != nil {
+		logrus.Fatalf("Error creating middlewares: %v", err)
+	}
 	initRouter(api, d, c)
 
 	cli.d = d
@@ -422,12 +431,12 @@ func initRouter(s *apiserver.Server, d *daemon.Daemon, c *cluster.Cluster) {
 	if d.NetworkControllerEnabled() {
 		routers = append(routers, network.NewRouter(d, c))
 	}
-	routers = addExperimentalRouters(routers)
+	routers = addExperimentalRouters(routers, d)
 
 	s.InitRouter(utils.IsDebugEnabled(), routers...)
 }
 
-func (cli *DaemonCli) initMiddlewares(s *apiserver.Server, cfg *apiserver.Config) {
+func (cli *DaemonCli) initMiddlewares(s *apiserver.Server, cfg *apiserver.Config, d *daemon.Daemon) error {
 	v := cfg.Version
 
 	vm := middleware.NewVersionMiddleware(v, api.DefaultVersion, api.MinVersion)
@@ -441,9 +450,29 @@ func (cli *DaemonCli) initMiddlewares(s *apiserver.Server, cfg *apiserver.Config
 	u := middleware.NewUserAgentMiddleware(v)
 	s.UseMiddleware(u)
 
-	if len(cli.Config.AuthorizationPlugins) > 0 {
-		authZPlugins := authorization.NewPlugins(cli.Config.AuthorizationPlugins)
-		handleAuthorization := authorization.NewMiddleware(authZPlugins)
-		s.UseMiddleware(handleAuthorization)
+	s.UseMiddleware(d.AuthorizationMiddleware())
+
+	// The audit middleware is evaluated before the AuthZ middleware, so that
+	// denied requests are audited.
+	if logger := d.AuditLogger(); logger != nil {
+		s.UseMiddleware(audit.NewMiddleware(logger, d))
 	}
+
+	// The AuthN middleware is added last, so that it is evaluated first and
+	// the identity of the user reaches the AuthZ and audit middlewares.
+	var authenticators []authentication.Authenticator
+	for _, value := range cli.Config.Authenticators {
+		config, err := authentication.ParseConfig(value)
+		if err != nil {
+			return err
+		}
+		authenticator, err := authentication.New(config)
+		if err != nil {
+			return fmt.Errorf("error creating %s authenticator: %v", config.Type, err)
+		}
+		authenticators = append(authenticators, authenticator)
+	}
+	s.UseMiddleware(middleware.NewAuthNMiddleware(authenticators))
+
+	return nil
 }
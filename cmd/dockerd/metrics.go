@@ -0,0 +1,35 @@
+package main
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newMetricsHandler returns the handler serving the metrics of the daemon
+// at /metrics.
+func newMetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", prometheus.Handler())
+	return mux
+}
+
+// startMetricsServer serves the Prometheus metrics of the daemon on addr.
+func startMetricsServer(addr string) error {
+	if err := allocateDaemonPort(addr); err != nil {
+		return err
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := http.Serve(l, newMetricsHandler()); err != nil {
+			logrus.Errorf("serve metrics api: %s", err)
+		}
+	}()
+	logrus.Debugf("Listener created for metrics on tcp (%s)", addr)
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsHandlerServesDaemonMetrics(t *testing.T) {
+	srv := httptest.NewServer(newMetricsHandler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{
+		`engine_daemon_container_actions_seconds_count{action="create"}`,
+		`engine_daemon_container_actions_seconds_count{action="start"}`,
+		`engine_daemon_container_actions_seconds_count{action="stop"}`,
+		`engine_daemon_container_actions_seconds_count{action="delete"}`,
+		`engine_daemon_image_actions_seconds_count{action="pull"}`,
+		`engine_daemon_health_checks_total{result="success"}`,
+		`engine_daemon_health_checks_total{result="failure"}`,
+	} {
+		if !strings.Contains(string(b), name) {
+			t.Fatalf("expected metric %s to be served, got:\n%s", name, b)
+		}
+	}
+}
@@ -4,10 +4,15 @@ package main
 
 import (
 	"github.com/docker/docker/api/server/router"
+	checkpointrouter "github.com/docker/docker/api/server/router/checkpoint"
 	pluginrouter "github.com/docker/docker/api/server/router/plugin"
+	"github.com/docker/docker/daemon"
 	"github.com/docker/docker/plugin"
 )
 
-func addExperimentalRouters(routers []router.Router) []router.Router {
+func addExperimentalRouters(routers []router.Router, d *daemon.Daemon) []router.Router {
+	// the checkpoint router has to come before the container router or its
+	// DELETE route gets masked by the container removal one
+	routers = append([]router.Router{checkpointrouter.NewRouter(d)}, routers...)
 	return append(routers, pluginrouter.NewRouter(plugin.GetManager()))
 }
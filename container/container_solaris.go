@@ -62,6 +62,16 @@ func (container *Container) IpcMounts() []Mount {
 	return nil
 }
 
+// UnmountSecrets unmounts the secrets of the container.
+func (container *Container) UnmountSecrets(unmount func(pth string) error) error {
+	return nil
+}
+
+// SecretMount returns the mount for the secrets of the container.
+func (container *Container) SecretMount() (*Mount, error) {
+	return nil, nil
+}
+
 // UpdateContainer updates configuration of a container
 func (container *Container) UpdateContainer(hostConfig *container.HostConfig) error {
 	return nil
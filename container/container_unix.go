@@ -24,6 +24,10 @@ import (
 // DefaultSHMSize is the default size (64MB) of the SHM which will be mounted in the container
 const DefaultSHMSize int64 = 67108864
 
+// SecretsDirectory is the path inside the container where the secrets of a
+// service task are mounted.
+const SecretsDirectory = "/run/secrets"
+
 // Container holds the fields specific to unixen implementations.
 // See CommonContainer for standard fields common to all containers.
 type Container struct {
@@ -256,6 +260,47 @@ func (container *Container) IpcMounts() []Mount {
 	return mounts
 }
 
+// SecretMountPath returns the path of the secret mount for the container.
+func (container *Container) SecretMountPath() (string, error) {
+	return container.GetRootResourcePath("secrets")
+}
+
+// UnmountSecrets uses the provided unmount function to unmount the in-memory
+// filesystem holding the secrets of the container, if any.
+func (container *Container) UnmountSecrets(unmount func(pth string) error) error {
+	if len(container.Secrets) == 0 {
+		return nil
+	}
+
+	secretsPath, err := container.SecretMountPath()
+	if err != nil {
+		return err
+	}
+	if err := unmount(secretsPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to umount %s: %v", secretsPath, err)
+	}
+	return nil
+}
+
+// SecretMount returns the read-only mount for the secrets of the container,
+// or nil if the container has no secrets.
+func (container *Container) SecretMount() (*Mount, error) {
+	if len(container.Secrets) == 0 {
+		return nil, nil
+	}
+
+	secretsPath, err := container.SecretMountPath()
+	if err != nil {
+		return nil, err
+	}
+	return &Mount{
+		Source:      secretsPath,
+		Destination: SecretsDirectory,
+		Writable:    false,
+		Propagation: volume.DefaultPropagationMode,
+	}, nil
+}
+
 // UpdateContainer updates configuration of a container.
 func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfig) error {
 	container.Lock()
@@ -58,6 +58,18 @@ func (container *Container) TmpfsMounts() []Mount {
 	return mounts
 }
 
+// UnmountSecrets unmounts the secrets of the container.
+// This is a NOOP on windows.
+func (container *Container) UnmountSecrets(unmount func(pth string) error) error {
+	return nil
+}
+
+// SecretMount returns the mount for the secrets of the container.
+// This is a NOOP on windows.
+func (container *Container) SecretMount() (*Mount, error) {
+	return nil, nil
+}
+
 // UpdateContainer updates configuration of a container
 func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfig) error {
 	container.Lock()
@@ -0,0 +1,119 @@
+// Package audit implements the audit log of the daemon, a record of the
+// requests made to the remote API, of who made them and of their outcome.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/daemon/logger/loggerutils"
+)
+
+// Level is the verbosity of the audit log.
+type Level string
+
+const (
+	// MetadataLevel records who made each request, on which endpoint and
+	// object, and with which outcome.
+	MetadataLevel Level = "metadata"
+	// RequestLevel also records the query parameters and the headers of the
+	// requests.
+	RequestLevel Level = "request"
+	// BodyLevel also records the JSON bodies of the requests.
+	BodyLevel Level = "body"
+)
+
+// ParseLevel parses the verbosity of the audit log.
+func ParseLevel(value string) (Level, error) {
+	switch level := Level(value); level {
+	case MetadataLevel, RequestLevel, BodyLevel:
+		return level, nil
+	}
+	return "", fmt.Errorf("invalid audit log level %q: must be metadata, request or body", value)
+}
+
+// includes returns whether the level l includes level other.
+func (l Level) includes(other Level) bool {
+	order := map[Level]int{MetadataLevel: 0, RequestLevel: 1, BodyLevel: 2}
+	return order[l] >= order[other]
+}
+
+// Record is the record of a single API request in the audit log.
+type Record struct {
+	Time        time.Time           `json:"time"`
+	RemoteAddr  string              `json:"remoteAddr,omitempty"`
+	User        string              `json:"user,omitempty"`
+	AuthNMethod string              `json:"authnMethod,omitempty"`
+	Method      string              `json:"method"`
+	Path        string              `json:"path"`
+	Query       map[string][]string `json:"query,omitempty"`
+	Headers     map[string]string   `json:"headers,omitempty"`
+	Body        json.RawMessage     `json:"body,omitempty"`
+	Object      *Object             `json:"object,omitempty"`
+	// StatusCode is the status code of the response. It is omitted for the
+	// requests which hijack the connection, such as attach.
+	StatusCode   int    `json:"statusCode,omitempty"`
+	Error        string `json:"error,omitempty"`
+	DurationNano int64  `json:"durationNano"`
+}
+
+// Object is the object an API request operates on.
+type Object struct {
+	Type string `json:"type"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Logger writes the records of the audit log to a file, as one JSON object
+// per line. The file is rotated when it reaches its maximum size.
+type Logger struct {
+	level Level
+	w     *loggerutils.RotateFileWriter
+}
+
+// NewLogger opens the audit log at path, creating it if needed. The log is
+// split into at most maxFiles files of maxSize bytes.
+func NewLogger(path string, maxSize int64, maxFiles int, level Level) (*Logger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	// The audit log may hold sensitive data, make sure it is only readable
+	// by root, even if the rotating writer would create it more permissive.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+
+	w, err := loggerutils.NewRotateFileWriter(path, maxSize, maxFiles, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{
+		level: level,
+		w:     w,
+	}, nil
+}
+
+// Level returns the verbosity of the audit log.
+func (l *Logger) Level() Level {
+	return l.level
+}
+
+// Log writes a record to the audit log.
+func (l *Logger) Log(r *Record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = l.w.Write(append(b, '\n'))
+	return err
+}
+
+// Close closes the audit log.
+func (l *Logger) Close() error {
+	return l.w.Close()
+}
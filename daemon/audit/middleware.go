@@ -0,0 +1,185 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/server/httputils"
+	"github.com/docker/docker/pkg/authentication"
+	"github.com/docker/docker/pkg/authorization"
+	"github.com/docker/docker/pkg/ioutils"
+	"golang.org/x/net/context"
+)
+
+// maxBodySize is the maximum size of the request bodies recorded at the body
+// level. Larger bodies are not recorded.
+const maxBodySize = 1048576 // 1MB
+
+// Middleware records the API requests in the audit log.
+type Middleware struct {
+	logger   *Logger
+	resolver authorization.ObjectResolver
+}
+
+// NewMiddleware creates a new Middleware writing to logger. If resolver is
+// not nil, it is used to record the IDs of the objects the requests operate
+// on.
+func NewMiddleware(logger *Logger, resolver authorization.ObjectResolver) Middleware {
+	return Middleware{
+		logger:   logger,
+		resolver: resolver,
+	}
+}
+
+// WrapHandler returns a new handler function wrapping the previous one in the request chain.
+func (m Middleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		start := time.Now()
+		record := m.newRecord(ctx, r, vars)
+
+		rw := &statusRecorder{ResponseWriter: w}
+		err := handler(ctx, rw, r, vars)
+
+		record.DurationNano = time.Since(start).Nanoseconds()
+		switch {
+		case err != nil:
+			record.StatusCode = httputils.GetHTTPErrorStatusCode(err)
+			record.Error = err.Error()
+		case !rw.hijacked:
+			record.StatusCode = rw.status()
+		}
+
+		if err := m.logger.Log(record); err != nil {
+			logrus.Errorf("Error writing audit record for %s %s: %v", r.Method, r.RequestURI, err)
+		}
+		return err
+	}
+}
+
+// newRecord returns the record of r, before it is handled.
+func (m Middleware) newRecord(ctx context.Context, r *http.Request, vars map[string]string) *Record {
+	record := &Record{
+		Time:       time.Now().UTC(),
+		RemoteAddr: r.RemoteAddr,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+	}
+	if id := authentication.IdentityFromContext(ctx); id != nil {
+		record.User = id.User
+		record.AuthNMethod = id.Method
+	}
+
+	// The object is resolved before the request is handled, so that the
+	// IDs of removed objects are recorded.
+	if m.resolver != nil {
+		if object := authorization.ResolveRequestObject(m.resolver, r, vars); object != nil {
+			record.Object = &Object{
+				Type: object.Type,
+				ID:   object.ID,
+				Name: object.Name,
+			}
+		}
+	}
+
+	level := m.logger.Level()
+	if level.includes(RequestLevel) {
+		record.Query = redactQuery(r.URL.Query())
+		record.Headers = redactHeaders(r.Header)
+	}
+	if level.includes(BodyLevel) && isJSON(r) {
+		body, err := peekBody(r)
+		if err != nil {
+			logrus.Debugf("Error reading the body of %s %s for the audit log: %v", r.Method, r.RequestURI, err)
+		} else if body != nil {
+			record.Body = redactBody(r.URL.Path, body)
+		}
+	}
+	return record
+}
+
+func isJSON(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+}
+
+// peekBody returns the body of r, and restores it for the handler of r. It
+// returns nil if the body is larger than maxBodySize.
+func peekBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil, nil
+	}
+	body := r.Body
+	data, err := ioutil.ReadAll(io.LimitReader(body, maxBodySize+1))
+	r.Body = ioutils.NewReadCloserWrapper(io.MultiReader(bytes.NewReader(data), body), body.Close)
+	if err != nil || len(data) > maxBodySize {
+		return nil, err
+	}
+	return data, nil
+}
+
+// statusRecorder records the status code written to a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	hijacked   bool
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	if s.statusCode == 0 {
+		s.statusCode = code
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.statusCode == 0 {
+		s.statusCode = http.StatusOK
+	}
+	return s.ResponseWriter.Write(b)
+}
+
+// status returns the status code of the response, which is 200 if the
+// handler didn't write anything.
+func (s *statusRecorder) status() int {
+	if s.statusCode == 0 {
+		return http.StatusOK
+	}
+	return s.statusCode
+}
+
+// Hijack returns the internal connection of the wrapped http.ResponseWriter
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("Internal response writer doesn't support the Hijacker interface")
+	}
+	s.hijacked = true
+	return hijacker.Hijack()
+}
+
+// CloseNotify uses the internal close notify API of the wrapped http.ResponseWriter
+func (s *statusRecorder) CloseNotify() <-chan bool {
+	closeNotifier, ok := s.ResponseWriter.(http.CloseNotifier)
+	if !ok {
+		logrus.Error("Internal response writer doesn't support the CloseNotifier interface")
+		return nil
+	}
+	return closeNotifier.CloseNotify()
+}
+
+// Flush uses the internal flush API of the wrapped http.ResponseWriter
+func (s *statusRecorder) Flush() {
+	flusher, ok := s.ResponseWriter.(http.Flusher)
+	if !ok {
+		logrus.Error("Internal response writer doesn't support the Flusher interface")
+		return
+	}
+	flusher.Flush()
+}
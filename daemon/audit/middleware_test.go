@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/errors"
+	"github.com/docker/docker/pkg/authentication"
+	"github.com/docker/docker/pkg/authorization"
+	"golang.org/x/net/context"
+)
+
+type fakeResolver struct{}
+
+func (fakeResolver) ResolveObject(objectType, name string) (*authorization.Object, error) {
+	if name != "foo" {
+		return nil, fmt.Errorf("no such %s: %s", objectType, name)
+	}
+	return &authorization.Object{Type: objectType, ID: "abcdef", Name: name}, nil
+}
+
+func readRecords(t *testing.T, path string) []Record {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestMiddleware(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "audit.log")
+	logger, err := NewLogger(path, 1024*1024, 2, BodyLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(string(b), "s3cr3t") {
+			t.Fatalf("Expected the handler to get the original body, got %s", b)
+		}
+		if vars["name"] != "foo" {
+			return errors.NewRequestNotFoundError(fmt.Errorf("No such container: %s", vars["name"]))
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+	h := NewMiddleware(logger, fakeResolver{}).WrapHandler(handler)
+
+	ctx := authentication.WithIdentity(context.Background(), &authentication.Identity{User: "alice", Method: "token"})
+	for _, name := range []string{"foo", "bar"} {
+		body := `{"Env": ["PASSWORD=s3cr3t"], "AuthConfig": {"password": "s3cr3t"}}`
+		req, _ := http.NewRequest("POST", "/v1.24/containers/"+name+"/update", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer s3cr3t")
+		h(ctx, httptest.NewRecorder(), req, map[string]string{"name": name})
+	}
+
+	records := readRecords(t, path)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	r := records[0]
+	if r.User != "alice" || r.AuthNMethod != "token" || r.Method != "POST" || r.Path != "/v1.24/containers/foo/update" {
+		t.Fatalf("Unexpected record %+v", r)
+	}
+	if r.StatusCode != http.StatusNoContent || r.Error != "" {
+		t.Fatalf("Expected status %d, got %d (%s)", http.StatusNoContent, r.StatusCode, r.Error)
+	}
+	if r.Object == nil || r.Object.ID != "abcdef" || r.Object.Type != authorization.ContainerObject {
+		t.Fatalf("Expected the resolved container, got %+v", r.Object)
+	}
+	if r.Headers["Authorization"] != redacted {
+		t.Fatalf("Expected the Authorization header to be redacted, got %q", r.Headers["Authorization"])
+	}
+	if strings.Contains(string(r.Body), "s3cr3t") || !strings.Contains(string(r.Body), `"PASSWORD=[redacted]"`) {
+		t.Fatalf("Expected the body to be redacted, got %s", r.Body)
+	}
+
+	r = records[1]
+	if r.StatusCode != http.StatusNotFound || !strings.Contains(r.Error, "No such container") || r.Object != nil {
+		t.Fatalf("Expected a not found record without object, got %+v", r)
+	}
+}
+
+func TestMiddlewareMetadataLevel(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "audit.log")
+	logger, err := NewLogger(path, 1024*1024, 2, MetadataLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		return nil
+	}
+	h := NewMiddleware(logger, nil).WrapHandler(handler)
+
+	req, _ := http.NewRequest("POST", "/v1.24/containers/create?name=foo", strings.NewReader(`{"Image": "busybox"}`))
+	req.Header.Set("Content-Type", "application/json")
+	h(context.Background(), httptest.NewRecorder(), req, map[string]string{})
+
+	records := readRecords(t, path)
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+	if r.StatusCode != http.StatusOK || r.User != "" {
+		t.Fatalf("Unexpected record %+v", r)
+	}
+	if r.Query != nil || r.Headers != nil || r.Body != nil {
+		t.Fatalf("Expected no query, headers or body at the metadata level, got %+v", r)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, value := range []string{"metadata", "request", "body"} {
+		if _, err := ParseLevel(value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := ParseLevel("debug"); err == nil {
+		t.Fatal("Expected an error for an unknown level")
+	}
+}
@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redacted = "[redacted]"
+
+// sensitiveHeaders are the headers holding credentials, whose values are
+// never recorded.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"X-Registry-Auth",
+	"X-Registry-Config",
+}
+
+// sensitiveKeys are the keys of the JSON bodies holding credentials, whose
+// values are never recorded. They are compared case-insensitively.
+var sensitiveKeys = map[string]bool{
+	"auth":          true,
+	"identitytoken": true,
+	"jointoken":     true,
+	"password":      true,
+	"registrytoken": true,
+	"secret":        true,
+}
+
+// secretKeys are the keys of the JSON bodies of the secrets endpoints holding
+// the data of the secrets, whose values are never recorded.
+var secretKeys = map[string]bool{
+	"data": true,
+}
+
+// redactHeaders returns the headers of a request, with the values of the
+// headers holding credentials redacted.
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for k := range header {
+		headers[k] = header.Get(k)
+	}
+	for _, k := range sensitiveHeaders {
+		if _, ok := headers[k]; ok {
+			headers[k] = redacted
+		}
+	}
+	return headers
+}
+
+// redactQuery returns the query parameters of a request, with the values of
+// the build arguments redacted, as they are often used to pass secrets.
+func redactQuery(query url.Values) map[string][]string {
+	values := make(map[string][]string, len(query))
+	for k, v := range query {
+		values[k] = v
+	}
+	if buildArgs := query.Get("buildargs"); buildArgs != "" {
+		args := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(buildArgs), &args); err == nil {
+			for k := range args {
+				args[k] = redacted
+			}
+			b, _ := json.Marshal(args)
+			values["buildargs"] = []string{string(b)}
+		} else {
+			values["buildargs"] = []string{redacted}
+		}
+	}
+	return values
+}
+
+// redactBody returns the JSON body of a request to path, with the
+// credentials, the data of the secrets and the values of the environment
+// variables redacted. It returns nil if the body is not valid JSON.
+func redactBody(path string, body []byte) json.RawMessage {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+	keys := sensitiveKeys
+	if isSecretPath(path) {
+		keys = make(map[string]bool, len(sensitiveKeys)+len(secretKeys))
+		for k := range sensitiveKeys {
+			keys[k] = true
+		}
+		for k := range secretKeys {
+			keys[k] = true
+		}
+	}
+	b, err := json.Marshal(redactValue(v, keys))
+	if err != nil {
+		return nil
+	}
+	return json.RawMessage(b)
+}
+
+// isSecretPath returns whether path is an endpoint of the secrets, for
+// example /v1.25/secrets/create.
+func isSecretPath(path string) bool {
+	for _, elem := range strings.Split(path, "/") {
+		if elem == "secrets" {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue redacts the values of keys in v, and the values of the
+// environment variables.
+func redactValue(v interface{}, keys map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			switch {
+			case keys[strings.ToLower(k)]:
+				if value != nil && value != "" {
+					v[k] = redacted
+				}
+			case strings.EqualFold(k, "Env"):
+				v[k] = redactEnv(value)
+			default:
+				v[k] = redactValue(value, keys)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value, keys)
+		}
+		return v
+	}
+	return v
+}
+
+// redactEnv redacts the values of a list of environment variables, keeping
+// their names.
+func redactEnv(v interface{}) interface{} {
+	env, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+	for i, e := range env {
+		s, ok := e.(string)
+		if !ok {
+			continue
+		}
+		if parts := strings.SplitN(s, "=", 2); len(parts) == 2 {
+			env[i] = parts[0] + "=" + redacted
+		}
+	}
+	return env
+}
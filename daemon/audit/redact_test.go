@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := `{
+		"Image": "busybox",
+		"Env": ["FOO=bar", "EMPTY"],
+		"TaskTemplate": {"ContainerSpec": {"Env": ["TOKEN=s3cr3t"]}},
+		"Password": "s3cr3t",
+		"IdentityToken": ""
+	}`
+	redactedBody := string(redactBody("/v1.25/containers/create", []byte(body)))
+	for _, expected := range []string{`"Image":"busybox"`, `"FOO=[redacted]"`, `"EMPTY"`, `"TOKEN=[redacted]"`, `"Password":"[redacted]"`, `"IdentityToken":""`} {
+		if !strings.Contains(redactedBody, expected) {
+			t.Fatalf("Expected %s in %s", expected, redactedBody)
+		}
+	}
+	if strings.Contains(redactedBody, "bar") || strings.Contains(redactedBody, "s3cr3t") {
+		t.Fatalf("Expected the secrets to be redacted, got %s", redactedBody)
+	}
+
+	if redactBody("/v1.25/containers/create", []byte("not json")) != nil {
+		t.Fatal("Expected no body for an invalid JSON body")
+	}
+}
+
+func TestRedactSecretBody(t *testing.T) {
+	body := `{"Name": "db_password", "Labels": {"env": "prod"}, "Data": "czNjcjN0"}`
+	redactedBody := string(redactBody("/v1.25/secrets/create", []byte(body)))
+	for _, expected := range []string{`"Name":"db_password"`, `"env":"prod"`, `"Data":"[redacted]"`} {
+		if !strings.Contains(redactedBody, expected) {
+			t.Fatalf("Expected %s in %s", expected, redactedBody)
+		}
+	}
+
+	// Data is only redacted on the secrets endpoints
+	redactedBody = string(redactBody("/v1.25/swarm/init", []byte(`{"Data": "value"}`)))
+	if !strings.Contains(redactedBody, `"Data":"value"`) {
+		t.Fatalf("Expected the data to be kept, got %s", redactedBody)
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	query := redactQuery(url.Values{
+		"t":         {"foo"},
+		"buildargs": {`{"TOKEN": "s3cr3t"}`},
+	})
+	if query["t"][0] != "foo" {
+		t.Fatalf("Expected the t parameter to be kept, got %v", query["t"])
+	}
+	if strings.Contains(query["buildargs"][0], "s3cr3t") || !strings.Contains(query["buildargs"][0], "TOKEN") {
+		t.Fatalf("Expected the value of the build argument to be redacted, got %v", query["buildargs"])
+	}
+}
@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/pkg/authorization"
+)
+
+// ResolveObject returns the identity and the labels of the object of type
+// objectType referenced by name, for authorization plugins. An exec instance
+// resolves to the container it runs in.
+func (daemon *Daemon) ResolveObject(objectType, name string) (*authorization.Object, error) {
+	switch objectType {
+	case authorization.ContainerObject:
+		return daemon.resolveContainer(name)
+	case authorization.ExecObject:
+		ec := daemon.execCommands.Get(name)
+		if ec == nil {
+			return nil, errExecNotFound(name)
+		}
+		return daemon.resolveContainer(ec.ContainerID)
+	case authorization.ImageObject:
+		img, err := daemon.GetImage(name)
+		if err != nil {
+			return nil, err
+		}
+		object := &authorization.Object{
+			Type: authorization.ImageObject,
+			ID:   img.ID().String(),
+			Name: name,
+		}
+		if img.Config != nil {
+			object.Labels = img.Config.Labels
+		}
+		return object, nil
+	case authorization.NetworkObject:
+		n, err := daemon.FindNetwork(name)
+		if err != nil {
+			return nil, err
+		}
+		return &authorization.Object{
+			Type:   authorization.NetworkObject,
+			ID:     n.ID(),
+			Name:   n.Name(),
+			Labels: n.Info().Labels(),
+		}, nil
+	case authorization.VolumeObject:
+		v, err := daemon.volumes.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		apiV := volumeToAPIType(v)
+		return &authorization.Object{
+			Type:   authorization.VolumeObject,
+			ID:     apiV.Name,
+			Name:   apiV.Name,
+			Labels: apiV.Labels,
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown object type %q", objectType)
+}
+
+func (daemon *Daemon) resolveContainer(name string) (*authorization.Object, error) {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+	return &authorization.Object{
+		Type:   authorization.ContainerObject,
+		ID:     container.ID,
+		Name:   strings.TrimPrefix(container.Name, "/"),
+		Labels: container.Config.Labels,
+	}, nil
+}
@@ -0,0 +1,214 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/dockerversion"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	containertypes "github.com/docker/engine-api/types/container"
+)
+
+// MakeImageCache creates a stateful image cache.
+func (daemon *Daemon) MakeImageCache(sourceRefs []string) builder.ImageCache {
+	if len(sourceRefs) == 0 {
+		return daemon
+	}
+
+	cache := &imageCache{daemon: daemon}
+
+	for _, ref := range sourceRefs {
+		img, err := daemon.GetImage(ref)
+		if err != nil {
+			logrus.Warnf("Could not look up %s for cache resolution, skipping: %v", ref, err)
+			continue
+		}
+		cache.sources = append(cache.sources, img)
+	}
+
+	return cache
+}
+
+// imageCache is cache based on history objects. Requires initial set of images.
+type imageCache struct {
+	sources []*image.Image
+	daemon  *Daemon
+}
+
+// GetCachedImageOnBuild returns a reference to a cached image whose parent
+// equals parentID and runconfig equals cfg. The images from the local cache
+// are only used if they descend from one of the cache sources; otherwise the
+// cache sources are searched for an image whose history and layers extend
+// those of the parent with a step matching cfg.
+func (ic *imageCache) GetCachedImageOnBuild(parentID string, cfg *containertypes.Config) (string, error) {
+	imgID, err := ic.daemon.GetCachedImageOnBuild(parentID, cfg)
+	if err != nil {
+		return "", err
+	}
+	if imgID != "" {
+		for _, s := range ic.sources {
+			if ic.isParent(s.ID(), image.ID(imgID)) {
+				return imgID, nil
+			}
+		}
+	}
+
+	var parent *image.Image
+	lenHistory := 0
+	if parentID != "" {
+		parent, err = ic.daemon.imageStore.Get(image.ID(parentID))
+		if err != nil {
+			return "", fmt.Errorf("unable to find image %v: %v", parentID, err)
+		}
+		lenHistory = len(parent.History)
+	}
+
+	for _, target := range ic.sources {
+		if !isValidParent(target, parent) || !isValidConfig(cfg, target.History[lenHistory]) {
+			continue
+		}
+
+		if len(target.History)-1 == lenHistory { // last
+			if parent != nil {
+				if err := ic.daemon.imageStore.SetParent(target.ID(), parent.ID()); err != nil {
+					return "", fmt.Errorf("failed to set parent for %v to %v: %v", target.ID(), parent.ID(), err)
+				}
+			}
+			return target.ID().String(), nil
+		}
+
+		imgID, err := ic.restoreCachedImage(parent, target, cfg)
+		if err != nil {
+			return "", fmt.Errorf("failed to restore cached image from %q to %v: %v", parentID, target.ID(), err)
+		}
+
+		ic.sources = []*image.Image{target} // avoid jumping to a different target on the next step
+		return imgID.String(), nil
+	}
+
+	return "", nil
+}
+
+// restoreCachedImage creates the intermediate image for the step of target
+// that follows parent. Its layers are already present as target was pulled.
+func (ic *imageCache) restoreCachedImage(parent, target *image.Image, cfg *containertypes.Config) (image.ID, error) {
+	var history []image.History
+	rootFS := image.NewRootFS()
+	lenHistory := 0
+	if parent != nil {
+		history = append(history, parent.History...)
+		rootFS = &image.RootFS{}
+		*rootFS = *parent.RootFS
+		rootFS.DiffIDs = append([]layer.DiffID(nil), parent.RootFS.DiffIDs...)
+		lenHistory = len(parent.History)
+	}
+	history = append(history, target.History[lenHistory])
+	if diffID := getLayerForHistoryIndex(target, lenHistory); diffID != "" {
+		rootFS.Append(diffID)
+	}
+
+	config, err := json.Marshal(&image.Image{
+		V1Image: image.V1Image{
+			DockerVersion:   dockerversion.Version,
+			Config:          cfg,
+			ContainerConfig: *cfg,
+			Architecture:    target.Architecture,
+			OS:              target.OS,
+			Author:          target.Author,
+			Created:         history[len(history)-1].Created,
+		},
+		RootFS:     rootFS,
+		History:    history,
+		OSFeatures: target.OSFeatures,
+		OSVersion:  target.OSVersion,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal image config: %v", err)
+	}
+
+	imgID, err := ic.daemon.imageStore.Create(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cache image: %v", err)
+	}
+
+	if parent != nil {
+		if err := ic.daemon.imageStore.SetParent(imgID, parent.ID()); err != nil {
+			return "", fmt.Errorf("failed to set parent for %v to %v: %v", imgID, parent.ID(), err)
+		}
+	}
+	return imgID, nil
+}
+
+// isParent returns true if parentID is an ancestor of imgID.
+func (ic *imageCache) isParent(imgID, parentID image.ID) bool {
+	nextParent, err := ic.daemon.imageStore.GetParent(imgID)
+	if err != nil {
+		return false
+	}
+	if nextParent == parentID {
+		return true
+	}
+	return ic.isParent(nextParent, parentID)
+}
+
+// getLayerForHistoryIndex returns the layer created by the history entry at
+// index of img, or an empty DiffID if that entry did not create a layer.
+func getLayerForHistoryIndex(img *image.Image, index int) layer.DiffID {
+	layerIndex := 0
+	for i, h := range img.History {
+		if i == index {
+			if h.EmptyLayer {
+				return ""
+			}
+			break
+		}
+		if !h.EmptyLayer {
+			layerIndex++
+		}
+	}
+	if layerIndex >= len(img.RootFS.DiffIDs) {
+		return ""
+	}
+	return img.RootFS.DiffIDs[layerIndex]
+}
+
+// isValidConfig returns true if the history entry h was created by the
+// command of cfg.
+func isValidConfig(cfg *containertypes.Config, h image.History) bool {
+	// the commit records the command joined with spaces, see daemon.Commit
+	return strings.Join(cfg.Cmd, " ") == h.CreatedBy
+}
+
+// isValidParent returns true if the history and layers of img extend the
+// ones of parent by at least one step.
+func isValidParent(img, parent *image.Image) bool {
+	if len(img.History) == 0 {
+		return false
+	}
+	if parent == nil || len(parent.History) == 0 && len(parent.RootFS.DiffIDs) == 0 {
+		return true
+	}
+	if len(parent.History) >= len(img.History) {
+		return false
+	}
+	if len(parent.RootFS.DiffIDs) > len(img.RootFS.DiffIDs) {
+		return false
+	}
+
+	for i, h := range parent.History {
+		if !reflect.DeepEqual(h, img.History[i]) {
+			return false
+		}
+	}
+	for i, d := range parent.RootFS.DiffIDs {
+		if d != img.RootFS.DiffIDs[i] {
+			return false
+		}
+	}
+	return true
+}
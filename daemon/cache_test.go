@@ -0,0 +1,78 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	containertypes "github.com/docker/engine-api/types/container"
+)
+
+func newCacheTestImage(history []image.History, diffIDs ...layer.DiffID) *image.Image {
+	rootFS := image.NewRootFS()
+	for _, d := range diffIDs {
+		rootFS.Append(d)
+	}
+	return &image.Image{RootFS: rootFS, History: history}
+}
+
+func TestCacheIsValidParent(t *testing.T) {
+	created := time.Unix(1470000000, 0)
+	base := []image.History{{Created: created, CreatedBy: "/bin/sh -c #(nop) ADD file:abc in /"}}
+	next := image.History{Created: created, CreatedBy: "/bin/sh -c #(nop) ENV foo=bar", EmptyLayer: true}
+
+	parent := newCacheTestImage(base, "sha256:1")
+	target := newCacheTestImage(append(append([]image.History{}, base...), next), "sha256:1")
+
+	if !isValidParent(target, nil) {
+		t.Fatal("expected any image with history to be valid for scratch")
+	}
+	if !isValidParent(target, parent) {
+		t.Fatal("expected image extending the parent history to be valid")
+	}
+	if isValidParent(parent, parent) {
+		t.Fatal("expected image to not be a valid parent of itself")
+	}
+
+	other := newCacheTestImage(base, "sha256:2")
+	if isValidParent(target, other) {
+		t.Fatal("expected parent with different layers to be invalid")
+	}
+
+	changed := newCacheTestImage([]image.History{{Created: created.Add(time.Second), CreatedBy: base[0].CreatedBy}}, "sha256:1")
+	if isValidParent(target, changed) {
+		t.Fatal("expected parent with different history to be invalid")
+	}
+
+	if isValidParent(newCacheTestImage(nil), parent) {
+		t.Fatal("expected image without history to be invalid")
+	}
+}
+
+func TestCacheIsValidConfig(t *testing.T) {
+	h := image.History{CreatedBy: "/bin/sh -c #(nop) ENV foo=bar"}
+	cfg := &containertypes.Config{Cmd: []string{"/bin/sh", "-c", "#(nop) ENV foo=bar"}}
+	if !isValidConfig(cfg, h) {
+		t.Fatal("expected config to match history")
+	}
+	cfg.Cmd = []string{"/bin/sh", "-c", "#(nop) ENV foo=baz"}
+	if isValidConfig(cfg, h) {
+		t.Fatal("expected config to not match history")
+	}
+}
+
+func TestCacheGetLayerForHistoryIndex(t *testing.T) {
+	img := newCacheTestImage([]image.History{
+		{CreatedBy: "ADD"},
+		{CreatedBy: "ENV", EmptyLayer: true},
+		{CreatedBy: "RUN"},
+	}, "sha256:1", "sha256:2")
+
+	expected := []layer.DiffID{"sha256:1", "", "sha256:2"}
+	for i, e := range expected {
+		if actual := getLayerForHistoryIndex(img, i); actual != e {
+			t.Fatalf("expected layer %q for history %d, got %q", e, i, actual)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package daemon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errors"
+	"github.com/docker/docker/utils"
+	"github.com/docker/engine-api/types"
+)
+
+var validCheckpointNamePattern = regexp.MustCompile(`^` + utils.RestrictedNameChars + `+$`)
+
+// validateCheckpointName makes sure a checkpoint name can safely be used as
+// a directory name inside the container's checkpoint directory.
+func validateCheckpointName(name string) error {
+	if name == "" {
+		return errors.NewBadRequestError(fmt.Errorf("Checkpoint name must not be empty"))
+	}
+	if !validCheckpointNamePattern.MatchString(name) {
+		return errors.NewBadRequestError(fmt.Errorf("Invalid checkpoint name (%s), only %s are allowed", name, utils.RestrictedNameChars))
+	}
+	return nil
+}
+
+// getCheckpointDir returns the directory the checkpoints of container are
+// stored in, which is dir if it is set or the container's checkpoint
+// directory otherwise.
+func getCheckpointDir(container *container.Container, dir string) (string, error) {
+	if dir == "" {
+		return container.CheckpointDir(), nil
+	}
+	if !filepath.IsAbs(dir) {
+		return "", errors.NewBadRequestError(fmt.Errorf("Checkpoint directory must be an absolute path: %s", dir))
+	}
+	return filepath.Clean(dir), nil
+}
+
+// CheckpointCreate checkpoints the process running in a container with CRIU
+func (daemon *Daemon) CheckpointCreate(name string, config types.CheckpointCreateOptions) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if !container.IsRunning() {
+		return fmt.Errorf("Container %s not running", name)
+	}
+
+	if err := validateCheckpointName(config.CheckpointID); err != nil {
+		return err
+	}
+
+	checkpointDir, err := getCheckpointDir(container, config.CheckpointDir)
+	if err != nil {
+		return err
+	}
+
+	err = daemon.containerd.CreateCheckpoint(container.ID, config.CheckpointID, checkpointDir, config.Exit)
+	if err != nil {
+		return fmt.Errorf("Cannot checkpoint container %s: %s", name, err)
+	}
+
+	daemon.LogContainerEvent(container, "checkpoint")
+
+	return nil
+}
+
+// CheckpointDelete deletes the specified checkpoint
+func (daemon *Daemon) CheckpointDelete(name string, checkpoint string) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if err := validateCheckpointName(checkpoint); err != nil {
+		return err
+	}
+
+	checkpointDir := container.CheckpointDir()
+	if _, err := os.Stat(filepath.Join(checkpointDir, checkpoint)); err != nil {
+		if os.IsNotExist(err) {
+			return errors.NewRequestNotFoundError(fmt.Errorf("No such checkpoint: %s", checkpoint))
+		}
+		return err
+	}
+	return os.RemoveAll(filepath.Join(checkpointDir, checkpoint))
+}
+
+// CheckpointList lists all checkpoints of the specified container
+func (daemon *Daemon) CheckpointList(name string) ([]types.Checkpoint, error) {
+	var out []types.Checkpoint
+
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs, err := ioutil.ReadDir(container.CheckpointDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, err
+	}
+
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		out = append(out, types.Checkpoint{Name: d.Name()})
+	}
+	return out, nil
+}
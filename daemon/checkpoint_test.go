@@ -0,0 +1,60 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/container"
+	"golang.org/x/net/context"
+)
+
+func TestValidateCheckpointName(t *testing.T) {
+	for _, name := range []string{"checkpoint1", "my-checkpoint", "cp_1.0"} {
+		if err := validateCheckpointName(name); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "..", "../foo", "foo/bar", "-foo", ".hidden"} {
+		if err := validateCheckpointName(name); err == nil {
+			t.Fatalf("expected %q to be invalid", name)
+		}
+	}
+}
+
+func TestContainerStartRejectsCheckpointTraversal(t *testing.T) {
+	daemon := &Daemon{}
+	for _, name := range []string{"../../..", "../../../etc", "foo/../../bar"} {
+		err := daemon.ContainerStart(context.Background(), "foo", nil, name, "")
+		if err == nil || !strings.Contains(err.Error(), "Invalid checkpoint name") {
+			t.Fatalf("expected checkpoint %q to be rejected, got %v", name, err)
+		}
+	}
+}
+
+func TestGetCheckpointDir(t *testing.T) {
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			Root: "/var/lib/docker/containers/foo",
+		},
+	}
+
+	dir, err := getCheckpointDir(c, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != c.CheckpointDir() {
+		t.Fatalf("expected the container's checkpoint directory %s, got %s", c.CheckpointDir(), dir)
+	}
+
+	dir, err = getCheckpointDir(c, "/tmp/checkpoints/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != "/tmp/checkpoints" {
+		t.Fatalf("expected the custom checkpoint directory, got %s", dir)
+	}
+
+	if _, err := getCheckpointDir(c, "checkpoints"); err == nil {
+		t.Fatal("expected a relative checkpoint directory to be rejected")
+	}
+}
@@ -3,9 +3,11 @@ package cluster
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,17 +16,22 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/daemon/cluster/convert"
 	executorpkg "github.com/docker/docker/daemon/cluster/executor"
 	"github.com/docker/docker/daemon/cluster/executor/container"
+	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/errors"
 	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/docker/runconfig"
 	apitypes "github.com/docker/engine-api/types"
 	types "github.com/docker/engine-api/types/swarm"
+	timetypes "github.com/docker/engine-api/types/time"
 	swarmagent "github.com/docker/swarmkit/agent"
 	swarmapi "github.com/docker/swarmkit/api"
+	"github.com/docker/swarmkit/protobuf/ptypes"
 	"golang.org/x/net/context"
 )
 
@@ -34,6 +41,10 @@ const swarmConnectTimeout = 20 * time.Second
 const stateFile = "docker-state.json"
 const defaultAddr = "0.0.0.0:2377"
 
+// logContextPrefix is the prefix of the details attached to each line of the
+// logs of a service, which identify the task that produced it.
+const logContextPrefix = "com.docker.swarm"
+
 const (
 	initialReconnectDelay = 100 * time.Millisecond
 	maxReconnectDelay     = 30 * time.Second
@@ -102,6 +113,7 @@ type node struct {
 	ready          bool
 	conn           *grpc.ClientConn
 	client         swarmapi.ControlClient
+	logs           swarmapi.LogsClient
 	reconnectDelay time.Duration
 }
 
@@ -270,8 +282,10 @@ func (c *Cluster) startNewNode(forceNewCluster bool, listenAddr, joinAddr, secre
 			if node.conn != conn {
 				if conn == nil {
 					node.client = nil
+					node.logs = nil
 				} else {
 					node.client = swarmapi.NewControlClient(conn)
+					node.logs = swarmapi.NewLogsClient(conn)
 				}
 			}
 			node.conn = conn
@@ -433,7 +447,7 @@ func (c *Cluster) Leave(force bool) error {
 	c.Unlock()
 	if nodeID := node.NodeID(); nodeID != "" {
 		for _, id := range c.config.Backend.ListContainersForNode(nodeID) {
-			if err := c.config.Backend.ContainerRm(id, &apitypes.ContainerRmConfig{ForceRemove: true}); err != nil {
+			if err := c.config.Backend.ContainerRm(context.Background(), id, &apitypes.ContainerRmConfig{ForceRemove: true}); err != nil {
 				logrus.Errorf("error removing %v: %v", id, err)
 			}
 		}
@@ -678,6 +692,10 @@ func (c *Cluster) CreateService(s types.ServiceSpec) (string, error) {
 		return "", err
 	}
 
+	if err := populateSecretID(ctx, c.client, &s); err != nil {
+		return "", err
+	}
+
 	serviceSpec, err := convert.ServiceSpecToGRPC(s)
 	if err != nil {
 		return "", err
@@ -715,13 +733,19 @@ func (c *Cluster) UpdateService(serviceID string, version uint64, spec types.Ser
 		return c.errNoManager()
 	}
 
+	ctx := c.getRequestContext()
+
+	if err := populateSecretID(ctx, c.client, &spec); err != nil {
+		return err
+	}
+
 	serviceSpec, err := convert.ServiceSpecToGRPC(spec)
 	if err != nil {
 		return err
 	}
 
 	_, err = c.client.UpdateService(
-		c.getRequestContext(),
+		ctx,
 		&swarmapi.UpdateServiceRequest{
 			ServiceID: serviceID,
 			Spec:      &serviceSpec,
@@ -753,6 +777,130 @@ func (c *Cluster) RemoveService(input string) error {
 	return nil
 }
 
+// ServiceLogs collects the logs of the tasks of a service and writes them to
+// config.OutStream. Each line is prefixed with the IDs of the node, service
+// and task it comes from.
+func (c *Cluster) ServiceLogs(ctx context.Context, input string, config *backend.ContainerLogsConfig, started chan struct{}) error {
+	c.RLock()
+	if !c.isActiveManager() {
+		c.RUnlock()
+		return c.errNoManager()
+	}
+
+	service, err := getService(c.getRequestContext(), c.client, input)
+	if err != nil {
+		c.RUnlock()
+		return err
+	}
+
+	options, err := logSubscriptionOptions(config)
+	if err != nil {
+		c.RUnlock()
+		return err
+	}
+
+	stream, err := c.logs.SubscribeLogs(ctx, &swarmapi.SubscribeLogsRequest{
+		Selector: &swarmapi.LogSelector{
+			ServiceIDs: []string{service.ID},
+		},
+		Options: options,
+	})
+	// Release the lock before starting the stream.
+	c.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	wf := ioutils.NewWriteFlusher(config.OutStream)
+	defer wf.Close()
+	close(started)
+	wf.Flush()
+
+	outStream := stdcopy.NewStdWriter(wf, stdcopy.Stdout)
+	errStream := stdcopy.NewStdWriter(wf, stdcopy.Stderr)
+
+	for {
+		subscribeMsg, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range subscribeMsg.Messages {
+			var data []byte
+
+			if config.Timestamps {
+				ts, err := ptypes.Timestamp(msg.Timestamp)
+				if err != nil {
+					return err
+				}
+				data = append(data, []byte(ts.Format(logger.TimeFormat)+" ")...)
+			}
+
+			data = append(data, []byte(fmt.Sprintf("%s.node.id=%s,%s.service.id=%s,%s.task.id=%s ",
+				logContextPrefix, msg.Context.NodeID,
+				logContextPrefix, msg.Context.ServiceID,
+				logContextPrefix, msg.Context.TaskID,
+			))...)
+
+			data = append(data, msg.Data...)
+
+			switch msg.Stream {
+			case swarmapi.LogStreamStdout:
+				outStream.Write(data)
+			case swarmapi.LogStreamStderr:
+				errStream.Write(data)
+			}
+		}
+	}
+}
+
+// logSubscriptionOptions converts the options of a logs request to the
+// options of a swarmkit log subscription.
+func logSubscriptionOptions(config *backend.ContainerLogsConfig) (*swarmapi.LogSubscriptionOptions, error) {
+	if !(config.ShowStdout || config.ShowStderr) {
+		return nil, fmt.Errorf("You must choose at least one stream")
+	}
+
+	options := &swarmapi.LogSubscriptionOptions{
+		Follow: config.Follow,
+	}
+	if config.ShowStdout {
+		options.Streams = append(options.Streams, swarmapi.LogStreamStdout)
+	}
+	if config.ShowStderr {
+		options.Streams = append(options.Streams, swarmapi.LogStreamStderr)
+	}
+
+	if config.Tail != "" && config.Tail != "all" {
+		tail, err := strconv.ParseInt(config.Tail, 10, 64)
+		if err != nil || tail < 0 {
+			return nil, fmt.Errorf("invalid tail value: %q", config.Tail)
+		}
+		if tail == 0 {
+			// no lines from before the subscription
+			tail = -1
+		}
+		options.Tail = tail
+	}
+
+	if config.Since != "" {
+		s, n, err := timetypes.ParseTimestamps(config.Since, 0)
+		if err != nil {
+			return nil, err
+		}
+		since, err := ptypes.TimestampProto(time.Unix(s, n))
+		if err != nil {
+			return nil, err
+		}
+		options.Since = since
+	}
+
+	return options, nil
+}
+
 // GetNodes returns a list of all nodes known to a cluster.
 func (c *Cluster) GetNodes(options apitypes.NodeListOptions) ([]types.Node, error) {
 	c.RLock()
@@ -54,6 +54,15 @@ func containerSpecFromGRPC(c *swarmapi.ContainerSpec) types.ContainerSpec {
 		grace, _ := ptypes.Duration(c.StopGracePeriod)
 		containerSpec.StopGracePeriod = &grace
 	}
+
+	// Secrets
+	for _, s := range c.Secrets {
+		containerSpec.Secrets = append(containerSpec.Secrets, &types.SecretReference{
+			SecretID:   s.SecretID,
+			SecretName: s.SecretName,
+			Target:     s.Target,
+		})
+	}
 	return containerSpec
 }
 
@@ -72,6 +81,15 @@ func containerToGRPC(c types.ContainerSpec) (*swarmapi.ContainerSpec, error) {
 		containerSpec.StopGracePeriod = ptypes.DurationProto(*c.StopGracePeriod)
 	}
 
+	// Secrets
+	for _, s := range c.Secrets {
+		containerSpec.Secrets = append(containerSpec.Secrets, &swarmapi.SecretReference{
+			SecretID:   s.SecretID,
+			SecretName: s.SecretName,
+			Target:     s.Target,
+		})
+	}
+
 	// Mounts
 	for _, m := range c.Mounts {
 		mount := swarmapi.Mount{
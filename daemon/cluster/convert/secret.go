@@ -0,0 +1,41 @@
+package convert
+
+import (
+	types "github.com/docker/engine-api/types/swarm"
+	swarmapi "github.com/docker/swarmkit/api"
+	"github.com/docker/swarmkit/protobuf/ptypes"
+)
+
+// SecretFromGRPC converts a grpc Secret to a Secret.
+func SecretFromGRPC(s swarmapi.Secret) types.Secret {
+	secret := types.Secret{
+		ID: s.ID,
+		Spec: types.SecretSpec{
+			Annotations: types.Annotations{
+				Name:   s.Spec.Annotations.Name,
+				Labels: s.Spec.Annotations.Labels,
+			},
+			Data: s.Spec.Data,
+		},
+		Digest:     s.Digest,
+		SecretSize: s.SecretSize,
+	}
+
+	// Meta
+	secret.Version.Index = s.Meta.Version.Index
+	secret.CreatedAt, _ = ptypes.Timestamp(s.Meta.CreatedAt)
+	secret.UpdatedAt, _ = ptypes.Timestamp(s.Meta.UpdatedAt)
+
+	return secret
+}
+
+// SecretSpecToGRPC converts Secret to a grpc Secret.
+func SecretSpecToGRPC(s types.SecretSpec) swarmapi.SecretSpec {
+	return swarmapi.SecretSpec{
+		Annotations: swarmapi.Annotations{
+			Name:   s.Name,
+			Labels: s.Labels,
+		},
+		Data: s.Data,
+	}
+}
@@ -12,59 +12,106 @@ import (
 
 // ServiceFromGRPC converts a grpc Service to a Service.
 func ServiceFromGRPC(s swarmapi.Service) types.Service {
-	spec := s.Spec
+	service := types.Service{
+		ID:           s.ID,
+		Spec:         *serviceSpecFromGRPC(&s.Spec),
+		PreviousSpec: serviceSpecFromGRPC(s.PreviousSpec),
+		Endpoint:     endpointFromGRPC(s.Endpoint),
+		UpdateStatus: updateStatusFromGRPC(s.UpdateStatus),
+	}
+
+	// Meta
+	service.Version.Index = s.Meta.Version.Index
+	service.CreatedAt, _ = ptypes.Timestamp(s.Meta.CreatedAt)
+	service.UpdatedAt, _ = ptypes.Timestamp(s.Meta.UpdatedAt)
+
+	return service
+}
+
+func serviceSpecFromGRPC(spec *swarmapi.ServiceSpec) *types.ServiceSpec {
+	if spec == nil {
+		return nil
+	}
+
 	containerConfig := spec.Task.Runtime.(*swarmapi.TaskSpec_Container).Container
 
 	networks := make([]types.NetworkAttachmentConfig, 0, len(spec.Networks))
 	for _, n := range spec.Networks {
 		networks = append(networks, types.NetworkAttachmentConfig{Target: n.Target, Aliases: n.Aliases})
 	}
-	service := types.Service{
-		ID: s.ID,
-
-		Spec: types.ServiceSpec{
-			TaskTemplate: types.TaskSpec{
-				ContainerSpec: containerSpecFromGRPC(containerConfig),
-				Resources:     resourcesFromGRPC(s.Spec.Task.Resources),
-				RestartPolicy: restartPolicyFromGRPC(s.Spec.Task.Restart),
-				Placement:     placementFromGRPC(s.Spec.Task.Placement),
-			},
-
-			Networks:     networks,
-			EndpointSpec: endpointSpecFromGRPC(s.Spec.Endpoint),
+
+	convertedSpec := &types.ServiceSpec{
+		Annotations: types.Annotations{
+			Name:   spec.Annotations.Name,
+			Labels: spec.Annotations.Labels,
 		},
-		Endpoint: endpointFromGRPC(s.Endpoint),
-	}
 
-	// Meta
-	service.Version.Index = s.Meta.Version.Index
-	service.CreatedAt, _ = ptypes.Timestamp(s.Meta.CreatedAt)
-	service.UpdatedAt, _ = ptypes.Timestamp(s.Meta.UpdatedAt)
+		TaskTemplate: types.TaskSpec{
+			ContainerSpec: containerSpecFromGRPC(containerConfig),
+			Resources:     resourcesFromGRPC(spec.Task.Resources),
+			RestartPolicy: restartPolicyFromGRPC(spec.Task.Restart),
+			Placement:     placementFromGRPC(spec.Task.Placement),
+		},
 
-	// Annotations
-	service.Spec.Name = s.Spec.Annotations.Name
-	service.Spec.Labels = s.Spec.Annotations.Labels
+		Networks:     networks,
+		EndpointSpec: endpointSpecFromGRPC(spec.Endpoint),
+	}
 
 	// UpdateConfig
-	if s.Spec.Update != nil {
-		service.Spec.UpdateConfig = &types.UpdateConfig{
-			Parallelism: s.Spec.Update.Parallelism,
+	if spec.Update != nil {
+		convertedSpec.UpdateConfig = &types.UpdateConfig{
+			Parallelism:   spec.Update.Parallelism,
+			FailureAction: strings.ToLower(spec.Update.FailureAction.String()),
 		}
 
-		service.Spec.UpdateConfig.Delay, _ = ptypes.Duration(&s.Spec.Update.Delay)
+		convertedSpec.UpdateConfig.Delay, _ = ptypes.Duration(&spec.Update.Delay)
+		if spec.Update.Monitor != nil {
+			convertedSpec.UpdateConfig.Monitor, _ = ptypes.Duration(spec.Update.Monitor)
+		}
 	}
 
 	//Mode
-	switch t := s.Spec.GetMode().(type) {
+	switch t := spec.GetMode().(type) {
 	case *swarmapi.ServiceSpec_Global:
-		service.Spec.Mode.Global = &types.GlobalService{}
+		convertedSpec.Mode.Global = &types.GlobalService{}
 	case *swarmapi.ServiceSpec_Replicated:
-		service.Spec.Mode.Replicated = &types.ReplicatedService{
+		convertedSpec.Mode.Replicated = &types.ReplicatedService{
 			Replicas: &t.Replicated.Replicas,
 		}
 	}
 
-	return service
+	return convertedSpec
+}
+
+func updateStatusFromGRPC(s *swarmapi.UpdateStatus) *types.UpdateStatus {
+	if s == nil {
+		return nil
+	}
+
+	status := &types.UpdateStatus{
+		Message: s.Message,
+	}
+	switch s.State {
+	case swarmapi.UpdateStatus_UPDATING:
+		status.State = types.UpdateStateUpdating
+	case swarmapi.UpdateStatus_PAUSED:
+		status.State = types.UpdateStatePaused
+	case swarmapi.UpdateStatus_COMPLETED:
+		status.State = types.UpdateStateCompleted
+	case swarmapi.UpdateStatus_ROLLBACK_STARTED:
+		status.State = types.UpdateStateRollbackStarted
+	case swarmapi.UpdateStatus_ROLLBACK_PAUSED:
+		status.State = types.UpdateStateRollbackPaused
+	case swarmapi.UpdateStatus_ROLLBACK_COMPLETED:
+		status.State = types.UpdateStateRollbackCompleted
+	}
+	if s.StartedAt != nil {
+		status.StartedAt, _ = ptypes.Timestamp(s.StartedAt)
+	}
+	if s.CompletedAt != nil {
+		status.CompletedAt, _ = ptypes.Timestamp(s.CompletedAt)
+	}
+	return status
 }
 
 // ServiceSpecToGRPC converts a ServiceSpec to a grpc ServiceSpec.
@@ -109,10 +156,11 @@ func ServiceSpecToGRPC(s types.ServiceSpec) (swarmapi.ServiceSpec, error) {
 	}
 
 	if s.UpdateConfig != nil {
-		spec.Update = &swarmapi.UpdateConfig{
-			Parallelism: s.UpdateConfig.Parallelism,
-			Delay:       *ptypes.DurationProto(s.UpdateConfig.Delay),
+		update, err := updateConfigToGRPC(s.UpdateConfig)
+		if err != nil {
+			return swarmapi.ServiceSpec{}, err
 		}
+		spec.Update = update
 	}
 
 	if s.EndpointSpec != nil {
@@ -154,6 +202,24 @@ func ServiceSpecToGRPC(s types.ServiceSpec) (swarmapi.ServiceSpec, error) {
 	return spec, nil
 }
 
+func updateConfigToGRPC(u *types.UpdateConfig) (*swarmapi.UpdateConfig, error) {
+	update := &swarmapi.UpdateConfig{
+		Parallelism: u.Parallelism,
+		Delay:       *ptypes.DurationProto(u.Delay),
+	}
+
+	if action, ok := swarmapi.UpdateConfig_FailureAction_value[strings.ToUpper(u.FailureAction)]; ok {
+		update.FailureAction = swarmapi.UpdateConfig_FailureAction(action)
+	} else if u.FailureAction != "" {
+		return nil, fmt.Errorf("invalid FailureAction: %q", u.FailureAction)
+	}
+
+	if u.Monitor != 0 {
+		update.Monitor = ptypes.DurationProto(u.Monitor)
+	}
+	return update, nil
+}
+
 func resourcesFromGRPC(res *swarmapi.ResourceRequirements) *types.ResourceRequirements {
 	var resources *types.ResourceRequirements
 	if res != nil {
@@ -9,11 +9,14 @@ import (
 	"syscall"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/backend"
 	executorpkg "github.com/docker/docker/daemon/cluster/executor"
+	clustertypes "github.com/docker/docker/daemon/cluster/provider"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/libnetwork"
 	"github.com/docker/swarmkit/api"
 	"github.com/docker/swarmkit/log"
+	"github.com/docker/swarmkit/protobuf/ptypes"
 	"golang.org/x/net/context"
 )
 
@@ -23,9 +26,14 @@ import (
 type containerAdapter struct {
 	backend   executorpkg.Backend
 	container *containerConfig
+	secrets   secretGetter
 }
 
-func newContainerAdapter(b executorpkg.Backend, task *api.Task) (*containerAdapter, error) {
+// secretGetter returns the secret with the given ID, or nil if the secret is
+// not known to the node.
+type secretGetter func(id string) *api.Secret
+
+func newContainerAdapter(b executorpkg.Backend, task *api.Task, secrets secretGetter) (*containerAdapter, error) {
 	ctnr, err := newContainerConfig(task)
 	if err != nil {
 		return nil, err
@@ -34,6 +42,7 @@ func newContainerAdapter(b executorpkg.Backend, task *api.Task) (*containerAdapt
 	return &containerAdapter{
 		container: ctnr,
 		backend:   b,
+		secrets:   secrets,
 	}, nil
 }
 
@@ -142,11 +151,37 @@ func (c *containerAdapter) create(ctx context.Context, backend executorpkg.Backe
 		return err
 	}
 
+	secrets, err := c.containerSecrets()
+	if err != nil {
+		return err
+	}
+	if err := backend.SetContainerSecrets(cr.ID, secrets); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// containerSecrets resolves the secrets referenced by the container spec.
+func (c *containerAdapter) containerSecrets() ([]*clustertypes.Secret, error) {
+	var secrets []*clustertypes.Secret
+	for _, ref := range c.container.spec().Secrets {
+		secret := c.secrets(ref.SecretID)
+		if secret == nil {
+			return nil, fmt.Errorf("secret %s not found on node", ref.SecretID)
+		}
+		secrets = append(secrets, &clustertypes.Secret{
+			ID:     secret.ID,
+			Name:   secret.Spec.Annotations.Name,
+			Target: ref.Target,
+			Data:   secret.Spec.Data,
+		})
+	}
+	return secrets, nil
+}
+
 func (c *containerAdapter) start(ctx context.Context) error {
-	return c.backend.ContainerStart(c.container.name(), nil)
+	return c.backend.ContainerStart(ctx, c.container.name(), nil, "", "")
 }
 
 func (c *containerAdapter) inspect(ctx context.Context) (types.ContainerJSON, error) {
@@ -180,16 +215,80 @@ func (c *containerAdapter) shutdown(ctx context.Context) error {
 }
 
 func (c *containerAdapter) terminate(ctx context.Context) error {
-	return c.backend.ContainerKill(c.container.name(), uint64(syscall.SIGKILL))
+	return c.backend.ContainerKill(ctx, c.container.name(), uint64(syscall.SIGKILL))
 }
 
 func (c *containerAdapter) remove(ctx context.Context) error {
-	return c.backend.ContainerRm(c.container.name(), &types.ContainerRmConfig{
+	return c.backend.ContainerRm(ctx, c.container.name(), &types.ContainerRmConfig{
 		RemoveVolume: true,
 		ForceRemove:  true,
 	})
 }
 
+// logs returns the multiplexed log stream of the container, with each
+// message prefixed by its timestamp. The stream ends when the context is
+// cancelled or, when not following, once the logs have been read.
+func (c *containerAdapter) logs(ctx context.Context, options api.LogSubscriptionOptions) (io.ReadCloser, error) {
+	config := &backend.ContainerLogsConfig{
+		ContainerLogsOptions: types.ContainerLogsOptions{
+			Follow:     options.Follow,
+			Timestamps: true,
+			Tail:       "all",
+		},
+	}
+
+	if options.Since != nil {
+		since, err := ptypes.Timestamp(options.Since)
+		if err != nil {
+			return nil, err
+		}
+		config.Since = fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond())
+	}
+
+	if options.Tail < 0 {
+		config.Tail = "0"
+	} else if options.Tail > 0 {
+		config.Tail = fmt.Sprintf("%d", options.Tail)
+	}
+
+	if len(options.Streams) == 0 {
+		// empty == all
+		config.ShowStdout, config.ShowStderr = true, true
+	} else {
+		for _, stream := range options.Streams {
+			switch stream {
+			case api.LogStreamStdout:
+				config.ShowStdout = true
+			case api.LogStreamStderr:
+				config.ShowStderr = true
+			}
+		}
+	}
+
+	reader, writer := io.Pipe()
+	config.OutStream = writer
+
+	started := make(chan struct{})
+	errs := make(chan error, 1)
+	go func() {
+		err := c.backend.ContainerLogs(ctx, c.container.name(), config, started)
+		errs <- err
+		writer.CloseWithError(err)
+	}()
+
+	select {
+	case <-started:
+		return reader, nil
+	case err := <-errs:
+		if err == nil {
+			err = io.EOF
+		}
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 func (c *containerAdapter) createVolumes(ctx context.Context, backend executorpkg.Backend) error {
 	// Create plugin volumes that are embedded inside a Mount
 	for _, mount := range c.container.task.Spec.GetContainer().Mounts {
@@ -1,14 +1,22 @@
 package container
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	executorpkg "github.com/docker/docker/daemon/cluster/executor"
+	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/swarmkit/agent/exec"
 	"github.com/docker/swarmkit/api"
 	"github.com/docker/swarmkit/log"
+	"github.com/docker/swarmkit/protobuf/ptypes"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
@@ -26,10 +34,11 @@ type controller struct {
 }
 
 var _ exec.Controller = &controller{}
+var _ exec.ControllerLogs = &controller{}
 
 // NewController returns a dockerexec runner for the provided task.
-func newController(b executorpkg.Backend, task *api.Task) (*controller, error) {
-	adapter, err := newContainerAdapter(b, task)
+func newController(b executorpkg.Backend, task *api.Task, secrets secretGetter) (*controller, error) {
+	adapter, err := newContainerAdapter(b, task, secrets)
 	if err != nil {
 		return nil, err
 	}
@@ -238,6 +247,94 @@ func (r *controller) Remove(ctx context.Context) error {
 	return nil
 }
 
+// Logs publishes the logs of the task's container until the context is
+// cancelled or, if the options don't ask to follow them, all the logs have
+// been published.
+func (r *controller) Logs(ctx context.Context, publisher exec.LogPublisher, options api.LogSubscriptionOptions) error {
+	if err := r.checkClosed(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rc, err := r.adapter.logs(ctx, options)
+	if err != nil {
+		if err == io.EOF || isUnknownContainer(err) {
+			return nil // no logs to publish
+		}
+		return errors.Wrap(err, "failed getting container logs")
+	}
+	defer rc.Close()
+
+	var (
+		br     = bufio.NewReader(rc)
+		header [8]byte
+		msgctx = api.LogContext{
+			NodeID:    r.task.NodeID,
+			ServiceID: r.task.ServiceID,
+			TaskID:    r.task.ID,
+		}
+	)
+
+	for {
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			return errors.Wrap(err, "failed reading log header")
+		}
+
+		var stream api.LogStream
+		switch stdcopy.StdType(header[0]) {
+		case stdcopy.Stdout:
+			stream = api.LogStreamStdout
+		case stdcopy.Stderr:
+			stream = api.LogStreamStderr
+		default:
+			return fmt.Errorf("unexpected log stream type: %v", header[0])
+		}
+
+		data := make([]byte, binary.BigEndian.Uint32(header[4:]))
+		if _, err := io.ReadFull(br, data); err != nil {
+			return errors.Wrap(err, "failed reading log message")
+		}
+
+		t, line, err := parseLogLine(data)
+		if err != nil {
+			return err
+		}
+		ts, err := ptypes.TimestampProto(t)
+		if err != nil {
+			return err
+		}
+
+		if err := publisher.Publish(ctx, api.LogMessage{
+			Context:   msgctx,
+			Timestamp: ts,
+			Stream:    stream,
+			Data:      line,
+		}); err != nil {
+			return errors.Wrap(err, "failed to publish log message")
+		}
+	}
+}
+
+// parseLogLine splits a log line, as returned by the logs of a container
+// with timestamps, into its timestamp and message.
+func parseLogLine(data []byte) (time.Time, []byte, error) {
+	i := bytes.IndexByte(data, ' ')
+	if i < 0 {
+		return time.Time{}, nil, fmt.Errorf("invalid log line: missing timestamp")
+	}
+
+	t, err := time.Parse(logger.TimeFormat, string(data[:i]))
+	if err != nil {
+		return time.Time{}, nil, errors.Wrap(err, "invalid log timestamp")
+	}
+	return t, data[i+1:], nil
+}
+
 // Close the runner and clean up any ephemeral resources.
 func (r *controller) Close() error {
 	select {
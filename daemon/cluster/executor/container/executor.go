@@ -2,6 +2,7 @@ package container
 
 import (
 	"strings"
+	"sync"
 
 	executorpkg "github.com/docker/docker/daemon/cluster/executor"
 	clustertypes "github.com/docker/docker/daemon/cluster/provider"
@@ -15,6 +16,9 @@ import (
 
 type executor struct {
 	backend executorpkg.Backend
+
+	mu      sync.RWMutex
+	secrets map[string]*api.Secret
 }
 
 // NewExecutor returns an executor from the docker client.
@@ -113,7 +117,7 @@ func (e *executor) Configure(ctx context.Context, node *api.Node) error {
 
 // Controller returns a docker container runner.
 func (e *executor) Controller(t *api.Task) (exec.Controller, error) {
-	ctlr, err := newController(e.backend, t)
+	ctlr, err := newController(e.backend, t, e.getSecret)
 	if err != nil {
 		return nil, err
 	}
@@ -137,3 +141,24 @@ func (e *executor) SetNetworkBootstrapKeys(keys []*api.EncryptionKey) error {
 
 	return nil
 }
+
+// SetSecrets replaces the secrets known to the executor with the secrets
+// referenced by the tasks assigned to the node.
+func (e *executor) SetSecrets(secrets []*api.Secret) {
+	m := make(map[string]*api.Secret, len(secrets))
+	for _, secret := range secrets {
+		m[secret.ID] = secret
+	}
+
+	e.mu.Lock()
+	e.secrets = m
+	e.mu.Unlock()
+}
+
+// getSecret returns the secret with the given ID, or nil if the secret has
+// not been sent to the node.
+func (e *executor) getSecret(id string) *api.Secret {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.secrets[id]
+}
@@ -106,3 +106,31 @@ func getTask(ctx context.Context, c swarmapi.ControlClient, input string) (*swar
 	}
 	return rg.Task, nil
 }
+
+func getSecret(ctx context.Context, c swarmapi.ControlClient, input string) (*swarmapi.Secret, error) {
+	// GetSecret to match via full ID.
+	rg, err := c.GetSecret(ctx, &swarmapi.GetSecretRequest{SecretID: input})
+	if err != nil {
+		// If any error (including NotFound), ListSecrets to match via full name.
+		rl, err := c.ListSecrets(ctx, &swarmapi.ListSecretsRequest{Filters: &swarmapi.ListSecretsRequest_Filters{Names: []string{input}}})
+		if err != nil || len(rl.Secrets) == 0 {
+			// If any error or 0 result, ListSecrets to match via ID prefix.
+			rl, err = c.ListSecrets(ctx, &swarmapi.ListSecretsRequest{Filters: &swarmapi.ListSecretsRequest_Filters{IDPrefixes: []string{input}}})
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rl.Secrets) == 0 {
+			return nil, fmt.Errorf("secret %s not found", input)
+		}
+
+		if l := len(rl.Secrets); l > 1 {
+			return nil, fmt.Errorf("secret %s is ambigious (%d matches found)", input, l)
+		}
+
+		return rl.Secrets[0], nil
+	}
+	return rg.Secret, nil
+}
@@ -0,0 +1,10 @@
+package provider
+
+// Secret represents a swarm secret which is made available to a container
+// as a file named Target.
+type Secret struct {
+	ID     string
+	Name   string
+	Target string
+	Data   []byte
+}
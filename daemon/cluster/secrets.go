@@ -0,0 +1,117 @@
+package cluster
+
+import (
+	"github.com/docker/docker/daemon/cluster/convert"
+	apitypes "github.com/docker/engine-api/types"
+	types "github.com/docker/engine-api/types/swarm"
+	swarmapi "github.com/docker/swarmkit/api"
+	"golang.org/x/net/context"
+)
+
+// GetSecret returns a secret from a managed swarm cluster
+func (c *Cluster) GetSecret(input string) (types.Secret, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if !c.isActiveManager() {
+		return types.Secret{}, c.errNoManager()
+	}
+
+	secret, err := getSecret(c.getRequestContext(), c.client, input)
+	if err != nil {
+		return types.Secret{}, err
+	}
+	return convert.SecretFromGRPC(*secret), nil
+}
+
+// GetSecrets returns all secrets of a managed swarm cluster.
+func (c *Cluster) GetSecrets(options apitypes.SecretListOptions) ([]types.Secret, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if !c.isActiveManager() {
+		return nil, c.errNoManager()
+	}
+
+	filters, err := newListSecretsFilters(options.Filter)
+	if err != nil {
+		return nil, err
+	}
+	r, err := c.client.ListSecrets(
+		c.getRequestContext(),
+		&swarmapi.ListSecretsRequest{Filters: filters})
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := []types.Secret{}
+
+	for _, secret := range r.Secrets {
+		secrets = append(secrets, convert.SecretFromGRPC(*secret))
+	}
+
+	return secrets, nil
+}
+
+// CreateSecret creates a new secret in a managed swarm cluster.
+func (c *Cluster) CreateSecret(s types.SecretSpec) (string, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if !c.isActiveManager() {
+		return "", c.errNoManager()
+	}
+
+	secretSpec := convert.SecretSpecToGRPC(s)
+
+	r, err := c.client.CreateSecret(c.getRequestContext(),
+		&swarmapi.CreateSecretRequest{Spec: &secretSpec})
+	if err != nil {
+		return "", err
+	}
+
+	return r.Secret.ID, nil
+}
+
+// RemoveSecret removes a secret from a managed swarm cluster.
+func (c *Cluster) RemoveSecret(input string) error {
+	c.RLock()
+	defer c.RUnlock()
+
+	if !c.isActiveManager() {
+		return c.errNoManager()
+	}
+
+	ctx := c.getRequestContext()
+
+	secret, err := getSecret(ctx, c.client, input)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.client.RemoveSecret(ctx, &swarmapi.RemoveSecretRequest{SecretID: secret.ID}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// populateSecretID resolves the secrets referenced by the service spec, by
+// ID, ID prefix or name, to their full ID and name.
+func populateSecretID(ctx context.Context, c swarmapi.ControlClient, s *types.ServiceSpec) error {
+	for _, ref := range s.TaskTemplate.ContainerSpec.Secrets {
+		input := ref.SecretID
+		if input == "" {
+			input = ref.SecretName
+		}
+		secret, err := getSecret(ctx, c, input)
+		if err != nil {
+			return err
+		}
+		ref.SecretID = secret.ID
+		ref.SecretName = secret.Spec.Annotations.Name
+		if ref.Target == "" {
+			ref.Target = ref.SecretName
+		}
+	}
+	return nil
+}
@@ -8,12 +8,17 @@ import (
 	"io/ioutil"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/audit"
+	"github.com/docker/docker/daemon/events"
 	"github.com/docker/docker/opts"
+	"github.com/docker/docker/pkg/authentication"
 	"github.com/docker/docker/pkg/discovery"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/registry"
+	units "github.com/docker/go-units"
 	"github.com/imdario/mergo"
 )
 
@@ -29,6 +34,18 @@ const (
 	// stockRuntimeName is the reserved name/alias used to represent the
 	// OCI runtime being shipped with the docker daemon package.
 	stockRuntimeName = "runc"
+	// defaultEventsJournalMaxSize is the default maximum size of the
+	// events journal.
+	defaultEventsJournalMaxSize = "10m"
+	// defaultAuthorizationCacheTTL is the default duration for which the
+	// decisions of authorization plugins are cached.
+	defaultAuthorizationCacheTTL = "1m"
+	// defaultAuditLogMaxSize is the default maximum size of each file of
+	// the audit log.
+	defaultAuditLogMaxSize = "100m"
+	// defaultAuditLogMaxFiles is the default number of files the audit log
+	// is split into.
+	defaultAuditLogMaxFiles = 5
 )
 
 const (
@@ -41,9 +58,10 @@ const (
 // Use this to differentiate these options
 // with others like the ones in CommonTLSOptions.
 var flatOptions = map[string]bool{
-	"cluster-store-opts": true,
-	"log-opts":           true,
-	"runtimes":           true,
+	"cluster-store-opts":       true,
+	"log-opts":                 true,
+	"runtimes":                 true,
+	"registry-mirrors-by-host": true,
 }
 
 // LogConfig represents the default log configuration.
@@ -118,6 +136,55 @@ type CommonConfig struct {
 	// may take place at a time for each push.
 	MaxConcurrentUploads *int `json:"max-concurrent-uploads,omitempty"`
 
+	// MetricsAddress is the TCP address on which the daemon serves
+	// Prometheus metrics. Metrics are not served if it is empty.
+	MetricsAddress string `json:"metrics-addr,omitempty"`
+
+	// EventsJournal enables the on-disk journal of events, which keeps
+	// events across restarts of the daemon.
+	EventsJournal bool `json:"events-journal,omitempty"`
+
+	// EventsJournalMaxSize is the maximum size of the events journal,
+	// e.g. "10m".
+	EventsJournalMaxSize string `json:"events-journal-max-size,omitempty"`
+
+	// EventsJournalMaxAge is the maximum age of the events kept in the
+	// events journal, e.g. "168h". Events are only bounded by size if it
+	// is empty.
+	EventsJournalMaxAge string `json:"events-journal-max-age,omitempty"`
+
+	// EventSinks are the sinks to which events are forwarded, for example
+	// "type=webhook,url=https://example.com/events".
+	EventSinks []string `json:"event-sinks,omitempty"`
+
+	// Authenticators are the authenticators of the users of the API, tried
+	// in order, for example "type=token,file=/etc/docker/tokens.csv".
+	Authenticators []string `json:"authenticators,omitempty"`
+
+	// AuditLog is the path of the audit log of the API requests. The
+	// requests are not audited if it is empty.
+	AuditLog string `json:"audit-log,omitempty"`
+
+	// AuditLogMaxSize is the maximum size of each file of the audit log,
+	// e.g. "100m".
+	AuditLogMaxSize string `json:"audit-log-max-size,omitempty"`
+
+	// AuditLogMaxFiles is the maximum number of files of the audit log.
+	AuditLogMaxFiles int `json:"audit-log-max-files,omitempty"`
+
+	// AuditLogLevel is the verbosity of the audit log: metadata, request
+	// or body.
+	AuditLogLevel string `json:"audit-log-level,omitempty"`
+
+	// AuthorizationCacheSize is the maximum number of decisions of
+	// authorization plugins kept in the decision cache. Decisions are
+	// not cached if it is 0.
+	AuthorizationCacheSize int `json:"authorization-cache-size,omitempty"`
+
+	// AuthorizationCacheTTL is the duration for which the decisions of
+	// authorization plugins are cached, e.g. "30s".
+	AuthorizationCacheTTL string `json:"authorization-cache-ttl,omitempty"`
+
 	Debug     bool     `json:"debug,omitempty"`
 	Hosts     []string `json:"hosts,omitempty"`
 	LogLevel  string   `json:"log-level,omitempty"`
@@ -146,6 +213,13 @@ func (config *Config) InstallCommonFlags(cmd *flag.FlagSet, usageFn func(string)
 
 	cmd.Var(opts.NewNamedListOptsRef("storage-opts", &config.GraphOptions, nil), []string{"-storage-opt"}, usageFn("Set storage driver options"))
 	cmd.Var(opts.NewNamedListOptsRef("authorization-plugins", &config.AuthorizationPlugins, nil), []string{"-authorization-plugin"}, usageFn("List authorization plugins in order from first evaluator to last"))
+	cmd.Var(opts.NewNamedListOptsRef("authenticators", &config.Authenticators, authentication.ValidateAuthenticator), []string{"-authenticator"}, usageFn("Authenticate API users with a token, htpasswd, jwt or plugin authenticator"))
+	cmd.StringVar(&config.AuditLog, []string{"-audit-log"}, "", usageFn("Path of the audit log of API requests"))
+	cmd.StringVar(&config.AuditLogMaxSize, []string{"-audit-log-max-size"}, defaultAuditLogMaxSize, usageFn("Maximum size of each file of the audit log"))
+	cmd.IntVar(&config.AuditLogMaxFiles, []string{"-audit-log-max-files"}, defaultAuditLogMaxFiles, usageFn("Maximum number of files of the audit log"))
+	cmd.StringVar(&config.AuditLogLevel, []string{"-audit-log-level"}, string(audit.MetadataLevel), usageFn("Verbosity of the audit log (metadata, request or body)"))
+	cmd.IntVar(&config.AuthorizationCacheSize, []string{"-authorization-cache-size"}, 0, usageFn("Maximum number of cached authorization decisions"))
+	cmd.StringVar(&config.AuthorizationCacheTTL, []string{"-authorization-cache-ttl"}, defaultAuthorizationCacheTTL, usageFn("Duration for which authorization decisions are cached"))
 	cmd.Var(opts.NewNamedListOptsRef("exec-opts", &config.ExecOptions, nil), []string{"-exec-opt"}, usageFn("Set runtime execution options"))
 	cmd.StringVar(&config.Pidfile, []string{"p", "-pidfile"}, defaultPidFile, usageFn("Path to use for daemon PID file"))
 	cmd.StringVar(&config.Root, []string{"g", "-graph"}, defaultGraph, usageFn("Root of the Docker runtime"))
@@ -166,6 +240,11 @@ func (config *Config) InstallCommonFlags(cmd *flag.FlagSet, usageFn func(string)
 	cmd.StringVar(&config.CorsHeaders, []string{"-api-cors-header"}, "", usageFn("Set CORS headers in the remote API"))
 	cmd.IntVar(&maxConcurrentDownloads, []string{"-max-concurrent-downloads"}, defaultMaxConcurrentDownloads, usageFn("Set the max concurrent downloads for each pull"))
 	cmd.IntVar(&maxConcurrentUploads, []string{"-max-concurrent-uploads"}, defaultMaxConcurrentUploads, usageFn("Set the max concurrent uploads for each push"))
+	cmd.StringVar(&config.MetricsAddress, []string{"-metrics-addr"}, "", usageFn("Set default address and port to serve the metrics api on"))
+	cmd.BoolVar(&config.EventsJournal, []string{"-events-journal"}, false, usageFn("Persist events to an on-disk journal"))
+	cmd.StringVar(&config.EventsJournalMaxSize, []string{"-events-journal-max-size"}, defaultEventsJournalMaxSize, usageFn("Maximum size of the events journal"))
+	cmd.StringVar(&config.EventsJournalMaxAge, []string{"-events-journal-max-age"}, "", usageFn("Maximum age of the events kept in the events journal"))
+	cmd.Var(opts.NewNamedListOptsRef("event-sinks", &config.EventSinks, events.ValidateSink), []string{"-event-sink"}, usageFn("Forward events to a webhook, syslog or file sink"))
 
 	config.MaxConcurrentDownloads = &maxConcurrentDownloads
 	config.MaxConcurrentUploads = &maxConcurrentUploads
@@ -416,6 +495,11 @@ func ValidateConfiguration(config *Config) error {
 		}
 	}
 
+	// validate per-registry mirrors
+	if _, err := registry.ValidateMirrorsByHost(config.MirrorsByHost); err != nil {
+		return err
+	}
+
 	// validate MaxConcurrentDownloads
 	if config.IsValueSet("max-concurrent-downloads") && config.MaxConcurrentDownloads != nil && *config.MaxConcurrentDownloads < 0 {
 		return fmt.Errorf("invalid max concurrent downloads: %d", *config.MaxConcurrentDownloads)
@@ -426,6 +510,35 @@ func ValidateConfiguration(config *Config) error {
 		return fmt.Errorf("invalid max concurrent uploads: %d", *config.MaxConcurrentUploads)
 	}
 
+	// validate the event sinks
+	for _, sink := range config.EventSinks {
+		if _, err := events.ValidateSink(sink); err != nil {
+			return err
+		}
+	}
+
+	// validate the limits of the events journal
+	if _, _, err := config.eventsJournalLimits(); err != nil {
+		return err
+	}
+
+	// validate the authenticators
+	for _, authenticator := range config.Authenticators {
+		if _, err := authentication.ValidateAuthenticator(authenticator); err != nil {
+			return err
+		}
+	}
+
+	// validate the options of the audit log
+	if _, _, _, err := config.auditLogOptions(); err != nil {
+		return err
+	}
+
+	// validate the authorization decision cache
+	if _, _, err := config.AuthorizationCache(); err != nil {
+		return err
+	}
+
 	// validate that "default" runtime is not reset
 	if runtimes := config.GetAllRuntimes(); len(runtimes) > 0 {
 		if _, ok := runtimes[stockRuntimeName]; ok {
@@ -442,3 +555,88 @@ func ValidateConfiguration(config *Config) error {
 
 	return nil
 }
+
+// eventsJournalLimits returns the maximum size in bytes and the maximum age of
+// the events journal.
+func (config *Config) eventsJournalLimits() (int64, time.Duration, error) {
+	maxSize := defaultEventsJournalMaxSize
+	if config.EventsJournalMaxSize != "" {
+		maxSize = config.EventsJournalMaxSize
+	}
+	size, err := units.RAMInBytes(maxSize)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid events journal max size %q: %v", maxSize, err)
+	}
+	if size <= 0 {
+		return 0, 0, fmt.Errorf("invalid events journal max size %q: must be positive", maxSize)
+	}
+
+	var age time.Duration
+	if config.EventsJournalMaxAge != "" {
+		age, err = time.ParseDuration(config.EventsJournalMaxAge)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid events journal max age %q: %v", config.EventsJournalMaxAge, err)
+		}
+		if age < 0 {
+			return 0, 0, fmt.Errorf("invalid events journal max age %q: must not be negative", config.EventsJournalMaxAge)
+		}
+	}
+
+	return size, age, nil
+}
+
+// AuthorizationCache returns the maximum number of decisions of authorization
+// plugins to cache, and the duration for which they are cached.
+func (config *Config) AuthorizationCache() (int, time.Duration, error) {
+	if config.AuthorizationCacheSize < 0 {
+		return 0, 0, fmt.Errorf("invalid authorization cache size: %d", config.AuthorizationCacheSize)
+	}
+
+	ttl := defaultAuthorizationCacheTTL
+	if config.AuthorizationCacheTTL != "" {
+		ttl = config.AuthorizationCacheTTL
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid authorization cache ttl %q: %v", ttl, err)
+	}
+	if d <= 0 {
+		return 0, 0, fmt.Errorf("invalid authorization cache ttl %q: must be positive", ttl)
+	}
+
+	return config.AuthorizationCacheSize, d, nil
+}
+
+// auditLogOptions returns the maximum size in bytes of each file of the audit
+// log, the maximum number of files, and the verbosity of the audit log.
+func (config *Config) auditLogOptions() (int64, int, audit.Level, error) {
+	maxSize := defaultAuditLogMaxSize
+	if config.AuditLogMaxSize != "" {
+		maxSize = config.AuditLogMaxSize
+	}
+	size, err := units.RAMInBytes(maxSize)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("invalid audit log max size %q: %v", maxSize, err)
+	}
+	if size <= 0 {
+		return 0, 0, "", fmt.Errorf("invalid audit log max size %q: must be positive", maxSize)
+	}
+
+	maxFiles := defaultAuditLogMaxFiles
+	if config.AuditLogMaxFiles != 0 {
+		maxFiles = config.AuditLogMaxFiles
+	}
+	if maxFiles < 1 {
+		return 0, 0, "", fmt.Errorf("invalid audit log max files: %d", config.AuditLogMaxFiles)
+	}
+
+	level := audit.MetadataLevel
+	if config.AuditLogLevel != "" {
+		level, err = audit.ParseLevel(config.AuditLogLevel)
+		if err != nil {
+			return 0, 0, "", err
+		}
+	}
+
+	return size, maxFiles, level, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/docker/docker/pkg/signal"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/pkg/truncindex"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
 	containertypes "github.com/docker/engine-api/types/container"
 	"github.com/docker/engine-api/types/strslice"
 	"github.com/docker/go-connections/nat"
@@ -221,6 +222,10 @@ func (daemon *Daemon) verifyContainerSettings(hostConfig *containertypes.HostCon
 			}
 		}
 
+		if err := runconfigopts.ValidateHealthcheck(config.Healthcheck); err != nil {
+			return nil, err
+		}
+
 		// Validate if the given hostname is RFC 1123 (https://tools.ietf.org/html/rfc1123) compliant.
 		if len(config.Hostname) > 0 {
 			// RFC1123 specifies that 63 bytes is the maximium length
@@ -33,6 +33,10 @@ func (daemon *Daemon) setupIpcDirs(container *container.Container) error {
 	return nil
 }
 
+func (daemon *Daemon) setupSecretDir(container *container.Container) error {
+	return nil
+}
+
 func (daemon *Daemon) mountVolumes(container *container.Container) error {
 	return nil
 }
@@ -34,6 +34,10 @@ func (daemon *Daemon) setupIpcDirs(container *container.Container) error {
 	return nil
 }
 
+func (daemon *Daemon) setupSecretDir(container *container.Container) error {
+	return nil
+}
+
 // TODO Windows: Fix Post-TP5. This is a hack to allow docker cp to work
 // against containers which have volumes. You will still be able to cp
 // to somewhere on the container drive, but not to any mounted volumes
@@ -3,6 +3,9 @@ package daemon
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"golang.org/x/net/context"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/container"
@@ -21,15 +24,17 @@ import (
 
 // CreateManagedContainer creates a container that is managed by a Service
 func (daemon *Daemon) CreateManagedContainer(params types.ContainerCreateConfig) (types.ContainerCreateResponse, error) {
-	return daemon.containerCreate(params, true)
+	return daemon.containerCreate(params, true, map[string]string{})
 }
 
-// ContainerCreate creates a regular container
-func (daemon *Daemon) ContainerCreate(params types.ContainerCreateConfig) (types.ContainerCreateResponse, error) {
-	return daemon.containerCreate(params, false)
+// ContainerCreate creates a regular container. The create event is attributed
+// to the user that made the request of ctx, if any.
+func (daemon *Daemon) ContainerCreate(ctx context.Context, params types.ContainerCreateConfig) (types.ContainerCreateResponse, error) {
+	return daemon.containerCreate(params, false, identityAttributes(ctx))
 }
 
-func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, managed bool) (types.ContainerCreateResponse, error) {
+func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, managed bool, attributes map[string]string) (types.ContainerCreateResponse, error) {
+	start := time.Now()
 	if params.Config == nil {
 		return types.ContainerCreateResponse{}, fmt.Errorf("Config cannot be empty in order to create a container")
 	}
@@ -52,16 +57,18 @@ func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, manage
 		return types.ContainerCreateResponse{Warnings: warnings}, err
 	}
 
-	container, err := daemon.create(params, managed)
+	container, err := daemon.create(params, managed, attributes)
 	if err != nil {
 		return types.ContainerCreateResponse{Warnings: warnings}, daemon.imageNotExistToErrcode(err)
 	}
+	observeSince(containerActions, "create", start)
 
 	return types.ContainerCreateResponse{ID: container.ID, Warnings: warnings}, nil
 }
 
 // Create creates a new container from the given configuration with a given name.
-func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (retC *container.Container, retErr error) {
+// The attributes are added to the create event.
+func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool, attributes map[string]string) (retC *container.Container, retErr error) {
 	var (
 		container *container.Container
 		img       *image.Image
@@ -81,6 +88,10 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 		return nil, err
 	}
 
+	if err := verifyRestartPolicyHealthcheck(params.Config, params.HostConfig.RestartPolicy); err != nil {
+		return nil, err
+	}
+
 	if err := daemon.mergeAndVerifyLogConfig(&params.HostConfig.LogConfig); err != nil {
 		return nil, err
 	}
@@ -90,7 +101,7 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 	}
 	defer func() {
 		if retErr != nil {
-			if err := daemon.cleanupContainer(container, true); err != nil {
+			if err := daemon.cleanupContainer(container, true, map[string]string{}); err != nil {
 				logrus.Errorf("failed to cleanup container on create error: %v", err)
 			}
 		}
@@ -149,7 +160,7 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 	if err := daemon.Register(container); err != nil {
 		return nil, err
 	}
-	daemon.LogContainerEvent(container, "create")
+	daemon.LogContainerEventWithAttributes(container, "create", attributes)
 	return container, nil
 }
 
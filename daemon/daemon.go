@@ -14,7 +14,9 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -24,8 +26,10 @@ import (
 	containerd "github.com/docker/containerd/api/grpc/types"
 	"github.com/docker/docker/api"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/audit"
 	"github.com/docker/docker/daemon/events"
 	"github.com/docker/docker/daemon/exec"
+	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/engine-api/types"
 	containertypes "github.com/docker/engine-api/types/container"
 	"github.com/docker/libnetwork/cluster"
@@ -37,6 +41,7 @@ import (
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/migrate/v1"
+	"github.com/docker/docker/pkg/authorization"
 	"github.com/docker/docker/pkg/fileutils"
 	"github.com/docker/docker/pkg/graphdb"
 	"github.com/docker/docker/pkg/idtools"
@@ -84,6 +89,9 @@ type Daemon struct {
 	defaultLogConfig          containertypes.LogConfig
 	RegistryService           registry.Service
 	EventsService             *events.Events
+	eventForwarders           []*events.Forwarder
+	auditLogger               *audit.Logger
+	authzMiddleware           *authorization.Middleware
 	netController             libnetwork.NetworkController
 	volumes                   *store.VolumeStore
 	discoveryWatcher          discoveryReloader
@@ -100,6 +108,17 @@ type Daemon struct {
 	containerdRemote          libcontainerd.Remote
 	defaultIsolation          containertypes.Isolation // Default isolation mode on Windows
 	clusterProvider           cluster.Provider
+
+	// diskUsageRunning is set while SystemDiskUsage is computing, to
+	// prevent concurrent runs of that expensive operation
+	diskUsageRunning int32
+	// pruneRunning is set while a prune operation is in progress
+	pruneRunning int32
+
+	// startAttributes holds the attributes of the start events of the
+	// containers being started by containerStart, by container ID
+	startAttributesMu sync.Mutex
+	startAttributes   map[string]map[string]string
 }
 
 func (daemon *Daemon) restore() error {
@@ -274,7 +293,7 @@ func (daemon *Daemon) restore() error {
 
 			// Make sure networks are available before starting
 			daemon.waitForNetworks(c)
-			if err := daemon.containerStart(c); err != nil {
+			if err := daemon.containerStart(c, "", "", map[string]string{}); err != nil {
 				logrus.Errorf("Failed to start container %s: %s", c.ID, err)
 			}
 			close(chNotify)
@@ -539,6 +558,56 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 	}
 
 	eventsService := events.New()
+	if config.EventsJournal {
+		maxSize, maxAge, err := config.eventsJournalLimits()
+		if err != nil {
+			return nil, err
+		}
+		journal, err := events.NewJournal(filepath.Join(config.Root, "events", "events.log"), maxSize, maxAge)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't open the events journal: %v", err)
+		}
+		eventsService.SetJournal(journal)
+	}
+
+	for _, value := range config.EventSinks {
+		sinkConfig, err := events.ParseSinkConfig(value)
+		if err != nil {
+			return nil, err
+		}
+		forwarder, err := events.NewForwarder(eventsService, sinkConfig)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't create %s event sink: %v", sinkConfig.Type, err)
+		}
+		forwarder.Start()
+		d.eventForwarders = append(d.eventForwarders, forwarder)
+	}
+
+	if config.AuditLog != "" {
+		maxSize, maxFiles, level, err := config.auditLogOptions()
+		if err != nil {
+			return nil, err
+		}
+		d.auditLogger, err = audit.NewLogger(config.AuditLog, maxSize, maxFiles, level)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't open the audit log: %v", err)
+		}
+	}
+
+	// The authorization middleware is created even without plugins, so that
+	// plugins can be added when the configuration is reloaded.
+	authzCacheSize, authzCacheTTL, err := config.AuthorizationCache()
+	if err != nil {
+		return nil, err
+	}
+	var authzCache *authorization.DecisionCache
+	if authzCacheSize > 0 {
+		authzCache, err = authorization.NewDecisionCache(authzCacheSize, authzCacheTTL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	d.authzMiddleware = authorization.NewMiddleware(authorization.NewPlugins(config.AuthorizationPlugins), d, authzCache)
 
 	referenceStore, err := reference.NewReferenceStore(filepath.Join(imageRoot, "repositories.json"))
 	if err != nil {
@@ -649,6 +718,19 @@ func (daemon *Daemon) shutdownContainer(c *container.Container) error {
 // Shutdown stops the daemon.
 func (daemon *Daemon) Shutdown() error {
 	daemon.shutdown = true
+	// Stop forwarding events once the containers are stopped, so that
+	// their events are delivered to the sinks.
+	defer func() {
+		for _, forwarder := range daemon.eventForwarders {
+			forwarder.Stop()
+		}
+		if daemon.auditLogger != nil {
+			daemon.auditLogger.Close()
+		}
+		if err := daemon.EventsService.Close(); err != nil {
+			logrus.Warnf("Failed to close the events journal: %v", err)
+		}
+	}()
 	// Keep mounts and networking running on daemon shutdown if
 	// we are to keep containers running and restore them.
 	if daemon.configStore.LiveRestore {
@@ -757,6 +839,18 @@ func (daemon *Daemon) GraphDriverName() string {
 	return daemon.layerStore.DriverName()
 }
 
+// AuditLogger returns the logger of the audit log of API requests, or nil if
+// the requests are not audited.
+func (daemon *Daemon) AuditLogger() *audit.Logger {
+	return daemon.auditLogger
+}
+
+// AuthorizationMiddleware returns the middleware passing the API requests to
+// the authorization plugins of the daemon.
+func (daemon *Daemon) AuthorizationMiddleware() *authorization.Middleware {
+	return daemon.authzMiddleware
+}
+
 // GetUIDGIDMaps returns the current daemon's user namespace settings
 // for the full uid and gid maps which will be applied to containers
 // started in this instance.
@@ -840,6 +934,17 @@ func (daemon *Daemon) initDiscovery(config *Config) error {
 // - Daemon max concurrent uploads
 // - Cluster discovery (reconfigure and restart).
 // - Daemon live restore
+// - Registry mirrors and insecure registries.
+// - Authorization plugins.
+// - Options of the default log driver.
+// - Runtimes and default runtime.
+//
+// The new values are validated before any of them is applied, so that an
+// invalid configuration leaves the daemon unchanged. The live restore option
+// is reverted if the cluster discovery fails to be reconfigured, but a
+// failure of the discovery backend while it is reloaded may leave it
+// partially reconfigured. The "changed" attribute of the reload event lists
+// the options whose value changed.
 func (daemon *Daemon) Reload(config *Config) error {
 	var err error
 	// used to hold reloaded changes
@@ -856,25 +961,57 @@ func (daemon *Daemon) Reload(config *Config) error {
 	daemon.configStore.reloadLock.Lock()
 	defer daemon.configStore.reloadLock.Unlock()
 
-	daemon.platformReload(config, &attributes)
+	serviceOptions, err := daemon.reloadedServiceOptions(config)
+	if err != nil {
+		return err
+	}
+	if config.IsValueSet("log-opts") {
+		if err = logger.ValidateLogOpts(daemon.defaultLogConfig.Type, config.LogConfig.Config); err != nil {
+			return err
+		}
+	}
+	if config.IsValueSet("authorization-plugins") {
+		if err = authorization.ValidatePlugins(config.AuthorizationPlugins); err != nil {
+			return err
+		}
+	}
+	if err = daemon.validatePlatformReload(config); err != nil {
+		return err
+	}
+	discovery, err := daemon.reloadedDiscoverySettings(config)
+	if err != nil {
+		return err
+	}
+
+	previous := daemon.reloadAttributes()
+
+	if config.IsValueSet("live-restore") {
+		if err = daemon.containerdRemote.UpdateOptions(libcontainerd.WithLiveRestore(config.LiveRestore)); err != nil {
+			return err
+		}
+	}
 
-	if err = daemon.reloadClusterDiscovery(config); err != nil {
+	if err = daemon.reloadClusterDiscovery(config, discovery); err != nil {
+		if config.IsValueSet("live-restore") {
+			if err := daemon.containerdRemote.UpdateOptions(libcontainerd.WithLiveRestore(daemon.configStore.LiveRestore)); err != nil {
+				logrus.Errorf("Failed to revert the live restore option: %v", err)
+			}
+		}
 		return err
 	}
 
+	if config.IsValueSet("live-restore") {
+		daemon.configStore.LiveRestore = config.LiveRestore
+	}
+
+	daemon.platformReload(config)
+
 	if config.IsValueSet("labels") {
 		daemon.configStore.Labels = config.Labels
 	}
 	if config.IsValueSet("debug") {
 		daemon.configStore.Debug = config.Debug
 	}
-	if config.IsValueSet("live-restore") {
-		daemon.configStore.LiveRestore = config.LiveRestore
-		if err := daemon.containerdRemote.UpdateOptions(libcontainerd.WithLiveRestore(config.LiveRestore)); err != nil {
-			return err
-		}
-
-	}
 
 	// If no value is set for max-concurrent-downloads we assume it is the default value
 	// We always "reset" as the cost is lightweight and easy to maintain.
@@ -902,47 +1039,150 @@ func (daemon *Daemon) Reload(config *Config) error {
 		daemon.uploadManager.SetConcurrency(*daemon.configStore.MaxConcurrentUploads)
 	}
 
+	daemon.configStore.ServiceOptions = serviceOptions
+	if daemon.RegistryService != nil {
+		daemon.RegistryService.LoadOptions(serviceOptions)
+	}
+
+	if config.IsValueSet("authorization-plugins") {
+		daemon.configStore.AuthorizationPlugins = config.AuthorizationPlugins
+		if daemon.authzMiddleware != nil {
+			daemon.authzMiddleware.SetPlugins(authorization.NewPlugins(config.AuthorizationPlugins))
+		}
+	}
+
+	// The options of the default log driver are replaced as a whole, so
+	// that the containers being created keep a consistent set of options.
+	if config.IsValueSet("log-opts") {
+		daemon.configStore.LogConfig.Config = config.LogConfig.Config
+		daemon.defaultLogConfig.Config = config.LogConfig.Config
+	}
+
 	// We emit daemon reload event here with updatable configurations
+	for k, v := range daemon.reloadAttributes() {
+		attributes[k] = v
+	}
+	var changed []string
+	for k, v := range attributes {
+		if previous[k] != v {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	attributes["changed"] = jsonAttribute(changed, "[]")
+
+	return nil
+}
+
+// reloadedServiceOptions returns the registry options of the daemon updated
+// with the registry mirrors and the insecure registries set in config.
+func (daemon *Daemon) reloadedServiceOptions(config *Config) (registry.ServiceOptions, error) {
+	options := daemon.configStore.ServiceOptions
+
+	var reloaded registry.ServiceOptions
+	if config.IsValueSet("registry-mirrors") {
+		reloaded.Mirrors = config.Mirrors
+	}
+	if config.IsValueSet("insecure-registries") {
+		reloaded.InsecureRegistries = config.InsecureRegistries
+	}
+	if config.IsValueSet("registry-mirrors-by-host") {
+		reloaded.MirrorsByHost = config.MirrorsByHost
+	}
+	reloaded, err := registry.ValidateOptions(reloaded)
+	if err != nil {
+		return registry.ServiceOptions{}, err
+	}
+
+	if config.IsValueSet("registry-mirrors") {
+		options.Mirrors = reloaded.Mirrors
+	}
+	if config.IsValueSet("insecure-registries") {
+		options.InsecureRegistries = reloaded.InsecureRegistries
+	}
+	if config.IsValueSet("registry-mirrors-by-host") {
+		options.MirrorsByHost = reloaded.MirrorsByHost
+	}
+	return options, nil
+}
+
+// reloadAttributes returns the values of the options that can be reloaded,
+// as attributes of the reload event.
+func (daemon *Daemon) reloadAttributes() map[string]string {
+	attributes := map[string]string{}
+	daemon.platformReloadAttributes(attributes)
+
 	attributes["debug"] = fmt.Sprintf("%t", daemon.configStore.Debug)
+	attributes["live-restore"] = fmt.Sprintf("%t", daemon.configStore.LiveRestore)
 	attributes["cluster-store"] = daemon.configStore.ClusterStore
-	if daemon.configStore.ClusterOpts != nil {
-		opts, _ := json.Marshal(daemon.configStore.ClusterOpts)
-		attributes["cluster-store-opts"] = string(opts)
-	} else {
-		attributes["cluster-store-opts"] = "{}"
-	}
+	attributes["cluster-store-opts"] = jsonAttribute(daemon.configStore.ClusterOpts, "{}")
 	attributes["cluster-advertise"] = daemon.configStore.ClusterAdvertise
-	if daemon.configStore.Labels != nil {
-		labels, _ := json.Marshal(daemon.configStore.Labels)
-		attributes["labels"] = string(labels)
-	} else {
-		attributes["labels"] = "[]"
+	attributes["labels"] = jsonAttribute(daemon.configStore.Labels, "[]")
+	if daemon.configStore.MaxConcurrentDownloads != nil {
+		attributes["max-concurrent-downloads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentDownloads)
+	}
+	if daemon.configStore.MaxConcurrentUploads != nil {
+		attributes["max-concurrent-uploads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentUploads)
+	}
+	attributes["registry-mirrors"] = jsonAttribute(daemon.configStore.Mirrors, "[]")
+	attributes["insecure-registries"] = jsonAttribute(daemon.configStore.InsecureRegistries, "[]")
+	attributes["registry-mirrors-by-host"] = jsonAttribute(daemon.configStore.MirrorsByHost, "{}")
+	attributes["authorization-plugins"] = jsonAttribute(daemon.configStore.AuthorizationPlugins, "[]")
+	attributes["log-opts"] = jsonAttribute(daemon.defaultLogConfig.Config, "{}")
+	return attributes
+}
+
+// jsonAttribute returns the JSON encoding of v as an event attribute, or
+// empty if v is empty.
+func jsonAttribute(v interface{}, empty string) string {
+	if reflect.ValueOf(v).Len() == 0 {
+		return empty
 	}
-	attributes["max-concurrent-downloads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentDownloads)
-	attributes["max-concurrent-uploads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentUploads)
+	b, _ := json.Marshal(v)
+	return string(b)
+}
 
-	return nil
+// discoverySettings are the cluster discovery settings of a reloaded
+// configuration.
+type discoverySettings struct {
+	advertise    string
+	clusterStore string
+	// disabled is set if the discovery is disabled by the configuration
+	disabled bool
 }
 
-func (daemon *Daemon) reloadClusterDiscovery(config *Config) error {
-	var err error
-	newAdvertise := daemon.configStore.ClusterAdvertise
-	newClusterStore := daemon.configStore.ClusterStore
+// reloadedDiscoverySettings validates the cluster discovery settings of config
+// and returns them, without applying them.
+func (daemon *Daemon) reloadedDiscoverySettings(config *Config) (discoverySettings, error) {
+	settings := discoverySettings{
+		advertise:    daemon.configStore.ClusterAdvertise,
+		clusterStore: daemon.configStore.ClusterStore,
+	}
 	if config.IsValueSet("cluster-advertise") {
 		if config.IsValueSet("cluster-store") {
-			newClusterStore = config.ClusterStore
+			settings.clusterStore = config.ClusterStore
 		}
-		newAdvertise, err = parseClusterAdvertiseSettings(newClusterStore, config.ClusterAdvertise)
+		advertise, err := parseClusterAdvertiseSettings(settings.clusterStore, config.ClusterAdvertise)
 		if err != nil && err != errDiscoveryDisabled {
-			return err
+			return settings, err
 		}
+		settings.advertise = advertise
+		settings.disabled = err == errDiscoveryDisabled
 	}
 
 	if daemon.clusterProvider != nil {
 		if err := config.isSwarmCompatible(); err != nil {
-			return err
+			return settings, err
 		}
 	}
+	return settings, nil
+}
+
+// reloadClusterDiscovery applies the cluster discovery settings validated by
+// reloadedDiscoverySettings.
+func (daemon *Daemon) reloadClusterDiscovery(config *Config, settings discoverySettings) error {
+	newAdvertise := settings.advertise
+	newClusterStore := settings.clusterStore
 
 	// check discovery modifications
 	if !modifiedDiscoverySettings(daemon.configStore, newAdvertise, newClusterStore, config.ClusterOpts) {
@@ -957,12 +1197,12 @@ func (daemon *Daemon) reloadClusterDiscovery(config *Config) error {
 		}
 		daemon.discoveryWatcher = discoveryWatcher
 	} else {
-		if err == errDiscoveryDisabled {
+		if settings.disabled {
 			// disable discovery if it was previously enabled and it's disabled now
 			daemon.discoveryWatcher.Stop()
 		} else {
 			// reload discovery
-			if err = daemon.discoveryWatcher.Reload(config.ClusterStore, newAdvertise, config.ClusterOpts); err != nil {
+			if err := daemon.discoveryWatcher.Reload(config.ClusterStore, newAdvertise, config.ClusterOpts); err != nil {
 				return err
 			}
 		}
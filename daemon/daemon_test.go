@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -9,10 +10,12 @@ import (
 	"time"
 
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/pkg/discovery"
 	_ "github.com/docker/docker/pkg/discovery/memory"
 	"github.com/docker/docker/pkg/registrar"
 	"github.com/docker/docker/pkg/truncindex"
+	"github.com/docker/docker/registry"
 	"github.com/docker/docker/volume"
 	volumedrivers "github.com/docker/docker/volume/drivers"
 	"github.com/docker/docker/volume/local"
@@ -364,6 +367,170 @@ func TestDaemonReloadNotAffectOthers(t *testing.T) {
 	}
 }
 
+func TestDaemonReloadRegistryOptions(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.configStore = &Config{}
+	daemon.RegistryService = registry.NewService(registry.ServiceOptions{})
+
+	valuesSets := make(map[string]interface{})
+	valuesSets["registry-mirrors"] = []string{"https://mirror.example.com"}
+	valuesSets["insecure-registries"] = []string{"registry.example.com"}
+	newConfig := &Config{
+		CommonConfig: CommonConfig{
+			ServiceOptions: registry.ServiceOptions{
+				Mirrors:            []string{"https://mirror.example.com"},
+				InsecureRegistries: []string{"registry.example.com"},
+			},
+			valuesSet: valuesSets,
+		},
+	}
+
+	if err := daemon.Reload(newConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	if mirrors := daemon.configStore.Mirrors; len(mirrors) != 1 || mirrors[0] != "https://mirror.example.com/" {
+		t.Fatalf("Expected the reloaded mirror, got %v", mirrors)
+	}
+	if mirrors := daemon.RegistryService.ServiceConfig().Mirrors; len(mirrors) != 1 || mirrors[0] != "https://mirror.example.com/" {
+		t.Fatalf("Expected the registry service to use the reloaded mirror, got %v", mirrors)
+	}
+	index, err := daemon.RegistryService.ResolveIndex("registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index.Secure {
+		t.Fatal("Expected registry.example.com to be insecure")
+	}
+}
+
+func TestDaemonReloadLogOpts(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.configStore = &Config{}
+	daemon.defaultLogConfig = containertypes.LogConfig{
+		Type:   "json-file",
+		Config: map[string]string{"max-size": "10m"},
+	}
+
+	valuesSets := make(map[string]interface{})
+	valuesSets["log-opts"] = map[string]string{"max-file": "3", "max-size": "1m"}
+	newConfig := &Config{
+		CommonConfig: CommonConfig{
+			LogConfig: LogConfig{
+				Config: map[string]string{"max-file": "3", "max-size": "1m"},
+			},
+			valuesSet: valuesSets,
+		},
+	}
+
+	if err := daemon.Reload(newConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := containertypes.LogConfig{Config: map[string]string{"max-file": "5"}}
+	if err := daemon.mergeAndVerifyLogConfig(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Type != "json-file" || cfg.Config["max-size"] != "1m" || cfg.Config["max-file"] != "5" {
+		t.Fatalf("Expected the reloaded log options to be merged, got %v", cfg)
+	}
+}
+
+func TestDaemonReloadInvalidConfiguration(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.configStore = &Config{
+		CommonConfig: CommonConfig{
+			Labels: []string{"foo:bar"},
+		},
+	}
+	daemon.defaultLogConfig = containertypes.LogConfig{Type: "json-file"}
+
+	for _, newConfig := range []*Config{
+		{
+			CommonConfig: CommonConfig{
+				Labels:         []string{"foo:baz"},
+				ServiceOptions: registry.ServiceOptions{Mirrors: []string{"ftp://mirror.example.com"}},
+				valuesSet: map[string]interface{}{
+					"labels":           "foo:baz",
+					"registry-mirrors": []string{"ftp://mirror.example.com"},
+				},
+			},
+		},
+		{
+			CommonConfig: CommonConfig{
+				Labels:    []string{"foo:baz"},
+				LogConfig: LogConfig{Config: map[string]string{"unknown-opt": "foo"}},
+				valuesSet: map[string]interface{}{
+					"labels":   "foo:baz",
+					"log-opts": map[string]string{"unknown-opt": "foo"},
+				},
+			},
+		},
+		{
+			// The live restore option is not applied, as the daemon has
+			// no containerd remote to apply it to.
+			CommonConfig: CommonConfig{
+				Labels:           []string{"foo:baz"},
+				LiveRestore:      true,
+				ClusterAdvertise: "127.0.0.1:2375",
+				valuesSet: map[string]interface{}{
+					"labels":            "foo:baz",
+					"live-restore":      true,
+					"cluster-advertise": "127.0.0.1:2375",
+				},
+			},
+		},
+	} {
+		if err := daemon.Reload(newConfig); err == nil {
+			t.Fatalf("Expected an error reloading %v", newConfig.valuesSet)
+		}
+		if label := daemon.configStore.Labels[0]; label != "foo:bar" {
+			t.Fatalf("Expected the labels not to be reloaded, got %s", label)
+		}
+		if daemon.configStore.LiveRestore {
+			t.Fatal("Expected the live restore option not to be reloaded")
+		}
+	}
+}
+
+// failingRemote is a containerd remote whose options can't be updated.
+type failingRemote struct {
+	libcontainerd.Remote
+}
+
+func (r failingRemote) UpdateOptions(...libcontainerd.RemoteOption) error {
+	return errors.New("containerd is not reachable")
+}
+
+func TestDaemonReloadLiveRestoreFailure(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.configStore = &Config{}
+	daemon.containerdRemote = failingRemote{}
+
+	newConfig := &Config{
+		CommonConfig: CommonConfig{
+			ClusterStore:     "memory://127.0.0.1:2222",
+			ClusterAdvertise: "127.0.0.1:5555",
+			LiveRestore:      true,
+			valuesSet: map[string]interface{}{
+				"cluster-store":     "memory://127.0.0.1:2222",
+				"cluster-advertise": "127.0.0.1:5555",
+				"live-restore":      true,
+			},
+		},
+	}
+	if err := daemon.Reload(newConfig); err == nil {
+		t.Fatal("Expected an error reloading the live restore option")
+	}
+
+	if daemon.discoveryWatcher != nil || daemon.configStore.ClusterStore != "" {
+		t.Fatalf("Expected the discovery not to be reloaded, got cluster store %q", daemon.configStore.ClusterStore)
+	}
+	if daemon.configStore.LiveRestore {
+		t.Fatal("Expected the live restore option not to be reloaded")
+	}
+}
+
 func TestDaemonDiscoveryReload(t *testing.T) {
 	daemon := &Daemon{}
 	daemon.configStore = &Config{
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -527,8 +528,28 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 	return warnings, nil
 }
 
+// validatePlatformReload checks that the platform specific options of config
+// can be applied to the daemon, before any option is reloaded.
+func (daemon *Daemon) validatePlatformReload(config *Config) error {
+	runtimes := daemon.configStore.Runtimes
+	if config.IsValueSet("runtimes") {
+		runtimes = config.Runtimes
+	}
+	defaultRuntime := daemon.configStore.DefaultRuntime
+	if config.DefaultRuntime != "" {
+		defaultRuntime = config.DefaultRuntime
+	}
+	if defaultRuntime == "" || defaultRuntime == stockRuntimeName {
+		return nil
+	}
+	if _, ok := runtimes[defaultRuntime]; !ok {
+		return fmt.Errorf("Unknown default runtime %s", defaultRuntime)
+	}
+	return nil
+}
+
 // platformReload update configuration with platform specific options
-func (daemon *Daemon) platformReload(config *Config, attributes *map[string]string) {
+func (daemon *Daemon) platformReload(config *Config) {
 	if config.IsValueSet("runtimes") {
 		daemon.configStore.Runtimes = config.Runtimes
 		// Always set the default one
@@ -538,18 +559,27 @@ func (daemon *Daemon) platformReload(config *Config, attributes *map[string]stri
 	if config.DefaultRuntime != "" {
 		daemon.configStore.DefaultRuntime = config.DefaultRuntime
 	}
+}
+
+// platformReloadAttributes adds the platform specific options that can be
+// reloaded to the attributes of the reload event.
+func (daemon *Daemon) platformReloadAttributes(attributes map[string]string) {
+	var names []string
+	for name := range daemon.configStore.Runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	// Update attributes
 	var runtimeList bytes.Buffer
-	for name, rt := range daemon.configStore.Runtimes {
+	for _, name := range names {
 		if runtimeList.Len() > 0 {
 			runtimeList.WriteRune(' ')
 		}
-		runtimeList.WriteString(fmt.Sprintf("%s:%s", name, rt))
+		runtimeList.WriteString(fmt.Sprintf("%s:%s", name, daemon.configStore.Runtimes[name]))
 	}
 
-	(*attributes)["runtimes"] = runtimeList.String()
-	(*attributes)["default-runtime"] = daemon.configStore.DefaultRuntime
+	attributes["runtimes"] = runtimeList.String()
+	attributes["default-runtime"] = daemon.configStore.DefaultRuntime
 }
 
 // verifyDaemonSettings performs validation of daemon config struct
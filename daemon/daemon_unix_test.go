@@ -5,9 +5,11 @@ package daemon
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/docker/docker/container"
+	"github.com/docker/engine-api/types"
 	containertypes "github.com/docker/engine-api/types/container"
 )
 
@@ -197,3 +199,46 @@ func TestNetworkOptions(t *testing.T) {
 		t.Fatalf("Expected networkOptions error, got nil")
 	}
 }
+
+func TestDaemonReloadUnknownDefaultRuntime(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.configStore = &Config{
+		DefaultRuntime: stockRuntimeName,
+		Runtimes: map[string]types.Runtime{
+			stockRuntimeName: {Path: DefaultRuntimeBinary},
+			"custom":         {Path: "/usr/local/bin/custom-runc"},
+		},
+	}
+
+	newConfig := &Config{
+		CommonConfig: CommonConfig{
+			Labels: []string{"foo:baz"},
+			valuesSet: map[string]interface{}{
+				"labels":   "foo:baz",
+				"runtimes": "",
+			},
+		},
+		DefaultRuntime: "custom",
+		Runtimes:       map[string]types.Runtime{},
+	}
+	if err := daemon.Reload(newConfig); err == nil || !strings.Contains(err.Error(), "Unknown default runtime custom") {
+		t.Fatalf("Expected the reload to fail with an unknown default runtime, got %v", err)
+	}
+	if daemon.configStore.DefaultRuntime != stockRuntimeName {
+		t.Fatalf("Expected the default runtime not to change, got %s", daemon.configStore.DefaultRuntime)
+	}
+	if _, ok := daemon.configStore.Runtimes["custom"]; !ok {
+		t.Fatalf("Expected the runtimes not to change, got %v", daemon.configStore.Runtimes)
+	}
+	if len(daemon.configStore.Labels) != 0 {
+		t.Fatalf("Expected the labels not to change, got %v", daemon.configStore.Labels)
+	}
+
+	newConfig.Runtimes["custom"] = types.Runtime{Path: "/usr/local/bin/custom-runc"}
+	if err := daemon.Reload(newConfig); err != nil {
+		t.Fatal(err)
+	}
+	if daemon.configStore.DefaultRuntime != "custom" {
+		t.Fatalf("Expected the default runtime to be custom, got %s", daemon.configStore.DefaultRuntime)
+	}
+}
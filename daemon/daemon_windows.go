@@ -156,8 +156,19 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 	return warnings, nil
 }
 
+// validatePlatformReload checks that the platform specific options of config
+// can be applied to the daemon, before any option is reloaded.
+func (daemon *Daemon) validatePlatformReload(config *Config) error {
+	return nil
+}
+
 // platformReload update configuration with platform specific options
-func (daemon *Daemon) platformReload(config *Config, attributes *map[string]string) {
+func (daemon *Daemon) platformReload(config *Config) {
+}
+
+// platformReloadAttributes adds the platform specific options that can be
+// reloaded to the attributes of the reload event.
+func (daemon *Daemon) platformReloadAttributes(attributes map[string]string) {
 }
 
 // verifyDaemonSettings performs validation of daemon config struct
@@ -5,6 +5,9 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
+
+	"golang.org/x/net/context"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/container"
@@ -17,8 +20,10 @@ import (
 // ContainerRm removes the container id from the filesystem. An error
 // is returned if the container is not found, or if the remove
 // fails. If the remove succeeds, the container name is released, and
-// network links are removed.
-func (daemon *Daemon) ContainerRm(name string, config *types.ContainerRmConfig) error {
+// network links are removed. The destroy event is attributed to the
+// user that made the request of ctx, if any.
+func (daemon *Daemon) ContainerRm(ctx context.Context, name string, config *types.ContainerRmConfig) error {
+	start := time.Now()
 	container, err := daemon.GetContainer(name)
 	if err != nil {
 		return err
@@ -39,12 +44,15 @@ func (daemon *Daemon) ContainerRm(name string, config *types.ContainerRmConfig)
 		return daemon.rmLink(container, name)
 	}
 
-	err = daemon.cleanupContainer(container, config.ForceRemove)
+	err = daemon.cleanupContainer(container, config.ForceRemove, identityAttributes(ctx))
 	if err == nil || config.ForceRemove {
 		if e := daemon.removeMountPoints(container, config.RemoveVolume); e != nil {
 			logrus.Error(e)
 		}
 	}
+	if err == nil {
+		observeSince(containerActions, "delete", start)
+	}
 
 	return err
 }
@@ -77,7 +85,8 @@ func (daemon *Daemon) rmLink(container *container.Container, name string) error
 
 // cleanupContainer unregisters a container from the daemon, stops stats
 // collection and cleanly removes contents and metadata from the filesystem.
-func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemove bool) (err error) {
+// The attributes are added to the destroy event.
+func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemove bool, attributes map[string]string) (err error) {
 	if container.IsRunning() {
 		if !forceRemove {
 			err := fmt.Errorf("You cannot remove a running container %s. Stop the container before attempting removal or use -f", container.ID)
@@ -115,7 +124,7 @@ func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemo
 			selinuxFreeLxcContexts(container.ProcessLabel)
 			daemon.idIndex.Delete(container.ID)
 			daemon.containers.Delete(container.ID)
-			daemon.LogContainerEvent(container, "destroy")
+			daemon.LogContainerEventWithAttributes(container, "destroy", attributes)
 		}
 	}()
 
@@ -0,0 +1,188 @@
+package daemon
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/directory"
+	"github.com/docker/docker/volume"
+	"github.com/docker/engine-api/types"
+)
+
+// getLayerRefs returns the number of images referencing each layer.
+func (daemon *Daemon) getLayerRefs() map[layer.ChainID]int {
+	layerRefs := map[layer.ChainID]int{}
+	for _, img := range daemon.imageStore.Map() {
+		rootFS := *img.RootFS
+		rootFS.DiffIDs = nil
+		for _, id := range img.RootFS.DiffIDs {
+			rootFS.Append(id)
+			chid := rootFS.ChainID()
+			layerRefs[chid]++
+		}
+	}
+
+	return layerRefs
+}
+
+// SystemDiskUsage returns information about the daemon data disk usage
+func (daemon *Daemon) SystemDiskUsage() (*types.DiskUsage, error) {
+	if !atomic.CompareAndSwapInt32(&daemon.diskUsageRunning, 0, 1) {
+		return nil, fmt.Errorf("a disk usage operation is already running")
+	}
+	defer atomic.StoreInt32(&daemon.diskUsageRunning, 0)
+
+	// Retrieve container list
+	allContainers, err := daemon.Containers(&types.ContainerListOptions{
+		Size: true,
+		All:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve container list: %v", err)
+	}
+
+	// Get all top images with extra attributes
+	allImages, err := daemon.Images("", "", false, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve image list: %v", err)
+	}
+
+	// Get all local volumes
+	allVolumes := []*types.Volume{}
+	vols, warnings, err := daemon.volumes.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warnings {
+		logrus.Warnf("system df: %s", w)
+	}
+	for _, v := range vols {
+		allVolumes = append(allVolumes, daemon.volumeUsage(v))
+	}
+
+	// Get total layers size on disk
+	layerRefs := daemon.getLayerRefs()
+	allLayers := daemon.layerStore.Map()
+	var allLayersSize int64
+	for _, l := range allLayers {
+		size, err := l.DiffSize()
+		if err == nil {
+			if _, ok := layerRefs[l.ChainID()]; ok {
+				allLayersSize += size
+			} else {
+				logrus.Warnf("found leaked image layer %v", l.ChainID())
+			}
+		} else {
+			logrus.Warnf("failed to get diff size for layer %v", l.ChainID())
+		}
+	}
+
+	// Get the size of the build cache, and the part of it which is only
+	// used by dangling images
+	usedLayers := daemon.getUsedLayers(allContainers)
+	var builderSize, builderReclaimable int64
+	for chid := range daemon.getBuildCacheLayers() {
+		l, ok := allLayers[chid]
+		if !ok {
+			continue
+		}
+		size, err := l.DiffSize()
+		if err != nil {
+			logrus.Warnf("failed to get diff size for layer %v", chid)
+			continue
+		}
+		builderSize += size
+		if _, used := usedLayers[chid]; !used {
+			builderReclaimable += size
+		}
+	}
+
+	return &types.DiskUsage{
+		LayersSize:         allLayersSize,
+		Containers:         allContainers,
+		Volumes:            allVolumes,
+		Images:             allImages,
+		BuilderSize:        builderSize,
+		BuilderReclaimable: builderReclaimable,
+	}, nil
+}
+
+// getUsedLayers returns the layers of the images which are tagged or used by one
+// of containers. The other layers are only used by dangling images, and
+// are removed with them.
+func (daemon *Daemon) getUsedLayers(containers []*types.Container) map[layer.ChainID]struct{} {
+	inUse := map[image.ID]struct{}{}
+	for _, c := range containers {
+		inUse[image.ID(c.ImageID)] = struct{}{}
+	}
+
+	layers := map[layer.ChainID]struct{}{}
+	for id, img := range daemon.imageStore.Map() {
+		if _, ok := inUse[id]; !ok && len(daemon.referenceStore.References(id)) == 0 {
+			continue
+		}
+		rootFS := *img.RootFS
+		rootFS.DiffIDs = nil
+		for _, diffID := range img.RootFS.DiffIDs {
+			rootFS.Append(diffID)
+			layers[rootFS.ChainID()] = struct{}{}
+		}
+	}
+	return layers
+}
+
+// getBuildCacheLayers returns the layers created by the intermediate images
+// the builder keeps to cache build steps, that is the untagged images other
+// images are built on top of. Build steps which don't change the filesystem
+// create no layer.
+func (daemon *Daemon) getBuildCacheLayers() map[layer.ChainID]struct{} {
+	layers := map[layer.ChainID]struct{}{}
+	for id, img := range daemon.imageStore.Map() {
+		if len(daemon.referenceStore.References(id)) > 0 || len(daemon.imageStore.Children(id)) == 0 {
+			continue
+		}
+		chid := img.RootFS.ChainID()
+		if chid == "" {
+			continue
+		}
+		if parent, err := daemon.imageStore.GetParent(id); err == nil {
+			if parentImg, err := daemon.imageStore.Get(parent); err == nil && parentImg.RootFS.ChainID() == chid {
+				continue
+			}
+		}
+		layers[chid] = struct{}{}
+	}
+	return layers
+}
+
+// volumeUsage converts v to the type used by the remote API, including the
+// number of containers referencing it and, for local volumes, the size of
+// its data.
+func (daemon *Daemon) volumeUsage(v volume.Volume) *types.Volume {
+	tv := volumeToAPIType(v)
+	if vv, ok := v.(interface {
+		CachedPath() string
+	}); ok {
+		tv.Mountpoint = vv.CachedPath()
+	} else {
+		tv.Mountpoint = v.Path()
+	}
+
+	var size int64 = -1
+	if v.DriverName() == volume.DefaultDriverName {
+		var err error
+		size, err = directory.Size(tv.Mountpoint)
+		if err != nil {
+			logrus.Warnf("failed to determine size of volume %v: %v", v.Name(), err)
+			size = -1
+		}
+	}
+	tv.UsageData = &types.VolumeUsageData{
+		Size:     size,
+		RefCount: int64(len(daemon.volumes.Refs(v))),
+	}
+	return tv
+}
@@ -4,8 +4,11 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/net/context"
+
 	"github.com/docker/docker/container"
 	daemonevents "github.com/docker/docker/daemon/events"
+	"github.com/docker/docker/pkg/authentication"
 	"github.com/docker/engine-api/types/events"
 	"github.com/docker/engine-api/types/filters"
 	"github.com/docker/libnetwork"
@@ -112,6 +115,25 @@ func copyAttributes(attributes, labels map[string]string) {
 		return
 	}
 	for k, v := range labels {
+		// Labels must not set or hide the user that caused the event, even
+		// when the request was not authenticated.
+		if k == userAttribute {
+			continue
+		}
 		attributes[k] = v
 	}
 }
+
+// userAttribute is the attribute of events holding the authenticated user of
+// the API request that caused the event.
+const userAttribute = "user"
+
+// identityAttributes returns the attributes of an event caused by the API
+// request of ctx, identifying the user that made the request.
+func identityAttributes(ctx context.Context) map[string]string {
+	attributes := map[string]string{}
+	if id := authentication.IdentityFromContext(ctx); id != nil {
+		attributes[userAttribute] = id.User
+	}
+	return attributes
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/pkg/pubsub"
 	eventtypes "github.com/docker/engine-api/types/events"
 )
@@ -15,9 +16,10 @@ const (
 
 // Events is pubsub channel for events generated by the engine.
 type Events struct {
-	mu     sync.Mutex
-	events []eventtypes.Message
-	pub    *pubsub.Publisher
+	mu      sync.Mutex
+	events  []eventtypes.Message
+	pub     *pubsub.Publisher
+	journal *Journal
 }
 
 // New returns new *Events instance
@@ -28,6 +30,14 @@ func New() *Events {
 	}
 }
 
+// SetJournal sets the journal in which events are persisted. When a journal is
+// set, past events are read from the journal instead of the in-memory buffer.
+func (e *Events) SetJournal(j *Journal) {
+	e.mu.Lock()
+	e.journal = j
+	e.mu.Unlock()
+}
+
 // Subscribe adds new listener to events, returns slice of 64 stored
 // last events, a channel in which you can expect new events (in form
 // of interface{}, so you need type assertion), and a function to call
@@ -58,6 +68,15 @@ func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtype
 
 	buffered := e.loadBufferedEvents(since, until, topic)
 
+	// The journal is read once the lock is released, as it may be large. Only
+	// the events older than the buffered ones are read from it: the newer ones
+	// are either in the buffer or sent to the subscription.
+	journal := e.journal
+	oldest := time.Now().UTC()
+	if len(e.events) > 0 {
+		oldest = time.Unix(0, e.events[0].TimeNano)
+	}
+
 	var ch chan interface{}
 	if topic != nil {
 		ch = e.pub.SubscribeTopic(topic)
@@ -67,6 +86,10 @@ func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtype
 	}
 
 	e.mu.Unlock()
+
+	if journal != nil && !(since.IsZero() && until.IsZero()) {
+		buffered = append(loadJournalEvents(journal, since, until, oldest, topic), buffered...)
+	}
 	return buffered, ch
 }
 
@@ -106,7 +129,13 @@ func (e *Events) Log(action, eventType string, actor eventtypes.Actor) {
 	} else {
 		e.events = append(e.events, jm)
 	}
+	if e.journal != nil {
+		if err := e.journal.Write(jm); err != nil {
+			logrus.Warnf("Failed to write event to the events journal: %v", err)
+		}
+	}
 	e.mu.Unlock()
+	eventsCounter.WithLabelValues(eventType).Inc()
 	e.pub.Publish(jm)
 }
 
@@ -152,3 +181,35 @@ func (e *Events) loadBufferedEvents(since, until time.Time, topic func(interface
 	}
 	return buffered
 }
+
+// loadJournalEvents returns the events of the journal emitted between since
+// and until, and before the time of the oldest buffered event.
+func loadJournalEvents(journal *Journal, since, until, oldest time.Time, topic func(interface{}) bool) []eventtypes.Message {
+	before := oldest.Add(-time.Nanosecond)
+	if until.IsZero() || before.Before(until) {
+		until = before
+	}
+	if !since.IsZero() && until.Before(since) {
+		return nil
+	}
+
+	events, err := journal.Read(since, until, topic)
+	if err != nil {
+		logrus.Warnf("Failed to read the events journal, only returning buffered events: %v", err)
+		return nil
+	}
+	return events
+}
+
+// Close closes the journal, if one is set.
+func (e *Events) Close() error {
+	e.mu.Lock()
+	journal := e.journal
+	e.journal = nil
+	e.mu.Unlock()
+
+	if journal == nil {
+		return nil
+	}
+	return journal.Close()
+}
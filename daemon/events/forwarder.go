@@ -0,0 +1,155 @@
+package events
+
+import (
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	eventtypes "github.com/docker/engine-api/types/events"
+)
+
+const (
+	// forwarderQueueSize is the number of events a forwarder queues while
+	// its sink is slow or unavailable. Events are dropped when it is full.
+	forwarderQueueSize = 10000
+	// forwarderMaxRetries is the number of times the delivery of an event
+	// is retried before it is dropped.
+	forwarderMaxRetries = 5
+	// forwarderInitialBackoff is the delay before the first retry. It is
+	// doubled after each failed retry.
+	forwarderInitialBackoff = 500 * time.Millisecond
+	// forwarderDrainTimeout is how long a stopped forwarder keeps delivering
+	// the queued events before dropping them.
+	forwarderDrainTimeout = 10 * time.Second
+)
+
+// Forwarder subscribes to events and delivers the events matching its filter
+// to a sink. Events are queued, so that a slow sink doesn't make the forwarder
+// miss events, and failed deliveries are retried with a backoff.
+type Forwarder struct {
+	name   string
+	events *Events
+	sink   Sink
+	filter *Filter
+
+	listener chan interface{}
+	queue    chan eventtypes.Message
+	received chan struct{}
+	stop     chan struct{}
+	abort    chan struct{}
+	done     chan struct{}
+}
+
+// NewForwarder creates a forwarder delivering the events of e matching the
+// filters of config to a new sink. Start must be called to start forwarding.
+func NewForwarder(e *Events, config SinkConfig) (*Forwarder, error) {
+	sink, err := NewSink(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Forwarder{
+		name:     config.Type,
+		events:   e,
+		sink:     sink,
+		filter:   NewFilter(config.Filters),
+		queue:    make(chan eventtypes.Message, forwarderQueueSize),
+		received: make(chan struct{}),
+		stop:     make(chan struct{}),
+		abort:    make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Start subscribes to the events and starts delivering them to the sink.
+func (f *Forwarder) Start() {
+	_, f.listener = f.events.SubscribeTopic(time.Time{}, time.Time{}, f.filter)
+	go f.receive()
+	go f.deliver()
+}
+
+// Stop stops forwarding events and closes the sink. The queued events are
+// delivered first, unless it takes longer than forwarderDrainTimeout, in which
+// case the remaining ones are dropped.
+func (f *Forwarder) Stop() {
+	f.events.Evict(f.listener)
+	<-f.received
+	close(f.stop)
+	select {
+	case <-f.done:
+	case <-time.After(forwarderDrainTimeout):
+		logrus.Warnf("Timed out delivering queued events to %s event sink, dropping %d events", f.name, len(f.queue))
+		close(f.abort)
+		<-f.done
+	}
+	if err := f.sink.Close(); err != nil {
+		logrus.Warnf("Error closing %s event sink: %v", f.name, err)
+	}
+}
+
+// receive moves events from the subscription to the queue, so that the
+// subscription is never blocked by the sink.
+func (f *Forwarder) receive() {
+	defer close(f.received)
+	for m := range f.listener {
+		ev := m.(eventtypes.Message)
+		select {
+		case f.queue <- ev:
+		default:
+			logrus.Warnf("Event queue of %s event sink is full, dropping event %s %s", f.name, ev.Type, ev.Action)
+		}
+	}
+}
+
+// deliver sends the queued events to the sink until the forwarder is stopped,
+// and then the events left in the queue.
+func (f *Forwarder) deliver() {
+	defer close(f.done)
+	for {
+		select {
+		case <-f.stop:
+			f.drain()
+			return
+		case ev := <-f.queue:
+			if !f.send(ev) {
+				return
+			}
+		}
+	}
+}
+
+// drain sends the events left in the queue to the sink.
+func (f *Forwarder) drain() {
+	for {
+		select {
+		case ev := <-f.queue:
+			if !f.send(ev) {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// send delivers an event to the sink, retrying with a backoff if it fails.
+// It returns false if the forwarder was aborted while retrying.
+func (f *Forwarder) send(ev eventtypes.Message) bool {
+	backoff := forwarderInitialBackoff
+	for attempt := 0; ; attempt++ {
+		err := f.sink.Send(ev)
+		if err == nil {
+			return true
+		}
+		if attempt == forwarderMaxRetries {
+			logrus.Errorf("Failed to deliver event %s %s to %s event sink, dropping it: %v", ev.Type, ev.Action, f.name, err)
+			return true
+		}
+		logrus.Debugf("Failed to deliver event to %s event sink, retrying in %s: %v", f.name, backoff, err)
+
+		select {
+		case <-f.abort:
+			return false
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
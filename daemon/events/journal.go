@@ -0,0 +1,203 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/logger/loggerutils"
+	eventtypes "github.com/docker/engine-api/types/events"
+)
+
+// journalFiles is the number of files the journal is split into. When the
+// current file is full, the oldest one is discarded.
+const journalFiles = 4
+
+// maxPruneInterval is the longest interval between two removals of the
+// expired files of the journal.
+const maxPruneInterval = time.Hour
+
+// Journal is an on-disk log of events, which allows to query events older
+// than the ones kept in memory, including events from before a restart of
+// the daemon. It is bounded by size and, optionally, by age.
+type Journal struct {
+	// mu is held to write to the journal, which may rotate or remove its
+	// files, and while the files are opened to read them.
+	mu     sync.RWMutex
+	path   string
+	maxAge time.Duration
+	w      *loggerutils.RotateFileWriter
+	stop   chan struct{}
+}
+
+// NewJournal opens the journal at path, creating it if needed. The journal
+// takes at most maxSize bytes on disk. If maxAge is not zero, events older
+// than maxAge are discarded.
+func NewJournal(path string, maxSize int64, maxAge time.Duration) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	w, err := loggerutils.NewRotateFileWriter(path, maxSize/journalFiles, journalFiles, false)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &Journal{
+		path:   path,
+		maxAge: maxAge,
+		w:      w,
+		stop:   make(chan struct{}),
+	}
+	j.prune()
+	if maxAge != 0 {
+		interval := maxAge
+		if interval > maxPruneInterval {
+			interval = maxPruneInterval
+		}
+		go j.pruneEvery(interval)
+	}
+	return j, nil
+}
+
+// pruneEvery prunes the journal at the given interval until it is closed.
+func (j *Journal) pruneEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			j.prune()
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+// files returns the paths of the files of the journal, from the oldest to
+// the most recent.
+func (j *Journal) files() []string {
+	files := make([]string, 0, journalFiles)
+	for i := journalFiles - 1; i > 0; i-- {
+		files = append(files, j.path+"."+strconv.Itoa(i))
+	}
+	return append(files, j.path)
+}
+
+// prune removes the rotated files which only contain events older than the
+// maximum age of the journal.
+func (j *Journal) prune() {
+	if j.maxAge == 0 {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	limit := time.Now().Add(-j.maxAge)
+	for _, path := range j.files()[:journalFiles-1] {
+		fi, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if fi.ModTime().Before(limit) {
+			if err := os.Remove(path); err != nil {
+				logrus.Warnf("Failed to remove expired events journal file %s: %v", path, err)
+			}
+		}
+	}
+}
+
+// Write appends an event to the journal.
+func (j *Journal) Write(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	_, err = j.w.Write(append(b, '\n'))
+	j.mu.Unlock()
+	return err
+}
+
+// Read returns the events of the journal emitted between since and until,
+// filtered with topic if it's not nil. A zero until means no upper bound.
+func (j *Journal) Read(since, until time.Time, topic func(interface{}) bool) ([]eventtypes.Message, error) {
+	var sinceNanoUnix int64
+	if !since.IsZero() {
+		sinceNanoUnix = since.UnixNano()
+	}
+	if j.maxAge != 0 {
+		if limit := time.Now().Add(-j.maxAge).UnixNano(); limit > sinceNanoUnix {
+			sinceNanoUnix = limit
+		}
+	}
+
+	var untilNanoUnix int64
+	if !until.IsZero() {
+		untilNanoUnix = until.UnixNano()
+	}
+
+	// Open the files at once, so that they are not rotated while they are
+	// read. Events written afterwards are appended to the opened files.
+	var files []*os.File
+	j.mu.RLock()
+	for _, path := range j.files() {
+		f, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			j.mu.RUnlock()
+			closeFiles(files)
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	j.mu.RUnlock()
+	defer closeFiles(files)
+
+	var events []eventtypes.Message
+	for _, f := range files {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var ev eventtypes.Message
+			if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+				// A partial line may have been written if the daemon
+				// crashed, skip it.
+				logrus.Debugf("Skipping invalid entry in events journal %s: %v", f.Name(), err)
+				continue
+			}
+
+			if ev.TimeNano < sinceNanoUnix {
+				continue
+			}
+			if untilNanoUnix > 0 && ev.TimeNano > untilNanoUnix {
+				continue
+			}
+			if topic == nil || topic(ev) {
+				events = append(events, ev)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		f.Close()
+	}
+}
+
+// Close stops pruning the journal and closes it.
+func (j *Journal) Close() error {
+	close(j.stop)
+	return j.w.Close()
+}
@@ -0,0 +1,198 @@
+package events
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/engine-api/types/events"
+)
+
+func TestJournalKeepsEventsBeyondLimit(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "events", "events.log")
+	j, err := NewJournal(path, 1024*1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := New()
+	e.SetJournal(j)
+	since := time.Now()
+	for i := 0; i < eventsLimit+10; i++ {
+		e.Log("start", events.ContainerEventType, events.Actor{ID: "cont"})
+	}
+	if len(e.events) != eventsLimit {
+		t.Fatalf("Must be %d buffered events, got %d", eventsLimit, len(e.events))
+	}
+
+	buffered, l := e.SubscribeTopic(since, time.Time{}, nil)
+	e.Evict(l)
+	if len(buffered) != eventsLimit+10 {
+		t.Fatalf("Must be %d events from the journal, got %d", eventsLimit+10, len(buffered))
+	}
+
+	// The events survive a restart
+	if err := j.Close(); err != nil {
+		t.Fatal(err)
+	}
+	j, err = NewJournal(path, 1024*1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	e = New()
+	e.SetJournal(j)
+	buffered, l = e.SubscribeTopic(since, time.Time{}, nil)
+	e.Evict(l)
+	if len(buffered) != eventsLimit+10 {
+		t.Fatalf("Must be %d events after reopening the journal, got %d", eventsLimit+10, len(buffered))
+	}
+}
+
+func TestJournalMaxSize(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "events.log")
+	j, err := NewJournal(path, 4*1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	for i := 0; i < 1000; i++ {
+		if err := j.Write(events.Message{Action: "start", TimeNano: int64(i + 1)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var size int64
+	for _, f := range j.files() {
+		fi, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		size += fi.Size()
+	}
+	// The current file may exceed its capacity by one event.
+	if size > 5*1024 {
+		t.Fatalf("Journal should be bounded to 4k, got %d bytes", size)
+	}
+
+	read, err := j.Read(time.Unix(0, 1), time.Time{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) == 0 || len(read) >= 1000 {
+		t.Fatalf("Expected the oldest events to be discarded, got %d events", len(read))
+	}
+	if last := read[len(read)-1]; last.TimeNano != 1000 {
+		t.Fatalf("Expected the last event to be kept, got %v", last)
+	}
+}
+
+func TestJournalMaxAge(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	j, err := NewJournal(filepath.Join(tmp, "events.log"), 1024*1024, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	now := time.Now()
+	old := events.Message{Action: "old", TimeNano: now.Add(-2 * time.Hour).UnixNano()}
+	recent := events.Message{Action: "recent", TimeNano: now.UnixNano()}
+	for _, m := range []events.Message{old, recent} {
+		if err := j.Write(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read, err := j.Read(now.Add(-3*time.Hour), time.Time{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != 1 || read[0].Action != "recent" {
+		t.Fatalf("Expected only the recent event, got %v", read)
+	}
+}
+
+func TestJournalPrunesPeriodically(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "events.log")
+	j, err := NewJournal(path, 1024*1024, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	// A file rotated after the journal was opened
+	rotated := path + ".1"
+	if err := ioutil.WriteFile(rotated, []byte("{}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(rotated); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the expired file to be removed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEventsCloseJournal(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	j, err := NewJournal(filepath.Join(tmp, "events.log"), 1024*1024, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := New()
+	e.SetJournal(j)
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if e.journal != nil {
+		t.Fatal("Expected the journal to be unset")
+	}
+
+	// Events are still buffered once the journal is closed
+	since := time.Now()
+	e.Log("start", events.ContainerEventType, events.Actor{ID: "cont"})
+	buffered, l := e.SubscribeTopic(since, time.Time{}, nil)
+	e.Evict(l)
+	if len(buffered) != 1 {
+		t.Fatalf("Must be 1 buffered event, got %d", len(buffered))
+	}
+}
@@ -0,0 +1,14 @@
+package events
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var eventsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "engine",
+	Subsystem: "daemon",
+	Name:      "events_total",
+	Help:      "The number of events logged, by event type",
+}, []string{"type"})
+
+func init() {
+	prometheus.MustRegister(eventsCounter)
+}
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	eventtypes "github.com/docker/engine-api/types/events"
+	"github.com/docker/engine-api/types/filters"
+)
+
+// Sink delivers events to a system outside of the daemon.
+type Sink interface {
+	// Send delivers a single event. Failed deliveries are retried by the
+	// forwarder, so Send must not retry on its own.
+	Send(eventtypes.Message) error
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// SinkConfig is the configuration of an event sink, as parsed from a
+// --event-sink option.
+type SinkConfig struct {
+	// Type is the type of the sink: webhook, syslog or file.
+	Type string
+	// Options are the options specific to the type of the sink.
+	Options map[string]string
+	// Filters select the events delivered to the sink. All the events are
+	// delivered if it is empty.
+	Filters filters.Args
+}
+
+// ParseSinkConfig parses the comma separated list of key=value pairs of an
+// event sink, for example:
+//
+//	type=webhook,url=https://example.com/events,filter=type=container
+//
+// The filter key can be repeated, its value is a key=value filter as accepted
+// by `docker events --filter`.
+func ParseSinkConfig(value string) (SinkConfig, error) {
+	config := SinkConfig{
+		Options: make(map[string]string),
+		Filters: filters.NewArgs(),
+	}
+
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return config, err
+	}
+
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return config, fmt.Errorf("invalid field '%s' in event sink: must be a key=value pair", field)
+		}
+
+		key, val := strings.ToLower(parts[0]), parts[1]
+		switch key {
+		case "type":
+			config.Type = val
+		case "filter":
+			filter := strings.SplitN(val, "=", 2)
+			if len(filter) != 2 {
+				return config, fmt.Errorf("invalid filter '%s' in event sink: must be a key=value pair", val)
+			}
+			config.Filters.Add(strings.ToLower(filter[0]), filter[1])
+		default:
+			config.Options[key] = val
+		}
+	}
+
+	if err := config.Filters.Validate(acceptedSinkFilterTags); err != nil {
+		return config, err
+	}
+	if err := validateSinkOptions(config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// ValidateSink validates the value of an --event-sink option.
+func ValidateSink(value string) (string, error) {
+	if _, err := ParseSinkConfig(value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// acceptedSinkFilterTags are the filters accepted by `docker events`.
+var acceptedSinkFilterTags = map[string]bool{
+	"container": true,
+	"daemon":    true,
+	"event":     true,
+	"image":     true,
+	"label":     true,
+	"network":   true,
+	"type":      true,
+	"volume":    true,
+}
+
+// sinkOptions are the options accepted by each type of sink, and whether
+// they are required.
+var sinkOptions = map[string]map[string]bool{
+	"webhook": {"url": true},
+	"syslog":  {"address": false, "tag": false},
+	"file":    {"path": true},
+}
+
+func validateSinkOptions(config SinkConfig) error {
+	if config.Type == "" {
+		return fmt.Errorf("type is required for an event sink")
+	}
+	options, ok := sinkOptions[config.Type]
+	if !ok {
+		return fmt.Errorf("invalid event sink type '%s': must be webhook, syslog or file", config.Type)
+	}
+	for key := range config.Options {
+		if _, ok := options[key]; !ok {
+			return fmt.Errorf("unknown option '%s' for %s event sink", key, config.Type)
+		}
+	}
+	for key, required := range options {
+		if _, ok := config.Options[key]; required && !ok {
+			return fmt.Errorf("%s is required for %s event sink", key, config.Type)
+		}
+	}
+	return nil
+}
+
+// NewSink creates the sink described by config.
+func NewSink(config SinkConfig) (Sink, error) {
+	if err := validateSinkOptions(config); err != nil {
+		return nil, err
+	}
+	switch config.Type {
+	case "webhook":
+		return newWebhookSink(config.Options["url"])
+	case "syslog":
+		return newSyslogSink(config.Options["address"], config.Options["tag"])
+	default:
+		return newFileSink(config.Options["path"])
+	}
+}
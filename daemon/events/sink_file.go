@@ -0,0 +1,45 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	eventtypes "github.com/docker/engine-api/types/events"
+)
+
+// fileSink appends events to a file, one JSON object per line.
+type fileSink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func newFileSink(path string) (Sink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{f: f}, nil
+}
+
+// Send appends the event to the file.
+func (s *fileSink) Send(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.f.Write(append(b, '\n'))
+	return err
+}
+
+// Close closes the file.
+func (s *fileSink) Close() error {
+	return s.f.Close()
+}
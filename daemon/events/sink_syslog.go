@@ -0,0 +1,65 @@
+// +build linux
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+
+	syslog "github.com/RackSec/srslog"
+	eventtypes "github.com/docker/engine-api/types/events"
+)
+
+// syslogSink sends each event as a JSON object to syslog.
+type syslogSink struct {
+	w *syslog.Writer
+}
+
+// newSyslogSink connects to the syslog endpoint at address, in the form
+// proto://address, or to the local syslog if address is empty.
+func newSyslogSink(address, tag string) (Sink, error) {
+	if tag == "" {
+		tag = "docker-events"
+	}
+
+	var proto, addr string
+	if address != "" {
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil, err
+		}
+		switch u.Scheme {
+		case "unix", "unixgram":
+			proto, addr = u.Scheme, u.Path
+		case "tcp", "udp":
+			proto, addr = u.Scheme, u.Host
+			if _, _, err := net.SplitHostPort(addr); err != nil {
+				addr = net.JoinHostPort(addr, "514")
+			}
+		default:
+			return nil, fmt.Errorf("invalid syslog address %s: must be in the form proto://address with proto tcp, udp, unix or unixgram", address)
+		}
+	}
+
+	w, err := syslog.Dial(proto, addr, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogSink{w: w}, nil
+}
+
+// Send writes the event to syslog.
+func (s *syslogSink) Send(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return s.w.Info(string(b))
+}
+
+// Close closes the connection to syslog.
+func (s *syslogSink) Close() error {
+	return s.w.Close()
+}
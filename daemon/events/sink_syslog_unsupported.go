@@ -0,0 +1,12 @@
+// +build !linux
+
+package events
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func newSyslogSink(address, tag string) (Sink, error) {
+	return nil, fmt.Errorf("syslog event sinks are not supported on %s", runtime.GOOS)
+}
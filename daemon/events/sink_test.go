@@ -0,0 +1,194 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/engine-api/types/events"
+)
+
+func TestParseSinkConfig(t *testing.T) {
+	config, err := ParseSinkConfig("type=webhook,url=https://example.com/events,filter=type=container,filter=event=die")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Type != "webhook" {
+		t.Fatalf("Expected webhook sink, got %s", config.Type)
+	}
+	if config.Options["url"] != "https://example.com/events" {
+		t.Fatalf("Expected url option, got %v", config.Options)
+	}
+	if !config.Filters.ExactMatch("type", "container") || !config.Filters.ExactMatch("event", "die") {
+		t.Fatalf("Expected type and event filters, got %v", config.Filters)
+	}
+
+	invalids := map[string]string{
+		"url=https://example.com":          "type is required",
+		"type=unknown":                     "invalid event sink type 'unknown'",
+		"type=file":                        "path is required for file event sink",
+		"type=file,path=/tmp/e,foo=bar":    "unknown option 'foo' for file event sink",
+		"type=file,path":                   "invalid field 'path' in event sink",
+		"type=file,path=/e,filter=type":    "invalid filter 'type' in event sink",
+		"type=file,path=/e,filter=nme=web": "Invalid filter 'nme'",
+	}
+	for value, expected := range invalids {
+		if _, err := ParseSinkConfig(value); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected an error containing %q for %s, got %v", expected, value, err)
+		}
+	}
+}
+
+func TestForwarderFileSink(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "events-sink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "events.json")
+	config, err := ParseSinkConfig("type=file,path=" + path + ",filter=type=container")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := New()
+	f, err := NewForwarder(e, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Start()
+
+	e.Log("create", events.ContainerEventType, events.Actor{ID: "cont"})
+	e.Log("pull", events.ImageEventType, events.Actor{ID: "image"})
+	e.Log("start", events.ContainerEventType, events.Actor{ID: "cont"})
+
+	var lines []events.Message
+	for i := 0; i < 50 && len(lines) < 2; i++ {
+		time.Sleep(20 * time.Millisecond)
+		lines = readEventsFile(t, path)
+	}
+	f.Stop()
+
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 container events, got %v", lines)
+	}
+	if lines[0].Action != "create" || lines[1].Action != "start" {
+		t.Fatalf("Expected create and start events, got %v", lines)
+	}
+}
+
+func TestForwarderStopDeliversQueuedEvents(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "events-sink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "events.json")
+	config, err := ParseSinkConfig("type=file,path=" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := New()
+	f, err := NewForwarder(e, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Start()
+
+	for i := 0; i < 100; i++ {
+		e.Log("start", events.ContainerEventType, events.Actor{ID: "cont"})
+	}
+	f.Stop()
+
+	if lines := readEventsFile(t, path); len(lines) != 100 {
+		t.Fatalf("Expected the 100 events to be delivered, got %d", len(lines))
+	}
+}
+
+func readEventsFile(t *testing.T, path string) []events.Message {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var messages []events.Message
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var m events.Message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, m)
+	}
+	return messages
+}
+
+func TestForwarderWebhookRetries(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts int
+		received []events.Message
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var m events.Message
+		if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, m)
+	}))
+	defer server.Close()
+
+	config, err := ParseSinkConfig("type=webhook,url=" + server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := New()
+	f, err := NewForwarder(e, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Start()
+	defer f.Stop()
+
+	e.Log("create", events.ContainerEventType, events.Actor{ID: "cont"})
+
+	for i := 0; i < 100; i++ {
+		mu.Lock()
+		n := len(received)
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 || received[0].Action != "create" {
+		t.Fatalf("Expected the event to be delivered after a retry, got %v", received)
+	}
+	if attempts != 2 {
+		t.Fatalf("Expected 2 attempts, got %d", attempts)
+	}
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	eventtypes "github.com/docker/engine-api/types/events"
+)
+
+// webhookTimeout is the maximum time a single delivery to a webhook may take.
+const webhookTimeout = 10 * time.Second
+
+// webhookSink posts each event as a JSON object to a URL.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookSink(rawurl string) (Sink, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %s: must be an absolute http or https URL", rawurl)
+	}
+	return &webhookSink{
+		url:    rawurl,
+		client: &http.Client{Timeout: webhookTimeout},
+	}, nil
+}
+
+// Send posts the event to the webhook. Any response other than 2xx is
+// considered a failure.
+func (s *webhookSink) Send(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook %s returned %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+// Close does nothing, the webhook sink doesn't hold any resource.
+func (s *webhookSink) Close() error {
+	return nil
+}
@@ -75,6 +75,71 @@ func TestLogContainerEventWithAttributes(t *testing.T) {
 	})
 }
 
+func TestLogContainerEventIgnoresUserLabel(t *testing.T) {
+	e := events.New()
+	_, l, _ := e.Subscribe()
+	defer e.Evict(l)
+
+	container := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:   "container_id",
+			Name: "container_name",
+			Config: &containertypes.Config{
+				Labels: map[string]string{
+					"node": "1",
+					"user": "mallory",
+				},
+			},
+		},
+	}
+	daemon := &Daemon{
+		EventsService: e,
+	}
+	daemon.LogContainerEvent(container, "create")
+
+	select {
+	case ev := <-l:
+		event := ev.(eventtypes.Message)
+		if user, ok := event.Actor.Attributes["user"]; ok {
+			t.Fatalf("Expected no user attribute, got %q", user)
+		}
+		if node := event.Actor.Attributes["node"]; node != "1" {
+			t.Fatalf("Expected the node label to be copied, got %q", node)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("LogEvent test timed out")
+	}
+
+	daemon.LogContainerEventWithAttributes(container, "start", map[string]string{"user": "alice"})
+	validateTestAttributes(t, l, map[string]string{
+		"node": "1",
+		"user": "alice",
+	})
+}
+
+func TestStartEventAttributes(t *testing.T) {
+	daemon := &Daemon{}
+	if attributes := daemon.startEventAttributes("container_id"); len(attributes) != 0 {
+		t.Fatalf("Expected no attributes, got %v", attributes)
+	}
+
+	daemon.setStartAttributes("container_id", map[string]string{"user": "alice"})
+	attributes := daemon.startEventAttributes("container_id")
+	if attributes["user"] != "alice" {
+		t.Fatalf("Expected the user attribute, got %v", attributes)
+	}
+	// The attributes are copied, as the event adds the labels to them
+	attributes["name"] = "container_name"
+	if _, ok := daemon.startEventAttributes("container_id")["name"]; ok {
+		t.Fatal("Expected the start attributes not to be mutated")
+	}
+
+	daemon.setStartAttributes("container_id", nil)
+	if attributes := daemon.startEventAttributes("container_id"); len(attributes) != 0 {
+		t.Fatalf("Expected the attributes to be removed, got %v", attributes)
+	}
+}
+
 func validateTestAttributes(t *testing.T, l chan interface{}, expectedAttributesToTest map[string]string) {
 	select {
 	case ev := <-l:
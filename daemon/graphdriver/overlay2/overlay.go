@@ -10,18 +10,21 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"strconv"
 	"strings"
 	"syscall"
 
 	"github.com/Sirupsen/logrus"
 
 	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/daemon/graphdriver/quota"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/chrootarchive"
 	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/mount"
 	"github.com/docker/docker/pkg/parsers/kernel"
+	"github.com/docker/go-units"
 
 	"github.com/opencontainers/runc/libcontainer/label"
 )
@@ -76,10 +79,11 @@ const (
 
 // Driver contains information about the home directory and the list of active mounts that are created using this driver.
 type Driver struct {
-	home    string
-	uidMaps []idtools.IDMap
-	gidMaps []idtools.IDMap
-	ctr     *graphdriver.RefCounter
+	home     string
+	uidMaps  []idtools.IDMap
+	gidMaps  []idtools.IDMap
+	ctr      *graphdriver.RefCounter
+	quotaCtl *quota.Control
 }
 
 var backingFs = "<unknown>"
@@ -141,6 +145,14 @@ func Init(home string, options []string, uidMaps, gidMaps []idtools.IDMap) (grap
 		ctr:     graphdriver.NewRefCounter(graphdriver.NewFsChecker(graphdriver.FsMagicOverlay)),
 	}
 
+	// Per-container size limits are enforced with project quotas, which are
+	// only supported on xfs mounted with the pquota option.
+	if fsMagic == graphdriver.FsMagicXfs {
+		if d.quotaCtl, err = quota.NewControl(home); err != nil {
+			logrus.Debugf("overlay2: project quotas are not supported on %s: %v", home, err)
+		}
+	}
+
 	return d, nil
 }
 
@@ -170,10 +182,12 @@ func (d *Driver) String() string {
 }
 
 // Status returns current driver information in a two dimensional string array.
-// Output contains "Backing Filesystem" used in this implementation.
+// Output contains "Backing Filesystem" used in this implementation and
+// whether the size of containers can be limited with project quotas.
 func (d *Driver) Status() [][2]string {
 	return [][2]string{
 		{"Backing Filesystem", backingFs},
+		{"Project Quota", strconv.FormatBool(d.quotaCtl != nil)},
 	}
 }
 
@@ -218,9 +232,12 @@ func (d *Driver) CreateReadWrite(id, parent, mountLabel string, storageOpt map[s
 // Create is used to create the upper, lower, and merge directories required for overlay fs for a given id.
 // The parent filesystem is used to configure these directories for the overlay.
 func (d *Driver) Create(id, parent, mountLabel string, storageOpt map[string]string) (retErr error) {
-
-	if len(storageOpt) != 0 {
-		return fmt.Errorf("--storage-opt is not supported for overlay")
+	size, err := parseStorageOpt(storageOpt)
+	if err != nil {
+		return err
+	}
+	if size > 0 && d.quotaCtl == nil {
+		return fmt.Errorf("--storage-opt size is only supported for overlay2 over xfs with the 'pquota' mount option")
 	}
 
 	dir := d.dir(id)
@@ -243,6 +260,14 @@ func (d *Driver) Create(id, parent, mountLabel string, storageOpt map[string]str
 		}
 	}()
 
+	// The quota is set on the layer directory before creating its content,
+	// so that the project id is inherited by everything written below it.
+	if size > 0 {
+		if err := d.quotaCtl.SetQuota(dir, quota.Quota{Size: size}); err != nil {
+			return err
+		}
+	}
+
 	if err := idtools.MkdirAs(path.Join(dir, "diff"), 0755, rootUID, rootGID); err != nil {
 		return err
 	}
@@ -282,6 +307,29 @@ func (d *Driver) Create(id, parent, mountLabel string, storageOpt map[string]str
 	return nil
 }
 
+// parseStorageOpt returns the size limit set with the "size" storage option,
+// or 0 if none is set.
+func parseStorageOpt(storageOpt map[string]string) (uint64, error) {
+	var size uint64
+	for k, v := range storageOpt {
+		key := strings.ToLower(k)
+		switch key {
+		case "size":
+			s, err := units.RAMInBytes(v)
+			if err != nil {
+				return 0, err
+			}
+			if s < 0 {
+				return 0, fmt.Errorf("Invalid size %s", v)
+			}
+			size = uint64(s)
+		default:
+			return 0, fmt.Errorf("Unknown option %s", key)
+		}
+	}
+	return size, nil
+}
+
 func (d *Driver) getLower(parent string) (string, error) {
 	parentDir := d.dir(parent)
 
@@ -0,0 +1,280 @@
+// +build linux
+
+// Package quota implements XFS project quotas, which storage drivers use to
+// limit the size of a directory, such as the writable layer of a container.
+//
+// Each directory with a quota is assigned a unique project id, inherited by
+// every file and directory created below it, and a block limit is set on
+// that project id. This requires the backing filesystem to be XFS mounted
+// with the "pquota" (or "prjquota") option.
+package quota
+
+/*
+#include <stdlib.h>
+#include <linux/fs.h>
+#include <linux/quota.h>
+#include <linux/dqblk_xfs.h>
+
+#ifndef FS_XFLAG_PROJINHERIT
+struct fsxattr {
+	__u32		fsx_xflags;
+	__u32		fsx_extsize;
+	__u32		fsx_nextents;
+	__u32		fsx_projid;
+	unsigned char	fsx_pad[12];
+};
+#define FS_XFLAG_PROJINHERIT	0x00000200
+#endif
+#ifndef FS_IOC_FSGETXATTR
+#define FS_IOC_FSGETXATTR		_IOR ('X', 31, struct fsxattr)
+#endif
+#ifndef FS_IOC_FSSETXATTR
+#define FS_IOC_FSSETXATTR		_IOW ('X', 32, struct fsxattr)
+#endif
+
+#ifndef PRJQUOTA
+#define PRJQUOTA	2
+#endif
+#ifndef XFS_PROJ_QUOTA
+#define XFS_PROJ_QUOTA	2
+#endif
+#ifndef Q_XSETPQLIM
+#define Q_XSETPQLIM QCMD(Q_XSETQLIM, PRJQUOTA)
+#endif
+#ifndef Q_XGETPQUOTA
+#define Q_XGETPQUOTA QCMD(Q_XGETQUOTA, PRJQUOTA)
+#endif
+*/
+import "C"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// backingFsBlockDevName is the name of the block device node, created in the
+// base directory, which quotactl calls are made against.
+const backingFsBlockDevName = "backingFsBlockDev"
+
+// Quota holds the limits of a directory. Only the size, as a hard limit on
+// blocks, is supported.
+type Quota struct {
+	Size uint64
+}
+
+// Control sets project quotas on the subdirectories of a base directory,
+// usually the home directory of a storage driver.
+type Control struct {
+	sync.Mutex
+	backingFsBlockDev string
+	nextProjectID     uint32
+	quotas            map[string]uint32
+}
+
+// NewControl initializes project quotas for the subdirectories of basePath.
+// It returns an error if the backing filesystem of basePath does not support
+// project quotas.
+//
+// The project id of basePath is used as the lowest project id, so that
+// project ids managed outside of docker, for example with xfs_quota, do not
+// conflict with the ones assigned to containers:
+//
+//    echo 999:/var/lib/docker/overlay2 >> /etc/projects
+//    echo docker:999 >> /etc/projid
+//    xfs_quota -x -c 'project -s docker' /<xfs mount point>
+//
+// The project ids already assigned to the subdirectories of basePath are
+// loaded, so that they are kept across restarts of the daemon.
+func NewControl(basePath string) (*Control, error) {
+	minProjectID, err := getProjectID(basePath)
+	if err != nil {
+		return nil, err
+	}
+	minProjectID++
+
+	backingFsBlockDev, err := makeBackingFsDev(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Setting an empty quota on the first project id fails if project
+	// quotas are not enabled on the backing filesystem.
+	if err := setProjectQuota(backingFsBlockDev, minProjectID, Quota{}); err != nil {
+		return nil, err
+	}
+
+	q := &Control{
+		backingFsBlockDev: backingFsBlockDev,
+		nextProjectID:     minProjectID + 1,
+		quotas:            make(map[string]uint32),
+	}
+	if err := q.loadProjectIDs(basePath); err != nil {
+		return nil, err
+	}
+
+	logrus.Debugf("quota: project quotas enabled on %s, next project id is %d", basePath, q.nextProjectID)
+	return q, nil
+}
+
+// SetQuota assigns a project id to targetPath, if it doesn't have one yet,
+// and sets the limits of that project id to quota.
+func (q *Control) SetQuota(targetPath string, quota Quota) error {
+	q.Lock()
+	defer q.Unlock()
+
+	projectID, ok := q.quotas[targetPath]
+	if !ok {
+		projectID = q.nextProjectID
+		if err := setProjectID(targetPath, projectID); err != nil {
+			return err
+		}
+		q.quotas[targetPath] = projectID
+		q.nextProjectID++
+	}
+
+	logrus.Debugf("quota: setting quota of %s to %d bytes (project id %d)", targetPath, quota.Size, projectID)
+	return setProjectQuota(q.backingFsBlockDev, projectID, quota)
+}
+
+// GetQuota returns the limits of targetPath, which must have been set with
+// SetQuota.
+func (q *Control) GetQuota(targetPath string) (Quota, error) {
+	q.Lock()
+	projectID, ok := q.quotas[targetPath]
+	q.Unlock()
+	if !ok {
+		return Quota{}, fmt.Errorf("no quota set for %s", targetPath)
+	}
+
+	var d C.fs_disk_quota_t
+
+	cs := C.CString(q.backingFsBlockDev)
+	defer C.free(unsafe.Pointer(cs))
+
+	_, _, errno := syscall.Syscall6(syscall.SYS_QUOTACTL, C.Q_XGETPQUOTA,
+		uintptr(unsafe.Pointer(cs)), uintptr(C.__u32(projectID)),
+		uintptr(unsafe.Pointer(&d)), 0, 0)
+	if errno != 0 {
+		return Quota{}, fmt.Errorf("Failed to get quota limit for projid %d on %s: %v", projectID, q.backingFsBlockDev, errno.Error())
+	}
+
+	return Quota{Size: uint64(d.d_blk_hardlimit) * 512}, nil
+}
+
+// setProjectQuota sets the limits of a project id on the backing block
+// device. Limits are expressed in 512 bytes blocks.
+func setProjectQuota(backingFsBlockDev string, projectID uint32, quota Quota) error {
+	var d C.fs_disk_quota_t
+	d.d_version = C.FS_DQUOT_VERSION
+	d.d_id = C.__u32(projectID)
+	d.d_flags = C.XFS_PROJ_QUOTA
+
+	d.d_fieldmask = C.FS_DQ_BHARD | C.FS_DQ_BSOFT
+	d.d_blk_hardlimit = C.__u64(quota.Size / 512)
+	d.d_blk_softlimit = d.d_blk_hardlimit
+
+	cs := C.CString(backingFsBlockDev)
+	defer C.free(unsafe.Pointer(cs))
+
+	_, _, errno := syscall.Syscall6(syscall.SYS_QUOTACTL, C.Q_XSETPQLIM,
+		uintptr(unsafe.Pointer(cs)), uintptr(d.d_id),
+		uintptr(unsafe.Pointer(&d)), 0, 0)
+	if errno != 0 {
+		return fmt.Errorf("Failed to set quota limit for projid %d on %s: %v", projectID, backingFsBlockDev, errno.Error())
+	}
+	return nil
+}
+
+// getProjectID returns the project id of targetPath.
+func getProjectID(targetPath string) (uint32, error) {
+	dir, err := os.Open(targetPath)
+	if err != nil {
+		return 0, err
+	}
+	defer dir.Close()
+
+	var fsx C.struct_fsxattr
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dir.Fd(), C.FS_IOC_FSGETXATTR,
+		uintptr(unsafe.Pointer(&fsx)))
+	if errno != 0 {
+		return 0, fmt.Errorf("Failed to get projid for %s: %v", targetPath, errno.Error())
+	}
+	return uint32(fsx.fsx_projid), nil
+}
+
+// setProjectID sets the project id of targetPath, and marks it so that the
+// files and directories created below it inherit the project id.
+func setProjectID(targetPath string, projectID uint32) error {
+	dir, err := os.Open(targetPath)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	var fsx C.struct_fsxattr
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dir.Fd(), C.FS_IOC_FSGETXATTR,
+		uintptr(unsafe.Pointer(&fsx)))
+	if errno != 0 {
+		return fmt.Errorf("Failed to get projid for %s: %v", targetPath, errno.Error())
+	}
+	fsx.fsx_projid = C.__u32(projectID)
+	fsx.fsx_xflags |= C.FS_XFLAG_PROJINHERIT
+	_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, dir.Fd(), C.FS_IOC_FSSETXATTR,
+		uintptr(unsafe.Pointer(&fsx)))
+	if errno != 0 {
+		return fmt.Errorf("Failed to set projid for %s: %v", targetPath, errno.Error())
+	}
+	return nil
+}
+
+// loadProjectIDs records the project ids of the subdirectories of basePath
+// and moves the next project id past the highest one.
+func (q *Control) loadProjectIDs(basePath string) error {
+	files, err := ioutil.ReadDir(basePath)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+		path := filepath.Join(basePath, file.Name())
+		projectID, err := getProjectID(path)
+		if err != nil {
+			return err
+		}
+		if projectID > 0 {
+			q.quotas[path] = projectID
+		}
+		if q.nextProjectID <= projectID {
+			q.nextProjectID = projectID + 1
+		}
+	}
+	return nil
+}
+
+// makeBackingFsDev creates, in basePath, a block device node for the device
+// backing basePath, to be used by quotactl calls.
+func makeBackingFsDev(basePath string) (string, error) {
+	fi, err := os.Stat(basePath)
+	if err != nil {
+		return "", err
+	}
+
+	backingFsBlockDev := filepath.Join(basePath, backingFsBlockDevName)
+	// Re-create the node in case the base directory was moved to another
+	// device.
+	syscall.Unlink(backingFsBlockDev)
+	stat := fi.Sys().(*syscall.Stat_t)
+	if err := syscall.Mknod(backingFsBlockDev, syscall.S_IFBLK|0600, int(stat.Dev)); err != nil {
+		return "", fmt.Errorf("Failed to mknod %s: %v", backingFsBlockDev, err)
+	}
+	return backingFsBlockDev, nil
+}
@@ -3,8 +3,11 @@ package daemon
 import (
 	"bytes"
 	"fmt"
+	"net"
+	"net/http"
 	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
 	"golang.org/x/net/context"
@@ -13,6 +16,7 @@ import (
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/exec"
 	"github.com/docker/engine-api/types"
+	containertypes "github.com/docker/engine-api/types/container"
 	"github.com/docker/engine-api/types/strslice"
 )
 
@@ -105,6 +109,83 @@ func (p *cmdProbe) run(ctx context.Context, d *Daemon, container *container.Cont
 	}, nil
 }
 
+// httpProbe implements the "HTTP" probe type. The container is healthy if a
+// GET request to the URL returns a 2xx or 3xx status code.
+type httpProbe struct{}
+
+// Send a GET request to the URL from within the container's network namespace.
+// Failing to connect is reported as an unhealthy result, not as an error.
+func (p *httpProbe) run(ctx context.Context, d *Daemon, c *container.Container) (*types.HealthcheckResult, error) {
+	test := c.Config.Healthcheck.Test
+	if len(test) != 2 {
+		return nil, fmt.Errorf("HTTP health check requires exactly one URL, got %v", test[1:])
+	}
+
+	req, err := http.NewRequest("GET", test[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Cancel = ctx.Done()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			Dial: func(network, address string) (net.Conn, error) {
+				return dialContainer(ctx, c, network, address)
+			},
+			DisableKeepAlives: true,
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   err.Error(),
+		}, nil
+	}
+	defer resp.Body.Close()
+
+	exitCode := exitStatusUnhealthy
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusBadRequest {
+		exitCode = exitStatusHealthy
+	}
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitCode,
+		Output:   resp.Status,
+	}, nil
+}
+
+// tcpProbe implements the "TCP" probe type. The container is healthy if a
+// TCP connection to the address can be established.
+type tcpProbe struct{}
+
+// Connect to the address from within the container's network namespace.
+// Failing to connect is reported as an unhealthy result, not as an error.
+func (p *tcpProbe) run(ctx context.Context, d *Daemon, c *container.Container) (*types.HealthcheckResult, error) {
+	test := c.Config.Healthcheck.Test
+	if len(test) != 2 {
+		return nil, fmt.Errorf("TCP health check requires exactly one address, got %v", test[1:])
+	}
+
+	conn, err := dialContainer(ctx, c, "tcp", test[1])
+	if err != nil {
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   err.Error(),
+		}, nil
+	}
+	conn.Close()
+
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitStatusHealthy,
+		Output:   fmt.Sprintf("Connected to %s", test[1]),
+	}, nil
+}
+
 // Update the container's Status.Health struct based on the latest probe's result.
 func handleProbeResult(d *Daemon, c *container.Container, result *types.HealthcheckResult) {
 	c.Lock()
@@ -124,6 +205,12 @@ func handleProbeResult(d *Daemon, c *container.Container, result *types.Healthch
 		h.Log = append(h.Log, result)
 	}
 
+	if result.ExitCode == exitStatusHealthy {
+		healthChecks.WithLabelValues("success").Inc()
+	} else {
+		healthChecks.WithLabelValues("failure").Inc()
+	}
+
 	if result.ExitCode == exitStatusHealthy {
 		h.FailingStreak = 0
 		h.Status = types.Healthy
@@ -140,7 +227,46 @@ func handleProbeResult(d *Daemon, c *container.Container, result *types.Healthch
 
 	if oldStatus != h.Status {
 		d.LogContainerEvent(c, "health_status: "+h.Status)
+
+		if h.Status == types.Unhealthy && c.HostConfig != nil && c.HostConfig.RestartPolicy.IsOnUnhealthy() {
+			// c is locked, kill it from another goroutine.
+			go d.killUnhealthy(c)
+		}
+	}
+}
+
+// verifyRestartPolicyHealthcheck returns an error if the on-unhealthy restart
+// policy is given to a container without a health check.
+func verifyRestartPolicyHealthcheck(config *containertypes.Config, restartPolicy containertypes.RestartPolicy) error {
+	if !restartPolicy.IsOnUnhealthy() {
+		return nil
+	}
+	if config.Healthcheck == nil || len(config.Healthcheck.Test) == 0 || config.Healthcheck.Test[0] == "NONE" {
+		return fmt.Errorf("The on-unhealthy restart policy requires a health check")
 	}
+	return nil
+}
+
+// killUnhealthy kills a container whose health check failed, so that it is
+// restarted by its restart manager, which is told the exit is caused by the
+// health check. Unlike Kill, it doesn't cancel the restart manager nor mark
+// the container as manually stopped.
+func (d *Daemon) killUnhealthy(c *container.Container) {
+	c.Lock()
+	defer c.Unlock()
+
+	// The container may have been stopped or paused in the meantime.
+	if !c.Running || c.Paused || c.Restarting || c.State.Health == nil || c.State.Health.Status != types.Unhealthy {
+		return
+	}
+
+	logrus.Infof("Killing unhealthy container %s", c.ID)
+	c.RestartManager(false).SetUnhealthyKill()
+	if err := d.kill(c, int(syscall.SIGKILL)); err != nil {
+		logrus.Warnf("Failed to kill unhealthy container %s: %v", c.ID, err)
+		return
+	}
+	d.LogContainerEvent(c, "health_kill")
 }
 
 // Run the container's monitoring thread until notified via "stop".
@@ -213,8 +339,12 @@ func getProbe(c *container.Container) probe {
 		return &cmdProbe{shell: false}
 	case "CMD-SHELL":
 		return &cmdProbe{shell: true}
+	case "HTTP":
+		return &httpProbe{}
+	case "TCP":
+		return &tcpProbe{}
 	default:
-		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD')", config.Test[0])
+		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD', 'HTTP' or 'TCP')", config.Test[0])
 		return nil
 	}
 }
@@ -251,7 +381,12 @@ func (d *Daemon) initHealthMonitor(c *container.Container) {
 	// This is needed in case we're auto-restarting
 	d.stopHealthchecks(c)
 
-	if c.State.Health == nil {
+	if h := c.State.Health; h != nil {
+		// A restarted container starts with a clean health state, so
+		// that it can become unhealthy again.
+		h.Status = types.Starting
+		h.FailingStreak = 0
+	} else {
 		h := &container.Health{}
 		h.Status = types.Starting
 		h.FailingStreak = 0
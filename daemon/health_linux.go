@@ -0,0 +1,148 @@
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/vishvananda/netns"
+)
+
+// dialContainer connects to address from within the network namespace of the
+// container, so that probes can reach ports which are not published. The
+// socket is created in the container's namespace and connected from the
+// daemon's one. Names are not resolved, as the resolver of the daemon does not
+// use the network of the container: the host of address must be an IP address
+// or localhost.
+func dialContainer(ctx context.Context, c *container.Container, network, address string) (net.Conn, error) {
+	pid := c.GetPID()
+	if pid == 0 {
+		return nil, fmt.Errorf("container %s is not running", c.ID)
+	}
+	if network != "tcp" {
+		return nil, fmt.Errorf("unsupported network %s", network)
+	}
+
+	sa, family, err := probeSockaddr(address)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := containerSocket(pid, family)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a socket in the network namespace of container %s: %v", c.ID, err)
+	}
+
+	// The probe's context always has a deadline, which bounds the blocking
+	// connect below.
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := deadline.Sub(time.Now())
+		if timeout <= 0 {
+			syscall.Close(fd)
+			return nil, fmt.Errorf("dial %s %s: %v", network, address, context.DeadlineExceeded)
+		}
+		tv := syscall.NsecToTimeval(timeout.Nanoseconds())
+		if err := syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, syscall.SO_SNDTIMEO, &tv); err != nil {
+			syscall.Close(fd)
+			return nil, err
+		}
+	}
+	if err := syscall.Connect(fd, sa); err != nil {
+		syscall.Close(fd)
+		if err == syscall.EINPROGRESS {
+			err = context.DeadlineExceeded
+		}
+		return nil, fmt.Errorf("dial %s %s: %v", network, address, err)
+	}
+
+	f := os.NewFile(uintptr(fd), address)
+	defer f.Close()
+	return net.FileConn(f)
+}
+
+// probeSockaddr returns the socket address of address, and its family.
+func probeSockaddr(address string) (syscall.Sockaddr, int, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, 0, err
+	}
+	portNum, err := net.LookupPort("tcp", port)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ip := net.ParseIP(host)
+	if host == "localhost" {
+		ip = net.IPv4(127, 0, 0, 1)
+	}
+	if ip == nil {
+		return nil, 0, fmt.Errorf("invalid address %s: the host must be an IP address or localhost", address)
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		sa := &syscall.SockaddrInet4{Port: portNum}
+		copy(sa.Addr[:], ip4)
+		return sa, syscall.AF_INET, nil
+	}
+	sa := &syscall.SockaddrInet6{Port: portNum}
+	copy(sa.Addr[:], ip.To16())
+	return sa, syscall.AF_INET6, nil
+}
+
+// containerSocket creates a TCP socket in the network namespace of the process
+// pid. The network namespace is a property of the thread, so it is entered on
+// a locked thread. That thread is never unlocked if the daemon's namespace
+// cannot be restored on it, so that it is not reused.
+func containerSocket(pid, family int) (int, error) {
+	type result struct {
+		fd  int
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		runtime.LockOSThread()
+		fd, restored, err := socketInNamespace(pid, family)
+		if restored {
+			runtime.UnlockOSThread()
+		}
+		ch <- result{fd, err}
+	}()
+	r := <-ch
+	return r.fd, r.err
+}
+
+// socketInNamespace creates a socket in the network namespace of the process
+// pid, from the current thread. It returns whether the thread is back in its
+// original namespace.
+func socketInNamespace(pid, family int) (int, bool, error) {
+	origNS, err := netns.Get()
+	if err != nil {
+		return -1, true, fmt.Errorf("failed to get the current network namespace: %v", err)
+	}
+	defer origNS.Close()
+
+	containerNS, err := netns.GetFromPath(fmt.Sprintf("/proc/%d/ns/net", pid))
+	if err != nil {
+		return -1, true, err
+	}
+	defer containerNS.Close()
+
+	if err := netns.Set(containerNS); err != nil {
+		return -1, true, err
+	}
+
+	fd, err := syscall.Socket(family, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+
+	if restoreErr := netns.Set(origNS); restoreErr != nil {
+		logrus.Errorf("Failed to restore the network namespace after creating a health check socket: %v", restoreErr)
+		return fd, false, err
+	}
+	return fd, true, err
+}
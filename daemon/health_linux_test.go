@@ -0,0 +1,57 @@
+// +build linux
+
+package daemon
+
+import (
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/container"
+)
+
+func TestProbeSockaddr(t *testing.T) {
+	for _, address := range []string{"localhost:80", "127.0.0.1:http", "[::1]:8080"} {
+		if _, _, err := probeSockaddr(address); err != nil {
+			t.Fatalf("Expected %s to be valid, got %v", address, err)
+		}
+	}
+	for _, address := range []string{"db:6379", "localhost", "127.0.0.1:nosuchservice"} {
+		if _, _, err := probeSockaddr(address); err == nil {
+			t.Fatalf("Expected %s to be rejected", address)
+		}
+	}
+}
+
+func TestDialContainer(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("entering a network namespace requires root")
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// the test process stands in for the container
+	c := container.NewBaseContainer("container_id", "")
+	c.State.Pid = os.Getpid()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := dialContainer(ctx, c, "tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	if _, err := dialContainer(ctx, c, "tcp", "db:"+port); err == nil || !strings.Contains(err.Error(), "IP address or localhost") {
+		t.Fatalf("Expected names not to be resolved, got %v", err)
+	}
+}
@@ -1,11 +1,13 @@
 package daemon
 
 import (
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/events"
+	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/engine-api/types"
 	containertypes "github.com/docker/engine-api/types/container"
 	eventtypes "github.com/docker/engine-api/types/events"
@@ -110,3 +112,93 @@ func TestHealthStates(t *testing.T) {
 		t.Errorf("Expecting FailingStreak=0, but got %d\n", c.State.Health.FailingStreak)
 	}
 }
+
+// signalRecorder is a containerd client which records the signals sent to
+// containers.
+type signalRecorder struct {
+	libcontainerd.Client
+	signals []int
+}
+
+func (r *signalRecorder) Signal(containerID string, sig int) error {
+	r.signals = append(r.signals, sig)
+	return nil
+}
+
+func TestKillUnhealthy(t *testing.T) {
+	e := events.New()
+	_, l, _ := e.Subscribe()
+	defer e.Evict(l)
+
+	client := &signalRecorder{}
+	daemon := &Daemon{
+		EventsService: e,
+		containerd:    client,
+	}
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:   "container_id",
+			Name: "container_name",
+			Config: &containertypes.Config{
+				Image: "image_name",
+			},
+			HostConfig: &containertypes.HostConfig{
+				RestartPolicy: containertypes.RestartPolicy{Name: "on-unhealthy"},
+			},
+		},
+	}
+
+	// a container which is not running or not unhealthy anymore is left alone
+	reset(c)
+	c.State.Health.Status = types.Unhealthy
+	daemon.killUnhealthy(c)
+	c.State.Running = true
+	c.State.Health.Status = types.Healthy
+	daemon.killUnhealthy(c)
+	if len(client.signals) != 0 {
+		t.Fatalf("Expected no signal, got %v", client.signals)
+	}
+
+	c.State.Health.Status = types.Unhealthy
+	daemon.killUnhealthy(c)
+	if len(client.signals) != 1 || client.signals[0] != int(syscall.SIGKILL) {
+		t.Fatalf("Expected SIGKILL, got %v", client.signals)
+	}
+	if c.HasBeenManuallyStopped {
+		t.Fatal("Expected the killed container not to be marked as manually stopped")
+	}
+	select {
+	case event := <-l:
+		if ev := event.(eventtypes.Message); ev.Status != "health_kill" {
+			t.Fatalf("Expecting event health_kill, but got %#v", ev.Status)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expecting event health_kill, but got nothing")
+	}
+}
+
+func TestVerifyRestartPolicyHealthcheck(t *testing.T) {
+	onUnhealthy := containertypes.RestartPolicy{Name: "on-unhealthy"}
+	cases := []struct {
+		healthcheck *containertypes.HealthConfig
+		valid       bool
+	}{
+		{healthcheck: nil, valid: false},
+		{healthcheck: &containertypes.HealthConfig{}, valid: false},
+		{healthcheck: &containertypes.HealthConfig{Test: []string{"NONE"}}, valid: false},
+		{healthcheck: &containertypes.HealthConfig{Test: []string{"CMD-SHELL", "true"}}, valid: true},
+	}
+	for _, c := range cases {
+		err := verifyRestartPolicyHealthcheck(&containertypes.Config{Healthcheck: c.healthcheck}, onUnhealthy)
+		if c.valid && err != nil {
+			t.Fatalf("Expected %+v to be valid, got %v", c.healthcheck, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("Expected %+v to be rejected", c.healthcheck)
+		}
+	}
+
+	if err := verifyRestartPolicyHealthcheck(&containertypes.Config{}, containertypes.RestartPolicy{Name: "on-failure"}); err != nil {
+		t.Fatalf("Expected on-failure without a health check to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+// +build !linux
+
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/container"
+)
+
+// dialContainer is not supported on this platform.
+func dialContainer(ctx context.Context, c *container.Container, network, address string) (net.Conn, error) {
+	return nil, fmt.Errorf("HTTP and TCP health checks are not supported on %s", runtime.GOOS)
+}
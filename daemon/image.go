@@ -5,6 +5,8 @@ import (
 
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/reference"
 	"github.com/docker/docker/runconfig"
 	containertypes "github.com/docker/engine-api/types/container"
@@ -72,6 +74,38 @@ func (daemon *Daemon) GetImageOnBuild(name string) (builder.Image, error) {
 	return img, nil
 }
 
+// MountImage mounts the root filesystem of the image referenced by `name`
+// on top of a throwaway read-write layer so that the builder can read files
+// from it. The returned function unmounts and removes that layer.
+func (daemon *Daemon) MountImage(name string) (string, func() error, error) {
+	img, err := daemon.GetImage(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	mountID := stringid.GenerateRandomID()
+	rwLayer, err := daemon.layerStore.CreateRWLayer(mountID, img.RootFS.ChainID(), "", nil, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	root, err := rwLayer.Mount("")
+	if err != nil {
+		metadata, _ := daemon.layerStore.ReleaseRWLayer(rwLayer)
+		layer.LogReleaseMetadata(metadata)
+		return "", nil, err
+	}
+
+	release := func() error {
+		if err := rwLayer.Unmount(); err != nil {
+			return err
+		}
+		_, err := daemon.layerStore.ReleaseRWLayer(rwLayer)
+		return err
+	}
+	return root, release, nil
+}
+
 // GetCachedImage returns the most recent created image that is a child
 // of the image with imgID, that had the same config when it was
 // created. nil is returned if a child cannot be found. An error is
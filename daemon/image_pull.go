@@ -3,6 +3,7 @@ package daemon
 import (
 	"io"
 	"strings"
+	"time"
 
 	"github.com/docker/distribution/digest"
 	"github.com/docker/docker/builder"
@@ -74,6 +75,7 @@ func (daemon *Daemon) PullOnBuild(ctx context.Context, name string, authConfigs
 }
 
 func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.Named, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
+	start := time.Now()
 	// Include a buffer so that slow client connections don't affect
 	// transfer performance.
 	progressChan := make(chan progress.Progress, 100)
@@ -87,12 +89,17 @@ func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.
 		close(writesDone)
 	}()
 
+	// Attribute the events to the user that made the request, if any.
+	logImageEvent := func(id, name, action string) {
+		daemon.LogImageEventWithAttributes(id, name, action, identityAttributes(ctx))
+	}
+
 	imagePullConfig := &distribution.ImagePullConfig{
 		MetaHeaders:      metaHeaders,
 		AuthConfig:       authConfig,
 		ProgressOutput:   progress.ChanOutput(progressChan),
 		RegistryService:  daemon.RegistryService,
-		ImageEventLogger: daemon.LogImageEvent,
+		ImageEventLogger: logImageEvent,
 		MetadataStore:    daemon.distributionMetadataStore,
 		ImageStore:       daemon.imageStore,
 		ReferenceStore:   daemon.referenceStore,
@@ -102,5 +109,8 @@ func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.
 	err := distribution.Pull(ctx, ref, imagePullConfig)
 	close(progressChan)
 	<-writesDone
+	if err == nil {
+		observeSince(imageActions, "pull", start)
+	}
 	return err
 }
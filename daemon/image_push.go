@@ -37,12 +37,17 @@ func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHead
 		close(writesDone)
 	}()
 
+	// Attribute the events to the user that made the request, if any.
+	logImageEvent := func(id, name, action string) {
+		daemon.LogImageEventWithAttributes(id, name, action, identityAttributes(ctx))
+	}
+
 	imagePushConfig := &distribution.ImagePushConfig{
 		MetaHeaders:      metaHeaders,
 		AuthConfig:       authConfig,
 		ProgressOutput:   progress.ChanOutput(progressChan),
 		RegistryService:  daemon.RegistryService,
-		ImageEventLogger: daemon.LogImageEvent,
+		ImageEventLogger: logImageEvent,
 		MetadataStore:    daemon.distributionMetadataStore,
 		LayerStore:       daemon.layerStore,
 		ImageStore:       daemon.imageStore,
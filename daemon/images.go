@@ -5,6 +5,7 @@ import (
 	"path"
 	"sort"
 
+	"github.com/docker/docker/container"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/reference"
@@ -36,8 +37,9 @@ func (daemon *Daemon) Map() map[image.ID]*image.Image {
 // of filter arguments which will be interpreted by api/types/filters.
 // filter is a shell glob string applied to repository names. The argument
 // named all controls whether all images in the graph are filtered, or just
-// the heads.
-func (daemon *Daemon) Images(filterArgs, filter string, all bool) ([]*types.Image, error) {
+// the heads. withExtraAttrs controls whether the number of containers using
+// each image and the size shared with other images are calculated.
+func (daemon *Daemon) Images(filterArgs, filter string, all bool, withExtraAttrs bool) ([]*types.Image, error) {
 	var (
 		allImages    map[image.ID]*image.Image
 		err          error
@@ -84,6 +86,19 @@ func (daemon *Daemon) Images(filterArgs, filter string, all bool) ([]*types.Imag
 
 	images := []*types.Image{}
 
+	var (
+		allContainers []*container.Container
+		allLayers     map[layer.ChainID]layer.Layer
+		imagesMap     map[*image.Image]*types.Image
+		layerRefs     map[layer.ChainID]int
+	)
+	if withExtraAttrs {
+		allContainers = daemon.List()
+		allLayers = daemon.layerStore.Map()
+		imagesMap = make(map[*image.Image]*types.Image)
+		layerRefs = make(map[layer.ChainID]int)
+	}
+
 	var filterTagged bool
 	if filter != "" {
 		filterRef, err := reference.ParseNamed(filter)
@@ -171,9 +186,52 @@ func (daemon *Daemon) Images(filterArgs, filter string, all bool) ([]*types.Imag
 			continue
 		}
 
+		if withExtraAttrs {
+			newImage.Containers = 0
+			for _, c := range allContainers {
+				if c.ImageID == id {
+					newImage.Containers++
+				}
+			}
+
+			// count the references to each layer of the image
+			rootFS := *img.RootFS
+			rootFS.DiffIDs = nil
+			for _, diffID := range img.RootFS.DiffIDs {
+				rootFS.Append(diffID)
+				chid := rootFS.ChainID()
+				if _, ok := allLayers[chid]; !ok {
+					return nil, fmt.Errorf("layer %v was not found (corruption?)", chid)
+				}
+				layerRefs[chid]++
+			}
+			imagesMap[img] = newImage
+		}
+
 		images = append(images, newImage)
 	}
 
+	if withExtraAttrs {
+		// layers referenced by more than one image count as shared size
+		for img, newImage := range imagesMap {
+			rootFS := *img.RootFS
+			rootFS.DiffIDs = nil
+			newImage.SharedSize = 0
+			for _, diffID := range img.RootFS.DiffIDs {
+				rootFS.Append(diffID)
+				chid := rootFS.ChainID()
+				if layerRefs[chid] <= 1 {
+					continue
+				}
+				diffSize, err := allLayers[chid].DiffSize()
+				if err != nil {
+					return nil, err
+				}
+				newImage.SharedSize += diffSize
+			}
+		}
+	}
+
 	sort.Sort(sort.Reverse(byCreated(images)))
 
 	return images, nil
@@ -186,6 +244,8 @@ func newImage(image *image.Image, size int64) *types.Image {
 	newImage.Created = image.Created.Unix()
 	newImage.Size = size
 	newImage.VirtualSize = size
+	newImage.SharedSize = -1
+	newImage.Containers = -1
 	if image.Config != nil {
 		newImage.Labels = image.Config.Labels
 	}
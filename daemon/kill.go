@@ -7,6 +7,8 @@ import (
 	"syscall"
 	"time"
 
+	"golang.org/x/net/context"
+
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/pkg/signal"
@@ -32,7 +34,9 @@ func isErrNoSuchProcess(err error) bool {
 // If no signal is given (sig 0), then Kill with SIGKILL and wait
 // for the container to exit.
 // If a signal is given, then just send it to the container and return.
-func (daemon *Daemon) ContainerKill(name string, sig uint64) error {
+// The kill event is attributed to the user that made the request of ctx,
+// if any.
+func (daemon *Daemon) ContainerKill(ctx context.Context, name string, sig uint64) error {
 	container, err := daemon.GetContainer(name)
 	if err != nil {
 		return err
@@ -44,17 +48,17 @@ func (daemon *Daemon) ContainerKill(name string, sig uint64) error {
 
 	// If no signal is passed, or SIGKILL, perform regular Kill (SIGKILL + wait())
 	if sig == 0 || syscall.Signal(sig) == syscall.SIGKILL {
-		return daemon.Kill(container)
+		return daemon.killContainer(container, identityAttributes(ctx))
 	}
-	return daemon.killWithSignal(container, int(sig))
+	return daemon.killWithSignal(container, int(sig), identityAttributes(ctx))
 }
 
 // killWithSignal sends the container the given signal. This wrapper for the
 // host specific kill command prepares the container before attempting
 // to send the signal. An error is returned if the container is paused
 // or not running, or if there is a problem returned from the
-// underlying kill command.
-func (daemon *Daemon) killWithSignal(container *container.Container, sig int) error {
+// underlying kill command. The attributes are added to the kill event.
+func (daemon *Daemon) killWithSignal(container *container.Container, sig int, attributes map[string]string) error {
 	logrus.Debugf("Sending %d to %s", sig, container.ID)
 	container.Lock()
 	defer container.Unlock()
@@ -92,21 +96,25 @@ func (daemon *Daemon) killWithSignal(container *container.Container, sig int) er
 		}
 	}
 
-	attributes := map[string]string{
-		"signal": fmt.Sprintf("%d", sig),
-	}
+	attributes["signal"] = fmt.Sprintf("%d", sig)
 	daemon.LogContainerEventWithAttributes(container, "kill", attributes)
 	return nil
 }
 
 // Kill forcefully terminates a container.
 func (daemon *Daemon) Kill(container *container.Container) error {
+	return daemon.killContainer(container, map[string]string{})
+}
+
+// killContainer forcefully terminates a container. The attributes are added
+// to the kill event.
+func (daemon *Daemon) killContainer(container *container.Container, attributes map[string]string) error {
 	if !container.IsRunning() {
 		return errNotRunning{container.ID}
 	}
 
 	// 1. Send SIGKILL
-	if err := daemon.killPossiblyDeadProcess(container, int(syscall.SIGKILL)); err != nil {
+	if err := daemon.killPossiblyDeadProcess(container, int(syscall.SIGKILL), attributes); err != nil {
 		// While normally we might "return err" here we're not going to
 		// because if we can't stop the container by this point then
 		// its probably because its already stopped. Meaning, between
@@ -142,8 +150,8 @@ func (daemon *Daemon) Kill(container *container.Container) error {
 }
 
 // killPossibleDeadProcess is a wrapper around killSig() suppressing "no such process" error.
-func (daemon *Daemon) killPossiblyDeadProcess(container *container.Container, sig int) error {
-	err := daemon.killWithSignal(container, sig)
+func (daemon *Daemon) killPossiblyDeadProcess(container *container.Container, sig int, attributes map[string]string) error {
+	err := daemon.killWithSignal(container, sig, attributes)
 	if err == syscall.ESRCH {
 		e := errNoSuchProcess{container.GetPID(), sig}
 		logrus.Debug(e)
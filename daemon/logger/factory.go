@@ -49,11 +49,16 @@ func (lf *logdriverFactory) registerLogOptValidator(name string, l LogOptValidat
 
 func (lf *logdriverFactory) get(name string) (Creator, error) {
 	lf.m.Lock()
-	defer lf.m.Unlock()
-
 	c, ok := lf.registry[name]
-	if !ok {
-		return c, fmt.Errorf("logger: no log driver named '%s' is registered", name)
+	lf.m.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	// fall back to a logging plugin with the given name
+	c, err := getPlugin(name)
+	if err != nil {
+		return nil, fmt.Errorf("logger: no log driver named '%s' is registered: %v", name, err)
 	}
 	return c, nil
 }
@@ -81,6 +86,8 @@ func RegisterLogOptValidator(name string, l LogOptValidator) error {
 }
 
 // GetLogDriver provides the logging driver builder for a logging driver name.
+// If no logging driver is registered with the name, a logging plugin with that
+// name is looked up.
 func GetLogDriver(name string) (Creator, error) {
 	return factory.get(name)
 }
@@ -93,7 +100,12 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 	}
 
 	if !factory.driverRegistered(name) {
-		return fmt.Errorf("logger: no log driver named '%s' is registered", name)
+		// options of logging plugins are validated by the plugin when a
+		// container starts logging
+		if !pluginExists(name) {
+			return fmt.Errorf("logger: no log driver named '%s' is registered", name)
+		}
+		return nil
 	}
 
 	validator := factory.getLogOptValidator(name)
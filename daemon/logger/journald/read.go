@@ -163,11 +163,15 @@ func (s *journald) Close() error {
 	return nil
 }
 
-func (s *journald) drainJournal(logWatcher *logger.LogWatcher, config logger.ReadConfig, j *C.sd_journal, oldCursor string) string {
+// drainJournal sends the entries of the journal from the current position to
+// logWatcher. It returns the cursor of the last entry read, and whether the
+// end of the time window of config was reached.
+func (s *journald) drainJournal(logWatcher *logger.LogWatcher, config logger.ReadConfig, j *C.sd_journal, oldCursor string) (string, bool) {
 	var msg, data, cursor *C.char
 	var length C.size_t
 	var stamp C.uint64_t
 	var priority C.int
+	var done bool
 
 	// Walk the journal from here forward until we run out of new entries.
 drain:
@@ -191,6 +195,11 @@ drain:
 			}
 			// Set up the time and text of the entry.
 			timestamp := time.Unix(int64(stamp)/1000000, (int64(stamp)%1000000)*1000)
+			// Stop if the entry is past the end of the time window.
+			if !config.Until.IsZero() && timestamp.After(config.Until) {
+				done = true
+				break
+			}
 			line := append(C.GoBytes(unsafe.Pointer(msg), C.int(length)), "\n"...)
 			// Recover the stream name by mapping
 			// from the journal priority back to
@@ -204,15 +213,19 @@ drain:
 			} else if priority == C.int(journal.PriInfo) {
 				source = "stdout"
 			}
-			// Retrieve the values of any variables we're adding to the journal.
-			attrs := make(map[string]string)
-			C.sd_journal_restart_data(j)
-			for C.get_attribute_field(j, &data, &length) > C.int(0) {
-				kv := strings.SplitN(C.GoStringN(data, C.int(length)), "=", 2)
-				attrs[kv[0]] = kv[1]
-			}
-			if len(attrs) == 0 {
-				attrs = nil
+			// Retrieve the values of any variables we're adding to the
+			// journal, if they were requested.
+			var attrs map[string]string
+			if config.Details {
+				attrs = make(map[string]string)
+				C.sd_journal_restart_data(j)
+				for C.get_attribute_field(j, &data, &length) > C.int(0) {
+					kv := strings.SplitN(C.GoStringN(data, C.int(length)), "=", 2)
+					attrs[kv[0]] = kv[1]
+				}
+				if len(attrs) == 0 {
+					attrs = nil
+				}
 			}
 			// Send the log message.
 			logWatcher.Msg <- &logger.Message{
@@ -232,7 +245,7 @@ drain:
 		retCursor = C.GoString(cursor)
 		C.free(unsafe.Pointer(cursor))
 	}
-	return retCursor
+	return retCursor, done
 }
 
 func (s *journald) followJournal(logWatcher *logger.LogWatcher, config logger.ReadConfig, j *C.sd_journal, pfd [2]C.int, cursor string) {
@@ -244,7 +257,11 @@ func (s *journald) followJournal(logWatcher *logger.LogWatcher, config logger.Re
 		// or we hit an error.
 		status := C.wait_for_data_or_close(j, pfd[0])
 		for status == 1 {
-			cursor = s.drainJournal(logWatcher, config, j, cursor)
+			var done bool
+			cursor, done = s.drainJournal(logWatcher, config, j, cursor)
+			if done {
+				break
+			}
 			status = C.wait_for_data_or_close(j, pfd[0])
 		}
 		if status < 0 {
@@ -274,6 +291,7 @@ func (s *journald) readLogs(logWatcher *logger.LogWatcher, config logger.ReadCon
 	var cmatch *C.char
 	var stamp C.uint64_t
 	var sinceUnixMicro uint64
+	var untilUnixMicro uint64
 	var pipes [2]C.int
 	cursor := ""
 
@@ -314,10 +332,20 @@ func (s *journald) readLogs(logWatcher *logger.LogWatcher, config logger.ReadCon
 		nano := config.Since.UnixNano()
 		sinceUnixMicro = uint64(nano / 1000)
 	}
+	if !config.Until.IsZero() {
+		nano := config.Until.UnixNano()
+		untilUnixMicro = uint64(nano / 1000)
+	}
 	if config.Tail > 0 {
 		lines := config.Tail
-		// Start at the end of the journal.
-		if C.sd_journal_seek_tail(j) < 0 {
+		if untilUnixMicro != 0 {
+			// Start at the end of the time window.
+			if C.sd_journal_seek_realtime_usec(j, C.uint64_t(untilUnixMicro)) < 0 {
+				logWatcher.Err <- fmt.Errorf("error seeking to end time in journal")
+				return
+			}
+		} else if C.sd_journal_seek_tail(j) < 0 {
+			// Start at the end of the journal.
 			logWatcher.Err <- fmt.Errorf("error seeking to end of journal")
 			return
 		}
@@ -363,8 +391,8 @@ func (s *journald) readLogs(logWatcher *logger.LogWatcher, config logger.ReadCon
 			return
 		}
 	}
-	cursor = s.drainJournal(logWatcher, config, j, "")
-	if config.Follow {
+	cursor, done := s.drainJournal(logWatcher, config, j, "")
+	if config.Follow && !done {
 		// Allocate a descriptor for following the journal, if we'll
 		// need one.  Do it here so that we can report if it fails.
 		if fd := C.sd_journal_get_fd(j); fd < C.int(0) {
@@ -1,6 +1,7 @@
 package jsonfilelog
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"io/ioutil"
 	"os"
@@ -200,6 +201,159 @@ func TestJSONFileLoggerWithLabelsEnv(t *testing.T) {
 	}
 }
 
+func TestJSONFileLoggerWithCompress(t *testing.T) {
+	cid := "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657"
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	filename := filepath.Join(tmp, "container.log")
+	config := map[string]string{"max-file": "3", "max-size": "1k", "compress": "true"}
+	l, err := New(logger.Context{
+		ContainerID: cid,
+		LogPath:     filename,
+		Config:      config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 36; i++ {
+		if err := l.Log(&logger.Message{Line: []byte("line" + strconv.Itoa(i)), Source: "src1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// closing waits for the rotated files to be compressed
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{filename + ".1.gz", filename + ".2.gz"} {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := gzip.NewReader(f)
+		if err != nil {
+			f.Close()
+			t.Fatal(err)
+		}
+		if _, err := ioutil.ReadAll(r); err != nil {
+			t.Fatalf("Error decompressing %s: %v", name, err)
+		}
+		f.Close()
+	}
+	if _, err := os.Stat(filename + ".1"); !os.IsNotExist(err) {
+		t.Fatalf("Uncompressed rotated file was not removed: %v", err)
+	}
+
+	l, err = New(logger.Context{
+		ContainerID: cid,
+		LogPath:     filename,
+		Config:      config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	readLines := func(config logger.ReadConfig) []string {
+		watcher := l.(logger.LogReader).ReadLogs(config)
+		defer watcher.Close()
+		var lines []string
+		for msg := range watcher.Msg {
+			lines = append(lines, string(msg.Line))
+		}
+		return lines
+	}
+
+	lines := readLines(logger.ReadConfig{Tail: 20})
+	if len(lines) != 20 {
+		t.Fatalf("Wrong number of lines read: %d, expected 20", len(lines))
+	}
+	if lines[0] != "line16\n" || lines[19] != "line35\n" {
+		t.Fatalf("Wrong lines read: %q", lines)
+	}
+
+	lines = readLines(logger.ReadConfig{Tail: -1})
+	if len(lines) == 0 || lines[len(lines)-1] != "line35\n" {
+		t.Fatalf("Wrong lines read: %q", lines)
+	}
+
+	if _, err := New(logger.Context{
+		ContainerID: cid,
+		LogPath:     filename,
+		Config:      map[string]string{"compress": "true"},
+	}); err == nil {
+		t.Fatal("Expected an error for compress without max-file and max-size")
+	}
+}
+
+func TestJSONFileLoggerReadLogsUntilDetails(t *testing.T) {
+	cid := "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657"
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	l, err := New(logger.Context{
+		ContainerID:     cid,
+		LogPath:         filepath.Join(tmp, "container.log"),
+		Config:          map[string]string{"labels": "rack"},
+		ContainerLabels: map[string]string{"rack": "101"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	start := time.Unix(1470000000, 0).UTC()
+	for i := 0; i < 3; i++ {
+		msg := &logger.Message{Line: []byte("line" + strconv.Itoa(i)), Source: "src1", Timestamp: start.Add(time.Duration(i) * time.Second)}
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	readAll := func(config logger.ReadConfig) []*logger.Message {
+		watcher := l.(logger.LogReader).ReadLogs(config)
+		defer watcher.Close()
+		var msgs []*logger.Message
+		for {
+			select {
+			case err := <-watcher.Err:
+				t.Fatal(err)
+			case msg, ok := <-watcher.Msg:
+				if !ok {
+					return msgs
+				}
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+
+	msgs := readAll(logger.ReadConfig{Tail: -1, Until: start.Add(time.Second)})
+	if len(msgs) != 2 || string(msgs[1].Line) != "line1\n" {
+		t.Fatalf("Wrong messages read until %s: %v", start.Add(time.Second), msgs)
+	}
+	if msgs[0].Attrs != nil {
+		t.Fatalf("Attrs returned without details: %v", msgs[0].Attrs)
+	}
+
+	msgs = readAll(logger.ReadConfig{Tail: 1, Until: start.Add(time.Second)})
+	if len(msgs) != 1 || string(msgs[0].Line) != "line1\n" {
+		t.Fatalf("Wrong messages read with tail 1 until %s: %v", start.Add(time.Second), msgs)
+	}
+
+	msgs = readAll(logger.ReadConfig{Tail: -1, Details: true})
+	if len(msgs) != 3 {
+		t.Fatalf("Wrong number of messages read: %d, expected 3", len(msgs))
+	}
+	if !reflect.DeepEqual(map[string]string(msgs[0].Attrs), map[string]string{"rack": "101"}) {
+		t.Fatalf("Wrong log attrs: %v", msgs[0].Attrs)
+	}
+}
+
 func BenchmarkJSONFileLoggerWithReader(b *testing.B) {
 	b.StopTimer()
 	b.ResetTimer()
@@ -1,7 +1,9 @@
 package jsonfilelog
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,8 +12,8 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/loggerutils"
 	"github.com/docker/docker/pkg/filenotify"
-	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/jsonlog"
 	"github.com/docker/docker/pkg/tailfile"
 )
@@ -45,19 +47,6 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	defer close(logWatcher.Msg)
 
 	pth := l.writer.LogPath()
-	var files []io.ReadSeeker
-	for i := l.writer.MaxFiles(); i > 1; i-- {
-		f, err := os.Open(fmt.Sprintf("%s.%d", pth, i-1))
-		if err != nil {
-			if !os.IsNotExist(err) {
-				logWatcher.Err <- err
-				break
-			}
-			continue
-		}
-		files = append(files, f)
-	}
-
 	latestFile, err := os.Open(pth)
 	if err != nil {
 		logWatcher.Err <- err
@@ -65,15 +54,7 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	}
 
 	if config.Tail != 0 {
-		tailer := ioutils.MultiReadSeeker(append(files, latestFile)...)
-		tailFile(tailer, logWatcher, config.Tail, config.Since)
-	}
-
-	// close all the rotated files
-	for _, f := range files {
-		if err := f.(io.Closer).Close(); err != nil {
-			logrus.WithField("logger", "json-file").Warnf("error closing tailed log file: %v", err)
-		}
+		tailFiles(pth, l.writer.MaxFiles(), latestFile, logWatcher, config)
 	}
 
 	if !config.Follow {
@@ -92,7 +73,7 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	l.mu.Unlock()
 
 	notifyRotate := l.writer.NotifyRotate()
-	followLogs(latestFile, logWatcher, notifyRotate, config.Since)
+	followLogs(latestFile, logWatcher, notifyRotate, config)
 
 	l.mu.Lock()
 	delete(l.readers, logWatcher)
@@ -101,16 +82,162 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	l.writer.NotifyRotateEvict(notifyRotate)
 }
 
-func tailFile(f io.ReadSeeker, logWatcher *logger.LogWatcher, tail int, since time.Time) {
-	var rdr io.Reader = f
-	if tail > 0 {
-		ls, err := tailfile.TailFile(f, tail)
+// rotatedFile is a rotated log file opened for reading. Compressed files are
+// decompressed as they are read.
+type rotatedFile struct {
+	*os.File
+	gzipReader *gzip.Reader // nil if the file is not compressed
+}
+
+func (f *rotatedFile) Read(p []byte) (int, error) {
+	if f.gzipReader != nil {
+		return f.gzipReader.Read(p)
+	}
+	return f.File.Read(p)
+}
+
+func (f *rotatedFile) Close() error {
+	if f.gzipReader != nil {
+		f.gzipReader.Close()
+	}
+	return f.File.Close()
+}
+
+// tail returns the last n lines of the file. Compressed files cannot be read
+// backwards, so they are scanned up to their end.
+func (f *rotatedFile) tail(n int) ([][]byte, error) {
+	if f.gzipReader == nil {
+		return tailfile.TailFile(f.File, n)
+	}
+	var lines [][]byte
+	rdr := bufio.NewReader(f.gzipReader)
+	for {
+		line, err := rdr.ReadBytes('\n')
+		if len(line) > 0 {
+			lines = append(lines, bytes.TrimSuffix(line, []byte("\n")))
+			if len(lines) > n {
+				lines = lines[1:]
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				return lines, nil
+			}
+			return nil, err
+		}
+	}
+}
+
+// openRotatedFile opens the rotated log file at pth, preferring its compressed
+// version if there is one.
+func openRotatedFile(pth string) (*rotatedFile, error) {
+	compressed, err := os.Open(pth + loggerutils.CompressedExtension)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		f, err := os.Open(pth)
+		if err != nil {
+			return nil, err
+		}
+		return &rotatedFile{File: f}, nil
+	}
+
+	gzipReader, err := gzip.NewReader(compressed)
+	if err != nil {
+		compressed.Close()
+		return nil, fmt.Errorf("error reading compressed log file %s: %v", compressed.Name(), err)
+	}
+	return &rotatedFile{File: compressed, gzipReader: gzipReader}, nil
+}
+
+// tailFiles sends the messages of latestFile and of the rotated files of pth
+// selected by config. When only the last lines are requested, the rotated
+// files are opened from the most recent one, and only as far back as needed.
+func tailFiles(pth string, maxFiles int, latestFile *os.File, logWatcher *logger.LogWatcher, config logger.ReadConfig) {
+	if config.Tail > 0 && config.Until.IsZero() {
+		lines, err := tailFile(pth, maxFiles, latestFile, config.Tail)
 		if err != nil {
 			logWatcher.Err <- err
 			return
 		}
-		rdr = bytes.NewBuffer(bytes.Join(ls, []byte("\n")))
+		sendMessages(bytes.NewBuffer(bytes.Join(lines, []byte("\n"))), logWatcher, config)
+		return
 	}
+
+	var files []io.Reader
+	for i := maxFiles - 1; i > 0; i-- {
+		f, err := openRotatedFile(fmt.Sprintf("%s.%d", pth, i))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logWatcher.Err <- err
+				break
+			}
+			continue
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				logrus.WithField("logger", "json-file").Warnf("error closing tailed log file: %v", err)
+			}
+		}()
+		files = append(files, f)
+	}
+
+	rdr := io.MultiReader(append(files, latestFile)...)
+	if config.Tail > 0 {
+		tailFileUntil(rdr, logWatcher, config)
+		return
+	}
+	sendMessages(rdr, logWatcher, config)
+}
+
+// tailFile returns the last n lines of latestFile and the rotated files of
+// pth.
+func tailFile(pth string, maxFiles int, latestFile *os.File, n int) ([][]byte, error) {
+	lines, err := tailfile.TailFile(latestFile, n)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i < maxFiles && len(lines) < n; i++ {
+		f, err := openRotatedFile(fmt.Sprintf("%s.%d", pth, i))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		older, err := f.tail(n - len(lines))
+		if closeErr := f.Close(); closeErr != nil {
+			logrus.WithField("logger", "json-file").Warnf("error closing tailed log file: %v", closeErr)
+		}
+		if err != nil {
+			return nil, err
+		}
+		lines = append(older, lines...)
+	}
+	return lines, nil
+}
+
+// sendMessage reports whether msg is past the end of the time window of
+// config. Messages within the window are sent to logWatcher, stripped of their
+// attributes unless details are requested.
+func sendMessage(logWatcher *logger.LogWatcher, msg *logger.Message, config logger.ReadConfig) (done bool) {
+	if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
+		return false
+	}
+	if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
+		return true
+	}
+	if !config.Details {
+		msg.Attrs = nil
+	}
+	logWatcher.Msg <- msg
+	return false
+}
+
+// sendMessages sends the messages decoded from rdr that are within the time
+// window of config.
+func sendMessages(rdr io.Reader, logWatcher *logger.LogWatcher, config logger.ReadConfig) {
 	dec := json.NewDecoder(rdr)
 	l := &jsonlog.JSONLog{}
 	for {
@@ -121,14 +248,43 @@ func tailFile(f io.ReadSeeker, logWatcher *logger.LogWatcher, tail int, since ti
 			}
 			return
 		}
-		if !since.IsZero() && msg.Timestamp.Before(since) {
-			continue
+		if sendMessage(logWatcher, msg, config) {
+			return
 		}
-		logWatcher.Msg <- msg
 	}
 }
 
-func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan interface{}, since time.Time) {
+// tailFileUntil sends the last config.Tail messages of f that were logged
+// before config.Until. The whole file is scanned, as the cutoff has to be
+// applied before the tail is taken.
+func tailFileUntil(f io.Reader, logWatcher *logger.LogWatcher, config logger.ReadConfig) {
+	var msgs []*logger.Message
+	dec := json.NewDecoder(f)
+	for {
+		msg, err := decodeLogLine(dec, &jsonlog.JSONLog{})
+		if err != nil {
+			if err != io.EOF {
+				logWatcher.Err <- err
+				return
+			}
+			break
+		}
+		if msg.Timestamp.After(config.Until) {
+			break
+		}
+		msgs = append(msgs, msg)
+		if len(msgs) > config.Tail {
+			msgs = msgs[1:]
+		}
+	}
+	for _, msg := range msgs {
+		if sendMessage(logWatcher, msg, config) {
+			return
+		}
+	}
+}
+
+func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan interface{}, config logger.ReadConfig) {
 	dec := json.NewDecoder(f)
 	l := &jsonlog.JSONLog{}
 
@@ -154,6 +310,15 @@ func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan int
 		}
 	}
 
+	// stop following once the end of the time window is reached, even if
+	// nothing is logged anymore
+	var untilC <-chan time.Time
+	if !config.Until.IsZero() {
+		untilTimer := time.NewTimer(config.Until.Sub(time.Now()))
+		defer untilTimer.Stop()
+		untilC = untilTimer.C
+	}
+
 	var retries int
 	for {
 		msg, err := decodeLogLine(dec, l)
@@ -190,6 +355,9 @@ func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan int
 			case <-logWatcher.WatchClose():
 				fileWatcher.Remove(name)
 				return
+			case <-untilC:
+				fileWatcher.Remove(name)
+				return
 			case <-notifyRotate:
 				f.Close()
 				fileWatcher.Remove(name)
@@ -217,9 +385,15 @@ func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan int
 		}
 
 		retries = 0 // reset retries since we've succeeded
-		if !since.IsZero() && msg.Timestamp.Before(since) {
+		if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
 			continue
 		}
+		if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
+			return
+		}
+		if !config.Details {
+			msg.Attrs = nil
+		}
 		select {
 		case logWatcher.Msg <- msg:
 		case <-logWatcher.WatchClose():
@@ -229,10 +403,9 @@ func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan int
 				if err != nil {
 					return
 				}
-				if !since.IsZero() && msg.Timestamp.Before(since) {
-					continue
+				if sendMessage(logWatcher, msg, config) {
+					return
 				}
-				logWatcher.Msg <- msg
 			}
 		}
 	}
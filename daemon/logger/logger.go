@@ -68,8 +68,12 @@ type Logger interface {
 // ReadConfig is the configuration passed into ReadLogs.
 type ReadConfig struct {
 	Since  time.Time
+	Until  time.Time
 	Tail   int
 	Follow bool
+	// Details requests the extra attributes of the messages, if the logger
+	// stores them.
+	Details bool
 }
 
 // LogReader is the interface for reading log messages for loggers that support reading.
@@ -1,13 +1,19 @@
 package loggerutils
 
 import (
+	"compress/gzip"
+	"io"
 	"os"
 	"strconv"
 	"sync"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/pkg/pubsub"
 )
 
+// CompressedExtension is the file extension of compressed rotated log files.
+const CompressedExtension = ".gz"
+
 // RotateFileWriter is Logger implementation for default Docker logging.
 type RotateFileWriter struct {
 	f            *os.File // store for closing
@@ -15,11 +21,16 @@ type RotateFileWriter struct {
 	capacity     int64 //maximum size of each file
 	currentSize  int64 // current size of the latest file
 	maxFiles     int   //maximum number of files
+	compress     bool  // whether rotated files are compressed
 	notifyRotate *pubsub.Publisher
+
+	// compressing tracks the compression of the latest rotated file
+	compressing sync.WaitGroup
 }
 
-//NewRotateFileWriter creates new RotateFileWriter
-func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateFileWriter, error) {
+//NewRotateFileWriter creates new RotateFileWriter. If compress is set, the
+//rotated files are compressed with gzip.
+func NewRotateFileWriter(logPath string, capacity int64, maxFiles int, compress bool) (*RotateFileWriter, error) {
 	log, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
 	if err != nil {
 		return nil, err
@@ -35,6 +46,7 @@ func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateF
 		capacity:     capacity,
 		currentSize:  size,
 		maxFiles:     maxFiles,
+		compress:     compress,
 		notifyRotate: pubsub.NewPublisher(0, 1),
 	}, nil
 }
@@ -65,6 +77,8 @@ func (w *RotateFileWriter) checkCapacityAndRotate() error {
 		if err := w.f.Close(); err != nil {
 			return err
 		}
+		// the rotated file must not be renamed while it is being compressed
+		w.compressing.Wait()
 		if err := rotate(name, w.maxFiles); err != nil {
 			return err
 		}
@@ -75,29 +89,95 @@ func (w *RotateFileWriter) checkCapacityAndRotate() error {
 		w.f = file
 		w.currentSize = 0
 		w.notifyRotate.Publish(struct{}{})
+
+		if w.compress && w.maxFiles > 1 {
+			w.compressing.Add(1)
+			go func() {
+				defer w.compressing.Done()
+				if err := compressFile(name + ".1"); err != nil {
+					// keep the uncompressed file, it is still read back and
+					// rotated
+					logrus.Errorf("Error compressing rotated log file %s: %v", name+".1", err)
+				}
+			}()
+		}
 	}
 
 	return nil
 }
 
+// rotate shifts the rotated files of name, compressed or not, by one and
+// renames name to name.1. The oldest file is removed.
 func rotate(name string, maxFiles int) error {
 	if maxFiles < 2 {
 		return nil
 	}
+
 	for i := maxFiles - 1; i > 1; i-- {
-		toPath := name + "." + strconv.Itoa(i)
-		fromPath := name + "." + strconv.Itoa(i-1)
-		if err := os.Rename(fromPath, toPath); err != nil && !os.IsNotExist(err) {
-			return err
+		for _, extension := range []string{"", CompressedExtension} {
+			toPath := name + "." + strconv.Itoa(i) + extension
+			fromPath := name + "." + strconv.Itoa(i-1) + extension
+			if err := os.Remove(toPath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err := os.Rename(fromPath, toPath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
 		}
 	}
 
+	for _, extension := range []string{"", CompressedExtension} {
+		if err := os.Remove(name + ".1" + extension); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 	if err := os.Rename(name, name+".1"); err != nil && !os.IsNotExist(err) {
 		return err
 	}
 	return nil
 }
 
+// compressFile compresses the file at path with gzip, and replaces it with
+// the compressed file.
+func compressFile(path string) (retErr error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	tmpPath := path + CompressedExtension + ".tmp"
+	out, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0640)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		out.Close()
+		if retErr != nil {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	compressWriter := gzip.NewWriter(out)
+	if _, err := io.Copy(compressWriter, file); err != nil {
+		return err
+	}
+	if err := compressWriter.Close(); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path+CompressedExtension); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
 // LogPath returns the location the given writer logs to.
 func (w *RotateFileWriter) LogPath() string {
 	return w.f.Name()
@@ -108,6 +188,11 @@ func (w *RotateFileWriter) MaxFiles() int {
 	return w.maxFiles
 }
 
+// Compress returns whether the rotated files are compressed
+func (w *RotateFileWriter) Compress() bool {
+	return w.compress
+}
+
 //NotifyRotate returns the new subscriber
 func (w *RotateFileWriter) NotifyRotate() chan interface{} {
 	return w.notifyRotate.Subscribe()
@@ -118,7 +203,9 @@ func (w *RotateFileWriter) NotifyRotateEvict(sub chan interface{}) {
 	w.notifyRotate.Evict(sub)
 }
 
-// Close closes underlying file and signals all readers to stop.
+// Close closes underlying file and signals all readers to stop. It waits for
+// the compression of the latest rotated file to complete.
 func (w *RotateFileWriter) Close() error {
+	w.compressing.Wait()
 	return w.f.Close()
 }
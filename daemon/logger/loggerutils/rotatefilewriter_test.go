@@ -0,0 +1,49 @@
+package loggerutils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotateKeepsUncompressedFiles(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	name := filepath.Join(tmp, "container.log")
+
+	// a rotated file left uncompressed by a failed compression
+	if err := ioutil.WriteFile(name+".1", []byte("leftover\n"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewRotateFileWriter(name, 10, 3, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"0123456789\n", "rotated\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(name + ".2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "leftover\n" {
+		t.Fatalf("Wrong content of %s: %q", name+".2", b)
+	}
+	if _, err := os.Stat(name + ".1" + CompressedExtension); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(name + ".1"); !os.IsNotExist(err) {
+		t.Fatalf("Uncompressed rotated file was not removed: %v", err)
+	}
+}
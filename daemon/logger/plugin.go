@@ -0,0 +1,207 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/plugin"
+)
+
+const extName = "LogDriver"
+
+// PluginStreamRoot is the directory in which the streams of the logging
+// plugins are created. Managed plugins must mount it to be able to read the
+// messages sent by the daemon.
+const PluginStreamRoot = "/run/docker/logging"
+
+// logPlugin defines the available functions that logging plugins must implement.
+type logPlugin interface {
+	// StartLogging tells the plugin to start reading the messages of a
+	// container from the stream at the given path
+	StartLogging(streamPath string, info Context) (err error)
+	// StopLogging tells the plugin to stop reading from the given stream
+	StopLogging(streamPath string) (err error)
+	// Capabilities gets the list of capabilities of the plugin
+	Capabilities() (capability Capability, err error)
+	// ReadLogs returns a stream of the messages logged by a container
+	ReadLogs(info Context, config ReadConfig) (stream io.ReadCloser, err error)
+}
+
+// Capability defines the list of capabilities that a logging plugin can
+// implement.
+type Capability struct {
+	// ReadLogs is set if the plugin supports reading the logs back, so that
+	// `docker logs` works with the plugin.
+	ReadLogs bool
+}
+
+// PluginMessage is the record streamed to logging plugins for every message
+// logged by a container, and read back from them by `docker logs`. Messages
+// are sent as a stream of JSON objects.
+type PluginMessage struct {
+	Source   string
+	TimeNano int64
+	Line     []byte
+	Attrs    map[string]string `json:",omitempty"`
+}
+
+// pluginExists returns true if a logging plugin with the given name is
+// available. Unlike getPlugin, it doesn't wait for a plugin which cannot be
+// found yet.
+func pluginExists(name string) bool {
+	_, err := plugin.LookupWithCapabilityNoRetry(name, extName)
+	return err == nil
+}
+
+// getPlugin returns a logging driver builder for the logging plugin with the
+// given name.
+func getPlugin(name string) (Creator, error) {
+	p, err := plugin.LookupWithCapability(name, extName)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up logging plugin %s: %v", name, err)
+	}
+
+	return makePluginCreator(name, &logPluginProxy{p.Client()}), nil
+}
+
+func makePluginCreator(name string, l logPlugin) Creator {
+	return func(ctx Context) (Logger, error) {
+		if err := os.MkdirAll(PluginStreamRoot, 0700); err != nil {
+			return nil, err
+		}
+
+		a := &pluginAdapter{
+			driverName: name,
+			plugin:     l,
+			streamPath: filepath.Join(PluginStreamRoot, stringid.GenerateNonCryptoID()),
+			logInfo:    ctx,
+		}
+
+		capability, err := l.Capabilities()
+		if err != nil {
+			logrus.Debugf("logging plugin %s does not report its capabilities: %v", name, err)
+		}
+
+		stream, err := openPluginStream(a.streamPath)
+		if err != nil {
+			return nil, fmt.Errorf("error creating stream for logging plugin %s: %v", name, err)
+		}
+		a.stream = stream
+		a.enc = json.NewEncoder(stream)
+
+		if err := l.StartLogging(a.streamPath, ctx); err != nil {
+			a.closeStream()
+			return nil, fmt.Errorf("error starting logging plugin %s: %v", name, err)
+		}
+
+		if capability.ReadLogs {
+			return &pluginAdapterWithRead{a}, nil
+		}
+		return a, nil
+	}
+}
+
+// pluginAdapter is a Logger which sends the messages of a container to a
+// logging plugin.
+type pluginAdapter struct {
+	driverName string
+	plugin     logPlugin
+	streamPath string
+	logInfo    Context
+
+	mu     sync.Mutex
+	stream io.WriteCloser
+	enc    *json.Encoder
+}
+
+func (a *pluginAdapter) Log(msg *Message) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.enc.Encode(&PluginMessage{
+		Source:   msg.Source,
+		TimeNano: msg.Timestamp.UnixNano(),
+		Line:     msg.Line,
+		Attrs:    msg.Attrs,
+	})
+}
+
+func (a *pluginAdapter) Name() string {
+	return a.driverName
+}
+
+func (a *pluginAdapter) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	err := a.plugin.StopLogging(a.streamPath)
+	a.closeStream()
+	return err
+}
+
+func (a *pluginAdapter) closeStream() {
+	if err := a.stream.Close(); err != nil {
+		logrus.Errorf("error closing stream of logging plugin %s: %v", a.driverName, err)
+	}
+	if err := os.Remove(a.streamPath); err != nil && !os.IsNotExist(err) {
+		logrus.Errorf("error removing stream of logging plugin %s: %v", a.driverName, err)
+	}
+}
+
+// pluginAdapterWithRead is a pluginAdapter for plugins which support reading
+// the logs back.
+type pluginAdapterWithRead struct {
+	*pluginAdapter
+}
+
+func (a *pluginAdapterWithRead) ReadLogs(config ReadConfig) *LogWatcher {
+	watcher := NewLogWatcher()
+
+	go func() {
+		defer close(watcher.Msg)
+
+		stream, err := a.plugin.ReadLogs(a.logInfo, config)
+		if err != nil {
+			watcher.Err <- fmt.Errorf("error reading logs from logging plugin %s: %v", a.driverName, err)
+			return
+		}
+		defer stream.Close()
+
+		dec := json.NewDecoder(stream)
+		for {
+			var m PluginMessage
+			if err := dec.Decode(&m); err != nil {
+				if err != io.EOF {
+					watcher.Err <- fmt.Errorf("error decoding logs from logging plugin %s: %v", a.driverName, err)
+				}
+				return
+			}
+
+			msg := &Message{
+				Source:    m.Source,
+				Timestamp: time.Unix(0, m.TimeNano),
+				Line:      m.Line,
+				Attrs:     m.Attrs,
+			}
+			// the plugin should filter the messages, but make sure
+			if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
+				continue
+			}
+
+			select {
+			case watcher.Msg <- msg:
+			case <-watcher.WatchClose():
+				return
+			}
+		}
+	}()
+
+	return watcher
+}
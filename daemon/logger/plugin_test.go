@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+type fakeLogPlugin struct {
+	logs []byte
+}
+
+func (p *fakeLogPlugin) StartLogging(streamPath string, info Context) error { return nil }
+
+func (p *fakeLogPlugin) StopLogging(streamPath string) error { return nil }
+
+func (p *fakeLogPlugin) Capabilities() (Capability, error) {
+	return Capability{ReadLogs: true}, nil
+}
+
+func (p *fakeLogPlugin) ReadLogs(info Context, config ReadConfig) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(p.logs)), nil
+}
+
+func TestPluginAdapterLog(t *testing.T) {
+	var buf bytes.Buffer
+	a := &pluginAdapter{
+		driverName: "test",
+		plugin:     &fakeLogPlugin{},
+		stream:     nopWriteCloser{&buf},
+		enc:        json.NewEncoder(&buf),
+	}
+
+	ts := time.Unix(0, 1234567890)
+	if err := a.Log(&Message{Line: []byte("hello"), Source: "stdout", Timestamp: ts, Attrs: LogAttributes{"foo": "bar"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var m PluginMessage
+	if err := json.NewDecoder(&buf).Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	if string(m.Line) != "hello" || m.Source != "stdout" || m.TimeNano != ts.UnixNano() || m.Attrs["foo"] != "bar" {
+		t.Fatalf("unexpected message sent to the plugin: %+v", m)
+	}
+}
+
+func TestPluginAdapterReadLogs(t *testing.T) {
+	var logs bytes.Buffer
+	enc := json.NewEncoder(&logs)
+	for i, line := range []string{"one", "two", "three"} {
+		if err := enc.Encode(&PluginMessage{Source: "stdout", TimeNano: int64(i + 1), Line: []byte(line)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a := &pluginAdapterWithRead{&pluginAdapter{
+		driverName: "test",
+		plugin:     &fakeLogPlugin{logs: logs.Bytes()},
+	}}
+
+	watcher := a.ReadLogs(ReadConfig{Since: time.Unix(0, 2)})
+	defer watcher.Close()
+
+	var lines []string
+	for {
+		select {
+		case err := <-watcher.Err:
+			t.Fatal(err)
+		case msg, ok := <-watcher.Msg:
+			if !ok {
+				if len(lines) != 2 || lines[0] != "two" || lines[1] != "three" {
+					t.Fatalf("unexpected lines read from the plugin: %v", lines)
+				}
+				return
+			}
+			lines = append(lines, string(msg.Line))
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout reading logs from the plugin")
+		}
+	}
+}
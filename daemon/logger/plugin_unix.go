@@ -0,0 +1,19 @@
+// +build linux freebsd
+
+package logger
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+// openPluginStream creates the FIFO used to send messages to a logging
+// plugin and opens it. The FIFO is opened read-write so that opening it does
+// not block until the plugin opens it for reading.
+func openPluginStream(path string) (io.WriteCloser, error) {
+	if err := syscall.Mkfifo(path, 0700); err != nil && !os.IsExist(err) {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_RDWR, 0700)
+}
@@ -31,12 +31,19 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 		return fmt.Errorf("You must choose at least one stream")
 	}
 
+	if container.HostConfig.LogConfig.Type == "none" {
+		return logger.ErrReadLogsNotSupported
+	}
+
 	cLog, err := daemon.getLogger(container)
 	if err != nil {
 		return err
 	}
 	logReader, ok := cLog.(logger.LogReader)
 	if !ok {
+		if cLog != container.LogDriver {
+			cLog.Close()
+		}
 		return logger.ErrReadLogsNotSupported
 	}
 
@@ -56,10 +63,27 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 		}
 		since = time.Unix(s, n)
 	}
+
+	var until time.Time
+	if config.Until != "" {
+		s, n, err := timetypes.ParseTimestamps(config.Until, 0)
+		if err != nil {
+			return err
+		}
+		until = time.Unix(s, n)
+		if until.Before(time.Now()) {
+			// there is nothing to follow once the end of the window
+			// has passed
+			follow = false
+		}
+	}
+
 	readConfig := logger.ReadConfig{
-		Since:  since,
-		Tail:   tailLines,
-		Follow: follow,
+		Since:   since,
+		Until:   until,
+		Tail:    tailLines,
+		Follow:  follow,
+		Details: config.Details,
 	}
 	logs := logReader.ReadLogs(readConfig)
 
@@ -151,7 +175,7 @@ func (daemon *Daemon) mergeAndVerifyLogConfig(cfg *containertypes.LogConfig) err
 	}
 
 	if cfg.Type == daemon.defaultLogConfig.Type {
-		for k, v := range daemon.defaultLogConfig.Config {
+		for k, v := range daemon.defaultLogOpts() {
 			if _, ok := cfg.Config[k]; !ok {
 				cfg.Config[k] = v
 			}
@@ -160,3 +184,11 @@ func (daemon *Daemon) mergeAndVerifyLogConfig(cfg *containertypes.LogConfig) err
 
 	return logger.ValidateLogOpts(cfg.Type, cfg.Config)
 }
+
+// defaultLogOpts returns the options of the default log driver, which can be
+// replaced when the configuration is reloaded.
+func (daemon *Daemon) defaultLogOpts() map[string]string {
+	daemon.configStore.reloadLock.Lock()
+	defer daemon.configStore.reloadLock.Unlock()
+	return daemon.defaultLogConfig.Config
+}
@@ -0,0 +1,53 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "engine"
+
+var (
+	containerActions = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "daemon",
+		Name:      "container_actions_seconds",
+		Help:      "The number of seconds it takes to process each container action",
+	}, []string{"action"})
+
+	imageActions = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "daemon",
+		Name:      "image_actions_seconds",
+		Help:      "The number of seconds it takes to process each image action",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, []string{"action"})
+
+	healthChecks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "daemon",
+		Name:      "health_checks_total",
+		Help:      "The number of health checks run, by result",
+	}, []string{"result"})
+)
+
+func init() {
+	for _, a := range []string{"create", "start", "stop", "delete"} {
+		containerActions.WithLabelValues(a)
+	}
+	imageActions.WithLabelValues("pull")
+	for _, r := range []string{"success", "failure"} {
+		healthChecks.WithLabelValues(r)
+	}
+
+	prometheus.MustRegister(containerActions)
+	prometheus.MustRegister(imageActions)
+	prometheus.MustRegister(healthChecks)
+}
+
+// observeSince records the time elapsed since start for the action in the
+// histogram h.
+func observeSince(h *prometheus.HistogramVec, action string, start time.Time) {
+	h.WithLabelValues(action).Observe(time.Since(start).Seconds())
+}
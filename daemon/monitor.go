@@ -86,7 +86,7 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 			return err
 		}
 		daemon.initHealthMonitor(c)
-		daemon.LogContainerEvent(c, "start")
+		daemon.LogContainerEventWithAttributes(c, "start", daemon.startEventAttributes(c.ID))
 	case libcontainerd.StatePause:
 		// Container is already locked in this case
 		c.Paused = true
@@ -657,11 +657,22 @@ func (daemon *Daemon) createSpec(c *container.Container) (*libcontainerd.Spec, e
 		return nil, err
 	}
 
+	if err := daemon.setupSecretDir(c); err != nil {
+		return nil, err
+	}
+
 	ms, err := daemon.setupMounts(c)
 	if err != nil {
 		return nil, err
 	}
 	ms = append(ms, c.IpcMounts()...)
+	secretMount, err := c.SecretMount()
+	if err != nil {
+		return nil, err
+	}
+	if secretMount != nil {
+		ms = append(ms, *secretMount)
+	}
 	ms = append(ms, c.TmpfsMounts()...)
 	sort.Sort(mounts(ms))
 	if err := setMounts(daemon, &s, c, ms); err != nil {
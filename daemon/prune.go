@@ -0,0 +1,334 @@
+package daemon
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/pkg/directory"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/runconfig"
+	"github.com/docker/docker/volume"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/engine-api/types/filters"
+	timetypes "github.com/docker/engine-api/types/time"
+)
+
+var (
+	containersAcceptedFilters = map[string]bool{
+		"label": true,
+		"until": true,
+	}
+	volumesAcceptedFilters = map[string]bool{
+		"label": true,
+		"until": true,
+	}
+	imagesAcceptedFilters = map[string]bool{
+		"dangling": true,
+		"label":    true,
+		"until":    true,
+	}
+	networksAcceptedFilters = map[string]bool{
+		"label": true,
+	}
+)
+
+// startPrune marks a prune operation as running and returns an error if
+// another one is already in progress. The returned function must be called
+// once the operation completes.
+func (daemon *Daemon) startPrune() (func(), error) {
+	if !atomic.CompareAndSwapInt32(&daemon.pruneRunning, 0, 1) {
+		return nil, fmt.Errorf("a prune operation is already running")
+	}
+	return func() { atomic.StoreInt32(&daemon.pruneRunning, 0) }, nil
+}
+
+// ContainersPrune removes unused containers
+func (daemon *Daemon) ContainersPrune(pruneFilters filters.Args) (*types.ContainersPruneReport, error) {
+	done, err := daemon.startPrune()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	if err := pruneFilters.Validate(containersAcceptedFilters); err != nil {
+		return nil, err
+	}
+	until, err := getUntilFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := &types.ContainersPruneReport{}
+	for _, c := range daemon.List() {
+		if c.IsRunning() {
+			continue
+		}
+		if !until.IsZero() && c.Created.After(until) {
+			continue
+		}
+		if !pruneFilters.MatchKVList("label", c.Config.Labels) {
+			continue
+		}
+		cSize, _ := daemon.getSize(c)
+		if err := daemon.ContainerRm(context.Background(), c.ID, &types.ContainerRmConfig{}); err != nil {
+			logrus.Warnf("failed to prune container %s: %v", c.ID, err)
+			continue
+		}
+		if cSize > 0 {
+			rep.SpaceReclaimed += uint64(cSize)
+		}
+		rep.ContainersDeleted = append(rep.ContainersDeleted, c.ID)
+	}
+
+	return rep, nil
+}
+
+// VolumesPrune removes unused local volumes
+func (daemon *Daemon) VolumesPrune(pruneFilters filters.Args) (*types.VolumesPruneReport, error) {
+	done, err := daemon.startPrune()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	if err := pruneFilters.Validate(volumesAcceptedFilters); err != nil {
+		return nil, err
+	}
+	until, err := getUntilFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	vols, warnings, err := daemon.volumes.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warnings {
+		logrus.Warnf("volume prune: %s", w)
+	}
+
+	rep := &types.VolumesPruneReport{}
+	for _, v := range daemon.volumes.FilterByUsed(vols, false) {
+		var labels map[string]string
+		if lv, ok := v.(volume.LabeledVolume); ok {
+			labels = lv.Labels()
+		}
+		if !pruneFilters.MatchKVList("label", labels) {
+			continue
+		}
+		if !until.IsZero() && !volumeCreatedBefore(v, until) {
+			continue
+		}
+
+		var vSize int64
+		if v.DriverName() == volume.DefaultDriverName {
+			vSize, err = directory.Size(v.Path())
+			if err != nil {
+				logrus.Warnf("could not determine size of volume %s: %v", v.Name(), err)
+			}
+		}
+		if err := daemon.VolumeRm(v.Name()); err != nil {
+			logrus.Warnf("failed to prune volume %s: %v", v.Name(), err)
+			continue
+		}
+		if vSize > 0 {
+			rep.SpaceReclaimed += uint64(vSize)
+		}
+		rep.VolumesDeleted = append(rep.VolumesDeleted, v.Name())
+	}
+
+	return rep, nil
+}
+
+// ImagesPrune removes unused images. Only dangling images are removed unless
+// the "dangling" filter is set to false, in which case every image that is
+// not used by a container is removed.
+func (daemon *Daemon) ImagesPrune(pruneFilters filters.Args) (*types.ImagesPruneReport, error) {
+	done, err := daemon.startPrune()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	if err := pruneFilters.Validate(imagesAcceptedFilters); err != nil {
+		return nil, err
+	}
+
+	danglingOnly := true
+	if pruneFilters.Include("dangling") {
+		if pruneFilters.ExactMatch("dangling", "false") || pruneFilters.ExactMatch("dangling", "0") {
+			danglingOnly = false
+		} else if !pruneFilters.ExactMatch("dangling", "true") && !pruneFilters.ExactMatch("dangling", "1") {
+			return nil, fmt.Errorf("Invalid filter 'dangling=%s'", pruneFilters.Get("dangling"))
+		}
+	}
+	until, err := getUntilFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	var allImages map[image.ID]*image.Image
+	if danglingOnly {
+		allImages = daemon.imageStore.Heads()
+	} else {
+		allImages = daemon.imageStore.Map()
+	}
+
+	usedImages := map[image.ID]bool{}
+	for _, c := range daemon.List() {
+		usedImages[c.ImageID] = true
+	}
+
+	layersBefore := daemon.layerStore.Map()
+
+	rep := &types.ImagesPruneReport{}
+	for id, img := range allImages {
+		if usedImages[id] {
+			continue
+		}
+		refs := daemon.referenceStore.References(id)
+		// intermediate images are removed together with their children
+		if len(refs) == 0 && len(daemon.imageStore.Children(id)) != 0 {
+			continue
+		}
+		if !until.IsZero() && img.Created.After(until) {
+			continue
+		}
+		var labels map[string]string
+		if img.Config != nil {
+			labels = img.Config.Labels
+		}
+		if !pruneFilters.MatchKVList("label", labels) {
+			continue
+		}
+
+		if len(refs) == 0 {
+			deleted, err := daemon.ImageDelete(id.String(), false, true)
+			if err != nil {
+				logrus.Warnf("failed to prune image %s: %v", id, err)
+				continue
+			}
+			rep.ImagesDeleted = append(rep.ImagesDeleted, deleted...)
+			continue
+		}
+
+		if danglingOnly && hasTag(refs) {
+			continue
+		}
+		for _, ref := range refs {
+			deleted, err := daemon.ImageDelete(ref.String(), false, true)
+			if err != nil {
+				logrus.Warnf("failed to prune image %s: %v", ref, err)
+				continue
+			}
+			rep.ImagesDeleted = append(rep.ImagesDeleted, deleted...)
+		}
+	}
+
+	// the reclaimed space is the size of the layers that are gone
+	layersAfter := daemon.layerStore.Map()
+	for chainID, l := range layersBefore {
+		if _, ok := layersAfter[chainID]; ok {
+			continue
+		}
+		diffSize, err := l.DiffSize()
+		if err != nil {
+			logrus.Warnf("failed to get size of layer %s: %v", chainID, err)
+			continue
+		}
+		rep.SpaceReclaimed += uint64(diffSize)
+	}
+
+	return rep, nil
+}
+
+// NetworksPrune removes unused local networks
+func (daemon *Daemon) NetworksPrune(pruneFilters filters.Args) (*types.NetworksPruneReport, error) {
+	done, err := daemon.startPrune()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	// networks do not record their creation time, so none of them could be
+	// matched by an until filter
+	if pruneFilters.Include("until") {
+		return nil, fmt.Errorf("the until filter is not supported when pruning networks")
+	}
+	if err := pruneFilters.Validate(networksAcceptedFilters); err != nil {
+		return nil, err
+	}
+
+	rep := &types.NetworksPruneReport{}
+	for _, nw := range daemon.GetNetworks() {
+		if runconfig.IsPreDefinedNetwork(nw.Name()) || nw.Info().Dynamic() {
+			continue
+		}
+		if len(nw.Endpoints()) > 0 {
+			continue
+		}
+		if !pruneFilters.MatchKVList("label", nw.Info().Labels()) {
+			continue
+		}
+		if err := daemon.DeleteNetwork(nw.ID()); err != nil {
+			logrus.Warnf("failed to prune network %s: %v", nw.Name(), err)
+			continue
+		}
+		rep.NetworksDeleted = append(rep.NetworksDeleted, nw.Name())
+	}
+
+	return rep, nil
+}
+
+// hasTag returns true if any of refs is a tag rather than a digest.
+func hasTag(refs []reference.Named) bool {
+	for _, ref := range refs {
+		if _, ok := ref.(reference.NamedTagged); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// volumeCreatedBefore returns true if v is known to have been created before
+// until. Volumes whose driver does not report a creation time are kept.
+func volumeCreatedBefore(v volume.Volume, until time.Time) bool {
+	cv, ok := v.(volume.CreatedVolume)
+	if !ok {
+		return false
+	}
+	created, err := cv.CreatedAt()
+	if err != nil {
+		logrus.Debugf("could not determine creation time of volume %s: %v", v.Name(), err)
+		return false
+	}
+	return created.Before(until)
+}
+
+// getUntilFromPruneFilters returns the time given by the "until" filter, or
+// the zero time if the filter is not set.
+func getUntilFromPruneFilters(pruneFilters filters.Args) (time.Time, error) {
+	until := time.Time{}
+	if !pruneFilters.Include("until") {
+		return until, nil
+	}
+	untilFilters := pruneFilters.Get("until")
+	if len(untilFilters) > 1 {
+		return until, fmt.Errorf("more than one until filter specified")
+	}
+	ts, err := timetypes.GetTimestamp(untilFilters[0], time.Now())
+	if err != nil {
+		return until, err
+	}
+	seconds, nanoseconds, err := timetypes.ParseTimestamps(ts, 0)
+	if err != nil {
+		return until, err
+	}
+	until = time.Unix(seconds, nanoseconds)
+	return until, nil
+}
@@ -0,0 +1,51 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	volumetestutils "github.com/docker/docker/volume/testutils"
+	"github.com/docker/engine-api/types/filters"
+)
+
+func TestGetUntilFromPruneFilters(t *testing.T) {
+	args := filters.NewArgs()
+	until, err := getUntilFromPruneFilters(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !until.IsZero() {
+		t.Fatalf("Expected zero time without until filter, got %v", until)
+	}
+
+	args.Add("until", "1470000000")
+	until, err = getUntilFromPruneFilters(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := time.Unix(1470000000, 0); !until.Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, until)
+	}
+
+	args.Add("until", "1h")
+	if _, err := getUntilFromPruneFilters(args); err == nil {
+		t.Fatal("Expected an error with more than one until filter")
+	}
+}
+
+func TestVolumeCreatedBeforeWithoutCreationTime(t *testing.T) {
+	v := volumetestutils.NewFakeVolume("fake", "fake")
+	if volumeCreatedBefore(v, time.Now()) {
+		t.Fatal("Expected a volume without a creation time to be kept")
+	}
+}
+
+func TestNetworksPruneRejectsUntilFilter(t *testing.T) {
+	args := filters.NewArgs()
+	args.Add("until", "1h")
+	daemon := &Daemon{}
+	if _, err := daemon.NetworksPrune(args); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Fatalf("Expected the until filter to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package daemon
+
+import (
+	clustertypes "github.com/docker/docker/daemon/cluster/provider"
+)
+
+// SetContainerSecrets sets the secrets which are made available to the
+// container when it is started. The secrets are only kept in memory.
+func (daemon *Daemon) SetContainerSecrets(name string, secrets []*clustertypes.Secret) error {
+	c, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	c.Secrets = secrets
+	c.Unlock()
+
+	return nil
+}
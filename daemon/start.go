@@ -6,7 +6,9 @@ import (
 	"runtime"
 	"strings"
 	"syscall"
+	"time"
 
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 
 	"github.com/Sirupsen/logrus"
@@ -14,16 +16,37 @@ import (
 	"github.com/docker/docker/errors"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/runconfig"
+	"github.com/docker/docker/utils"
 	containertypes "github.com/docker/engine-api/types/container"
 )
 
-// ContainerStart starts a container.
-func (daemon *Daemon) ContainerStart(name string, hostConfig *containertypes.HostConfig) error {
+// ContainerStart starts a container, restoring it from checkpoint if it is
+// set. The checkpoint is looked up in checkpointDir, or in the container's
+// checkpoint directory if checkpointDir is empty. The start event is
+// attributed to the user that made the request of ctx, if any.
+func (daemon *Daemon) ContainerStart(ctx context.Context, name string, hostConfig *containertypes.HostConfig, checkpoint string, checkpointDir string) error {
+	if checkpoint != "" {
+		// the name is joined with the container's checkpoint directory
+		if err := validateCheckpointName(checkpoint); err != nil {
+			return err
+		}
+		if !utils.ExperimentalBuild() {
+			return errors.NewBadRequestError(fmt.Errorf("checkpoint is only supported in experimental mode"))
+		}
+	}
+
 	container, err := daemon.GetContainer(name)
 	if err != nil {
 		return err
 	}
 
+	if checkpoint != "" {
+		checkpointDir, err = getCheckpointDir(container, checkpointDir)
+		if err != nil {
+			return err
+		}
+	}
+
 	if container.IsPaused() {
 		return fmt.Errorf("Cannot start a paused container, try unpause instead.")
 	}
@@ -77,19 +100,23 @@ func (daemon *Daemon) ContainerStart(name string, hostConfig *containertypes.Hos
 		return err
 	}
 
-	return daemon.containerStart(container)
+	return daemon.containerStart(container, checkpoint, checkpointDir, identityAttributes(ctx))
 }
 
 // Start starts a container
 func (daemon *Daemon) Start(container *container.Container) error {
-	return daemon.containerStart(container)
+	return daemon.containerStart(container, "", "", map[string]string{})
 }
 
 // containerStart prepares the container to run by setting up everything the
 // container needs, such as storage and networking, as well as links
 // between containers. The container is left waiting for a signal to
-// begin running.
-func (daemon *Daemon) containerStart(container *container.Container) (err error) {
+// begin running. If checkpoint is set, the container is restored from the
+// checkpoint of that name stored in checkpointDir, or in the container's
+// checkpoint directory if checkpointDir is empty. The attributes are added
+// to the start event.
+func (daemon *Daemon) containerStart(container *container.Container, checkpoint string, checkpointDir string, attributes map[string]string) (err error) {
+	start := time.Now()
 	container.Lock()
 	defer container.Unlock()
 
@@ -141,7 +168,16 @@ func (daemon *Daemon) containerStart(container *container.Container) (err error)
 		createOptions = append(createOptions, *copts...)
 	}
 
-	if err := daemon.containerd.Create(container.ID, *spec, createOptions...); err != nil {
+	// The start event is logged by StateChanged while the container is
+	// created.
+	daemon.setStartAttributes(container.ID, attributes)
+	defer daemon.setStartAttributes(container.ID, nil)
+
+	if checkpointDir == "" {
+		checkpointDir = container.CheckpointDir()
+	}
+
+	if err := daemon.containerd.Create(container.ID, checkpoint, checkpointDir, *spec, createOptions...); err != nil {
 		errDesc := grpc.ErrorDesc(err)
 		logrus.Errorf("Create container failed with error: %s", errDesc)
 		// if we receive an internal error from the initial start of a container then lets
@@ -163,6 +199,8 @@ func (daemon *Daemon) containerStart(container *container.Container) (err error)
 		return fmt.Errorf("%s", errDesc)
 	}
 
+	observeSince(containerActions, "start", start)
+
 	return nil
 }
 
@@ -173,6 +211,10 @@ func (daemon *Daemon) Cleanup(container *container.Container) {
 
 	container.UnmountIpcMounts(detachMounted)
 
+	if err := container.UnmountSecrets(detachMounted); err != nil {
+		logrus.Warnf("%s cleanup: failed to unmount secrets: %s", container.ID, err)
+	}
+
 	if err := daemon.conditionalUnmountOnCleanup(container); err != nil {
 		// FIXME: remove once reference counting for graphdrivers has been refactored
 		// Ensure that all the mounts are gone
@@ -192,3 +234,32 @@ func (daemon *Daemon) Cleanup(container *container.Container) {
 	}
 	container.CancelAttachContext()
 }
+
+// setStartAttributes sets the attributes of the start event of the container
+// id, or removes them if attributes is empty.
+func (daemon *Daemon) setStartAttributes(id string, attributes map[string]string) {
+	daemon.startAttributesMu.Lock()
+	defer daemon.startAttributesMu.Unlock()
+
+	if len(attributes) == 0 {
+		delete(daemon.startAttributes, id)
+		return
+	}
+	if daemon.startAttributes == nil {
+		daemon.startAttributes = make(map[string]map[string]string)
+	}
+	daemon.startAttributes[id] = attributes
+}
+
+// startEventAttributes returns the attributes of the start event of the
+// container id.
+func (daemon *Daemon) startEventAttributes(id string) map[string]string {
+	daemon.startAttributesMu.Lock()
+	defer daemon.startAttributesMu.Unlock()
+
+	attributes := map[string]string{}
+	for k, v := range daemon.startAttributes[id] {
+		attributes[k] = v
+	}
+	return attributes
+}
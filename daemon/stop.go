@@ -40,14 +40,15 @@ func (daemon *Daemon) containerStop(container *container.Container, seconds int)
 	if !container.IsRunning() {
 		return nil
 	}
+	start := time.Now()
 
 	daemon.stopHealthchecks(container)
 
 	stopSignal := container.StopSignal()
 	// 1. Send a stop signal
-	if err := daemon.killPossiblyDeadProcess(container, stopSignal); err != nil {
+	if err := daemon.killPossiblyDeadProcess(container, stopSignal, map[string]string{}); err != nil {
 		logrus.Infof("Failed to send signal %d to the process, force killing", stopSignal)
-		if err := daemon.killPossiblyDeadProcess(container, 9); err != nil {
+		if err := daemon.killPossiblyDeadProcess(container, 9, map[string]string{}); err != nil {
 			return err
 		}
 	}
@@ -63,5 +64,6 @@ func (daemon *Daemon) containerStop(container *container.Container, seconds int)
 	}
 
 	daemon.LogContainerEvent(container, "stop")
+	observeSince(containerActions, "stop", start)
 	return nil
 }
@@ -61,6 +61,10 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 		return errCannotUpdate(container.ID, fmt.Errorf("Container is marked for removal and cannot be \"update\"."))
 	}
 
+	if err := verifyRestartPolicyHealthcheck(container.Config, hostConfig.RestartPolicy); err != nil {
+		return errCannotUpdate(container.ID, err)
+	}
+
 	if container.IsRunning() && hostConfig.KernelMemory != 0 {
 		return errCannotUpdate(container.ID, fmt.Errorf("Can not update kernel memory to a running container, please stop it first."))
 	}
@@ -53,18 +53,19 @@ func shouldV2Fallback(err errcode.Error) bool {
 }
 
 // continueOnError returns true if we should fallback to the next endpoint
-// as a result of this error.
-func continueOnError(err error) bool {
+// as a result of this error. Any registry error returned by a mirror is a
+// reason to fall back, since the mirror may not have the content.
+func continueOnError(err error, mirrorEndpoint bool) bool {
 	switch v := err.(type) {
 	case errcode.Errors:
 		if len(v) == 0 {
 			return true
 		}
-		return continueOnError(v[0])
+		return continueOnError(v[0], mirrorEndpoint)
 	case ErrNoSupport:
-		return continueOnError(v.Err)
+		return continueOnError(v.Err, mirrorEndpoint)
 	case errcode.Error:
-		return shouldV2Fallback(v)
+		return mirrorEndpoint || shouldV2Fallback(v)
 	case *client.UnexpectedHTTPResponseError:
 		return true
 	case ImageConfigPullError:
@@ -68,7 +68,7 @@ func (p *v2Puller) Pull(ctx context.Context, ref reference.Named) (err error) {
 		if _, ok := err.(fallbackError); ok {
 			return err
 		}
-		if continueOnError(err) {
+		if continueOnError(err, p.endpoint.Mirror) {
 			logrus.Errorf("Error trying v2 registry: %v", err)
 			return fallbackError{
 				err:         err,
@@ -139,6 +139,16 @@ clone git github.com/docker/docker-credential-helpers v0.3.0
 clone git github.com/docker/containerd b93a33be39bc4ef0fb00bfcb79147a28c33d9d43
 
 # cluster
+# vendor/src/github.com/docker/swarmkit carries changes that are not in this
+# revision yet and are lost when it is revendored. Bump the pin once they are
+# merged upstream:
+# - secrets: the api/*.proto messages, manager/controlapi/secret.go,
+#   manager/state/store/secrets.go and their dispatcher and agent plumbing
+# - update failure actions and rollback: the UpdateConfig and UpdateStatus
+#   messages, manager/controlapi/service.go and
+#   manager/orchestrator/updater.go, with service_test.go and updater_test.go
+# - service logs: api/logbroker.proto, manager/logbroker and the log
+#   publisher of the agent
 clone git github.com/docker/swarmkit 3f135f206179ea157aeef2d1d401eb795f618da8
 clone git github.com/golang/mock bd3c8e81be01eef76d4b503f5e687d2d1354d2d9
 clone git github.com/gogo/protobuf 43a2e0b1c32252bfbbdf81f7faa7a88fb3fa4028
@@ -164,5 +174,6 @@ clone git github.com/pkg/errors 01fa4104b9c248c8945d14d9f128454d5b28d595
 clone git github.com/spf13/cobra 75205f23b3ea70dc7ae5e900d074e010c23c37e9 https://github.com/dnephin/cobra.git
 clone git github.com/spf13/pflag cb88ea77998c3f024757528e3305022ab50b43be
 clone git github.com/inconshreveable/mousetrap 76626ae9c91c4f2a10f34cad8ce83ea42c93bb75
+clone git gopkg.in/yaml.v2 v2.4.0
 
 clean
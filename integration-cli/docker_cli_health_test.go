@@ -150,3 +150,48 @@ func (s *DockerSuite) TestHealth(c *check.C) {
 	c.Check(last.Output, checker.Equals, "Health check exceeded timeout (1ms)")
 	dockerCmd(c, "rm", "-f", "test")
 }
+
+func (s *DockerSuite) TestHealthHTTPAndTCP(c *check.C) {
+	testRequires(c, DaemonIsLinux) // busybox doesn't work on Windows
+
+	imageName := "testhealthhttp"
+	_, err := buildImage(imageName,
+		`FROM busybox
+		RUN mkdir /www && echo OK > /www/status
+		CMD ["httpd", "-f", "-p", "8080", "-h", "/www"]
+		STOPSIGNAL SIGKILL
+		HEALTHCHECK --interval=1s --timeout=30s \
+		  HTTP http://localhost:8080/status`,
+		true)
+	c.Check(err, check.IsNil)
+
+	out, _ := dockerCmd(c, "inspect", "--format={{.Config.Healthcheck.Test}}", imageName)
+	c.Check(out, checker.Equals, "[HTTP http://localhost:8080/status]\n")
+
+	// The port is not published, the check runs in the container's network namespace
+	name := "test_health_http"
+	dockerCmd(c, "run", "-d", "--name", name, imageName)
+	waitForHealthStatus(c, name, "starting", "healthy")
+	health := getHealth(c, name)
+	last := health.Log[len(health.Log)-1]
+	c.Check(last.ExitCode, checker.Equals, 0)
+	c.Check(last.Output, checker.Equals, "200 OK")
+
+	// A 404 is unhealthy
+	dockerCmd(c, "exec", name, "rm", "/www/status")
+	waitForHealthStatus(c, name, "healthy", "unhealthy")
+	dockerCmd(c, "rm", "-f", name)
+
+	// TCP check from the CLI
+	name = "test_health_tcp"
+	dockerCmd(c, "run", "-d", "--name", name,
+		"--health-interval=1s",
+		"--health-retries=1",
+		"--health-tcp=localhost:8080",
+		imageName)
+	waitForHealthStatus(c, name, "starting", "healthy")
+
+	out, _ = dockerCmd(c, "inspect", "--format={{.Config.Healthcheck.Test}}", name)
+	c.Check(out, checker.Equals, "[TCP localhost:8080]\n")
+	dockerCmd(c, "rm", "-f", name)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/ioutil"
 	"sync"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution"
@@ -218,10 +219,12 @@ func (ls *layerStore) applyTar(tx MetadataTransaction, ts io.Reader, parent stri
 		return err
 	}
 
+	start := time.Now()
 	applySize, err := ls.driver.ApplyDiff(layer.cacheID, parent, archive.Reader(rdr))
 	if err != nil {
 		return err
 	}
+	ls.observeDriverAction("applydiff", start)
 
 	// Discard trailing data but ensure metadata is picked up to reconstruct stream
 	io.Copy(ioutil.Discard, rdr) // ignore error as reader may be closed
@@ -275,9 +278,11 @@ func (ls *layerStore) registerWithDescriptor(ts io.Reader, parent ChainID, descr
 		descriptor:     descriptor,
 	}
 
+	start := time.Now()
 	if err = ls.driver.Create(layer.cacheID, pid, "", nil); err != nil {
 		return nil, err
 	}
+	ls.observeDriverAction("create", start)
 
 	tx, err := ls.store.StartTransaction()
 	if err != nil {
@@ -357,11 +362,29 @@ func (ls *layerStore) Get(l ChainID) (Layer, error) {
 	return layer.getReference(), nil
 }
 
+// Map returns all the read-only layers in the store keyed by their chain ID.
+// No references are taken on the returned layers, so they must not be
+// released by the caller.
+func (ls *layerStore) Map() map[ChainID]Layer {
+	ls.layerL.Lock()
+	defer ls.layerL.Unlock()
+
+	layers := map[ChainID]Layer{}
+
+	for k, v := range ls.layerMap {
+		layers[k] = v
+	}
+
+	return layers
+}
+
 func (ls *layerStore) deleteLayer(layer *roLayer, metadata *Metadata) error {
+	start := time.Now()
 	err := ls.driver.Remove(layer.cacheID)
 	if err != nil {
 		return err
 	}
+	ls.observeDriverAction("remove", start)
 
 	err = ls.store.Remove(layer.chainID)
 	if err != nil {
@@ -473,9 +496,11 @@ func (ls *layerStore) CreateRWLayer(name string, parent ChainID, mountLabel stri
 		m.initID = pid
 	}
 
+	start := time.Now()
 	if err = ls.driver.CreateReadWrite(m.mountID, pid, "", storageOpt); err != nil {
 		return nil, err
 	}
+	ls.observeDriverAction("create_rw", start)
 
 	if err = ls.saveMount(m); err != nil {
 		return nil, err
@@ -523,11 +548,13 @@ func (ls *layerStore) ReleaseRWLayer(l RWLayer) ([]Metadata, error) {
 		return []Metadata{}, nil
 	}
 
+	start := time.Now()
 	if err := ls.driver.Remove(m.mountID); err != nil {
 		logrus.Errorf("Error removing mounted layer %s: %s", m.name, err)
 		m.retakeReference(l)
 		return nil, err
 	}
+	ls.observeDriverAction("remove", start)
 
 	if m.initID != "" {
 		if err := ls.driver.Remove(m.initID); err != nil {
@@ -353,6 +353,43 @@ func TestLayerRelease(t *testing.T) {
 	releaseAndCheckDeleted(t, ls, layer3a, layer3a, layer2, layer1)
 }
 
+func TestStoreMap(t *testing.T) {
+	// TODO Windows: Figure out why this is failing
+	if runtime.GOOS == "windows" {
+		t.Skip("Failing on Windows")
+	}
+	ls, _, cleanup := newTestStore(t)
+	defer cleanup()
+
+	layer1, err := createLayer(ls, "", initWithFiles(newTestFile("layer1.txt", []byte("layer 1 file"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layer2, err := createLayer(ls, layer1.ChainID(), initWithFiles(newTestFile("layer2.txt", []byte("layer 2 file"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layers := ls.Map()
+	if expected := 2; len(layers) != expected {
+		t.Fatalf("Unexpected number of layers %d, expected %d", len(layers), expected)
+	}
+	for _, l := range []Layer{layer1, layer2} {
+		if _, ok := layers[l.ChainID()]; !ok {
+			t.Fatalf("Layer %s missing from map", l.ChainID())
+		}
+	}
+
+	// Map does not take references, so releasing the layers removes them
+	releaseAndCheckDeleted(t, ls, layer2, layer2)
+	releaseAndCheckDeleted(t, ls, layer1, layer1)
+
+	if layers := ls.Map(); len(layers) != 0 {
+		t.Fatalf("Unexpected number of layers %d after release, expected 0", len(layers))
+	}
+}
+
 func TestStoreRestore(t *testing.T) {
 	// TODO Windows: Figure out why this is failing
 	if runtime.GOOS == "windows" {
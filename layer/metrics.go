@@ -0,0 +1,24 @@
+package layer
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var graphDriverActions = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "engine",
+	Subsystem: "daemon",
+	Name:      "graphdriver_actions_seconds",
+	Help:      "The number of seconds it takes to process each graph driver action",
+}, []string{"driver", "action"})
+
+func init() {
+	prometheus.MustRegister(graphDriverActions)
+}
+
+// observeDriverAction records the time elapsed since start for the graph
+// driver action.
+func (ls *layerStore) observeDriverAction(action string, start time.Time) {
+	graphDriverActions.WithLabelValues(ls.driver.String(), action).Observe(time.Since(start).Seconds())
+}
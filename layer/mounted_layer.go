@@ -2,6 +2,7 @@ package layer
 
 import (
 	"io"
+	"time"
 
 	"github.com/docker/docker/pkg/archive"
 )
@@ -93,11 +94,22 @@ type referencedRWLayer struct {
 }
 
 func (rl *referencedRWLayer) Mount(mountLabel string) (string, error) {
-	return rl.layerStore.driver.Get(rl.mountedLayer.mountID, mountLabel)
+	start := time.Now()
+	dir, err := rl.layerStore.driver.Get(rl.mountedLayer.mountID, mountLabel)
+	if err != nil {
+		return "", err
+	}
+	rl.layerStore.observeDriverAction("get", start)
+	return dir, nil
 }
 
 // Unmount decrements the activity count and unmounts the underlying layer
 // Callers should only call `Unmount` once per call to `Mount`, even on error.
 func (rl *referencedRWLayer) Unmount() error {
-	return rl.layerStore.driver.Put(rl.mountedLayer.mountID)
+	start := time.Now()
+	if err := rl.layerStore.driver.Put(rl.mountedLayer.mountID); err != nil {
+		return err
+	}
+	rl.layerStore.observeDriverAction("put", start)
+	return nil
 }
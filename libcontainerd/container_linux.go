@@ -86,7 +86,7 @@ func (ctr *container) spec() (*specs.Spec, error) {
 	return &spec, nil
 }
 
-func (ctr *container) start() error {
+func (ctr *container) start(checkpoint string, checkpointDir string) error {
 	spec, err := ctr.spec()
 	if err != nil {
 		return nil
@@ -103,9 +103,11 @@ func (ctr *container) start() error {
 		Stdout:     ctr.fifo(syscall.Stdout),
 		Stderr:     ctr.fifo(syscall.Stderr),
 		// check to see if we are running in ramdisk to disable pivot root
-		NoPivotRoot: os.Getenv("DOCKER_RAMDISK") != "",
-		Runtime:     ctr.runtime,
-		RuntimeArgs: ctr.runtimeArgs,
+		NoPivotRoot:   os.Getenv("DOCKER_RAMDISK") != "",
+		Runtime:       ctr.runtime,
+		RuntimeArgs:   ctr.runtimeArgs,
+		Checkpoint:    checkpoint,
+		CheckpointDir: checkpointDir,
 	}
 	ctr.client.appendContainer(ctr)
 
@@ -183,7 +185,7 @@ func (ctr *container) handleEvent(e *containerd.Event) error {
 							logrus.Error(err)
 						}
 					} else {
-						ctr.start()
+						ctr.start("", "")
 					}
 				}()
 			}
@@ -0,0 +1,42 @@
+package authentication
+
+const (
+	// AuthNApiAuthenticate is the url for daemon request authentication
+	AuthNApiAuthenticate = "AuthNPlugin.Authenticate"
+
+	// AuthNApiImplements is the name of the interface all AuthN plugins implement
+	AuthNApiImplements = "authn"
+)
+
+// PluginRequest holds data required for authN plugins
+type PluginRequest struct {
+	// RequestMethod holds the HTTP method (GET/POST/PUT)
+	RequestMethod string `json:"RequestMethod,omitempty"`
+
+	// RequestURI holds the full HTTP uri (e.g., /v1.21/version)
+	RequestURI string `json:"RequestUri,omitempty"`
+
+	// RequestHeaders stores the raw request headers sent to the docker daemon,
+	// including the credentials of the request
+	RequestHeaders map[string]string `json:"RequestHeaders,omitempty"`
+}
+
+// PluginResponse represents authN plugin response
+type PluginResponse struct {
+	// Authenticated indicates whether the credentials of the request are valid
+	Authenticated bool `json:"Authenticated"`
+
+	// User holds the name of the authenticated user
+	User string `json:"User,omitempty"`
+
+	// UserGroups holds the groups of the authenticated user
+	UserGroups []string `json:"UserGroups,omitempty"`
+
+	// Msg stores the reason the credentials of the request are rejected. If
+	// it is empty and the request is not authenticated, the request is left
+	// to the next authenticators.
+	Msg string `json:"Msg,omitempty"`
+
+	// Err stores a message in case there's an error
+	Err string `json:"Err,omitempty"`
+}
@@ -0,0 +1,129 @@
+package authentication
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Authenticator authenticates the users making API requests from the
+// credentials of the requests.
+type Authenticator interface {
+	// Name returns the name of the authenticator.
+	Name() string
+	// Authenticate returns the identity of the user that made r. It returns
+	// nil and no error if r doesn't hold credentials the authenticator
+	// handles, so that the next authenticator can be tried, and an error if
+	// r holds credentials the authenticator rejects.
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// Config is the configuration of an authenticator, as parsed from an
+// --authenticator option.
+type Config struct {
+	// Type is the type of the authenticator: token, htpasswd, jwt or plugin.
+	Type string
+	// Options are the options specific to the type of the authenticator.
+	Options map[string]string
+}
+
+// ParseConfig parses the comma separated list of key=value pairs of an
+// authenticator, for example:
+//
+//	type=jwt,jwks=/etc/docker/jwks.json,issuer=https://example.com
+func ParseConfig(value string) (Config, error) {
+	config := Config{
+		Options: make(map[string]string),
+	}
+
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return config, err
+	}
+
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return config, fmt.Errorf("invalid field '%s' in authenticator: must be a key=value pair", field)
+		}
+
+		key, val := strings.ToLower(parts[0]), parts[1]
+		if key == "type" {
+			config.Type = val
+		} else {
+			config.Options[key] = val
+		}
+	}
+
+	if err := validateOptions(config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// ValidateAuthenticator validates the value of an --authenticator option.
+func ValidateAuthenticator(value string) (string, error) {
+	if _, err := ParseConfig(value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// authenticatorOptions are the options accepted by each type of
+// authenticator, and whether they are required.
+var authenticatorOptions = map[string]map[string]bool{
+	"token":    {"file": true},
+	"htpasswd": {"file": true},
+	"jwt":      {"jwks": true, "issuer": false, "audience": false, "user-claim": false, "groups-claim": false},
+	"plugin":   {"name": true},
+}
+
+func validateOptions(config Config) error {
+	if config.Type == "" {
+		return fmt.Errorf("type is required for an authenticator")
+	}
+	options, ok := authenticatorOptions[config.Type]
+	if !ok {
+		return fmt.Errorf("invalid authenticator type '%s': must be token, htpasswd, jwt or plugin", config.Type)
+	}
+	for key := range config.Options {
+		if _, ok := options[key]; !ok {
+			return fmt.Errorf("unknown option '%s' for %s authenticator", key, config.Type)
+		}
+	}
+	for key, required := range options {
+		if _, ok := config.Options[key]; required && !ok {
+			return fmt.Errorf("%s is required for %s authenticator", key, config.Type)
+		}
+	}
+	return nil
+}
+
+// New creates the authenticator described by config.
+func New(config Config) (Authenticator, error) {
+	if err := validateOptions(config); err != nil {
+		return nil, err
+	}
+	switch config.Type {
+	case "token":
+		return newTokenAuthenticator(config.Options["file"])
+	case "htpasswd":
+		return newHtpasswdAuthenticator(config.Options["file"])
+	case "jwt":
+		return newJWTAuthenticator(config.Options)
+	default:
+		return newPluginAuthenticator(config.Options["name"]), nil
+	}
+}
+
+// bearerToken returns the bearer token of the Authorization header of r, if
+// any.
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(header[7:])
+}
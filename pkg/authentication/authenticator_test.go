@@ -0,0 +1,169 @@
+package authentication
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/go-connections/tlsconfig"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestParseConfig(t *testing.T) {
+	config, err := ParseConfig("type=jwt,jwks=/etc/docker/jwks.json,audience=docker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Type != "jwt" {
+		t.Fatalf("Expected jwt authenticator, got %s", config.Type)
+	}
+	if config.Options["jwks"] != "/etc/docker/jwks.json" || config.Options["audience"] != "docker" {
+		t.Fatalf("Expected jwks and audience options, got %v", config.Options)
+	}
+
+	invalids := map[string]string{
+		"file=/etc/docker/tokens":      "type is required",
+		"type=unknown":                 "invalid authenticator type 'unknown'",
+		"type=token":                   "file is required for token authenticator",
+		"type=plugin,name=p,file=/tmp": "unknown option 'file' for plugin authenticator",
+		"type=token,file":              "invalid field 'file' in authenticator",
+	}
+	for value, expected := range invalids {
+		if _, err := ParseConfig(value); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected an error containing %q for %s, got %v", expected, value, err)
+		}
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "authn-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := writeFile(t, tmp, "tokens.csv", "# token,user,groups\ns3cr3t,alice,admin,dev\n\nt0k3n,bob\n")
+	a, err := New(Config{Type: "token", Options: map[string]string{"file": path}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, _ := http.NewRequest("GET", "/info", nil)
+	if id, err := a.Authenticate(r); id != nil || err != nil {
+		t.Fatalf("Expected a request without token to be left to the next authenticators, got %v, %v", id, err)
+	}
+
+	r.Header.Set("Authorization", "Bearer s3cr3t")
+	id, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Identity{User: "alice", Groups: []string{"admin", "dev"}, Method: "token"}
+	if !reflect.DeepEqual(id, expected) {
+		t.Fatalf("Expected %v, got %v", expected, id)
+	}
+
+	r.Header.Set("Authorization", "Bearer unknown")
+	if id, err := a.Authenticate(r); id != nil || err != nil {
+		t.Fatalf("Expected an unknown token to be left to the next authenticators, got %v, %v", id, err)
+	}
+
+	path = writeFile(t, tmp, "invalid.csv", "s3cr3t\n")
+	if _, err := New(Config{Type: "token", Options: map[string]string{"file": path}}); err == nil {
+		t.Fatal("Expected an error for a token without user")
+	}
+}
+
+func TestHtpasswdAuthenticator(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "authn-htpasswd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("passw0rd"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writeFile(t, tmp, "htpasswd", "alice:"+string(hash)+"\n")
+	a, err := New(Config{Type: "htpasswd", Options: map[string]string{"file": path}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, _ := http.NewRequest("GET", "/info", nil)
+	r.SetBasicAuth("alice", "passw0rd")
+	id, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.User != "alice" || id.Method != "basic" {
+		t.Fatalf("Expected alice authenticated by basic authentication, got %v", id)
+	}
+
+	r.SetBasicAuth("alice", "wrong")
+	if _, err := a.Authenticate(r); err == nil {
+		t.Fatal("Expected an error for a wrong password")
+	}
+
+	r.SetBasicAuth("bob", "passw0rd")
+	if id, err := a.Authenticate(r); id != nil || err != nil {
+		t.Fatalf("Expected an unknown user to be left to the next authenticators, got %v, %v", id, err)
+	}
+
+	path = writeFile(t, tmp, "md5", "alice:$apr1$salt$hash\n")
+	if _, err := New(Config{Type: "htpasswd", Options: map[string]string{"file": path}}); err == nil {
+		t.Fatal("Expected an error for a password not hashed with bcrypt")
+	}
+}
+
+func TestPluginAuthenticatorSkipsRegistryCredentials(t *testing.T) {
+	var recorded PluginRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/"+AuthNApiAuthenticate, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&recorded); err != nil {
+			t.Fatal(err)
+		}
+		json.NewEncoder(w).Encode(PluginResponse{Authenticated: true, User: "alice"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := plugins.NewClient(server.URL, &tlsconfig.Options{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &pluginAuthenticator{name: "plugin", plugin: client}
+
+	r, _ := http.NewRequest("POST", "/images/create", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set("X-Registry-Auth", "credentials")
+	r.Header.Set("X-Registry-Config", "config")
+	identity, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity == nil || identity.User != "alice" {
+		t.Fatalf("Expected the request to be authenticated as alice, got %v", identity)
+	}
+
+	expected := map[string]string{"Authorization": "Bearer token"}
+	if !reflect.DeepEqual(recorded.RequestHeaders, expected) {
+		t.Fatalf("Expected headers %v, got %v", expected, recorded.RequestHeaders)
+	}
+}
@@ -0,0 +1,76 @@
+package authentication
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// htpasswdAuthenticator authenticates requests with HTTP basic
+// authentication, against the bcrypt hashed passwords of an htpasswd file.
+type htpasswdAuthenticator struct {
+	hashes map[string][]byte
+}
+
+// newHtpasswdAuthenticator creates an authenticator for the users of the
+// htpasswd file at path, e.g. as created by `htpasswd -B`. Only bcrypt hashed
+// passwords are supported.
+func newHtpasswdAuthenticator(path string) (Authenticator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	a := &htpasswdAuthenticator{
+		hashes: make(map[string][]byte),
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid htpasswd file %s: lines must be user:hash pairs", path)
+		}
+		if !strings.HasPrefix(parts[1], "$2") {
+			return nil, fmt.Errorf("invalid htpasswd file %s: the password of %s is not hashed with bcrypt", path, parts[0])
+		}
+		a.hashes[parts[0]] = []byte(parts[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *htpasswdAuthenticator) Name() string {
+	return "htpasswd"
+}
+
+// Authenticate checks the basic authentication credentials of r. Unknown
+// users are left to the next authenticators.
+func (a *htpasswdAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, nil
+	}
+	hash, ok := a.hashes[user]
+	if !ok {
+		return nil, nil
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil {
+		return nil, fmt.Errorf("invalid password for user %s", user)
+	}
+	return &Identity{
+		User:   user,
+		Method: "basic",
+	}, nil
+}
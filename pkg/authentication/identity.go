@@ -0,0 +1,47 @@
+package authentication
+
+import (
+	"net/http"
+
+	"golang.org/x/net/context"
+)
+
+// Identity is the identity of the user that made an API request.
+type Identity struct {
+	// User is the name of the user.
+	User string
+	// Groups are the groups the user belongs to.
+	Groups []string
+	// Method is the mechanism that authenticated the user (e.g., TLS).
+	Method string
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx holding the identity of the user that
+// made the request ctx belongs to.
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFromContext returns the identity of the user that made the request
+// ctx belongs to, or nil if the user is anonymous.
+func IdentityFromContext(ctx context.Context) *Identity {
+	id, _ := ctx.Value(identityKey{}).(*Identity)
+	return id
+}
+
+// TLSIdentity returns the identity of the client certificate of r, or nil if
+// r doesn't have one. The user is the common name of the certificate, and the
+// groups are its organizations.
+func TLSIdentity(r *http.Request) *Identity {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	subject := r.TLS.PeerCertificates[0].Subject
+	return &Identity{
+		User:   subject.CommonName,
+		Groups: subject.Organization,
+		Method: "TLS",
+	}
+}
@@ -0,0 +1,324 @@
+package authentication
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultUserClaim   = "sub"
+	defaultGroupsClaim = "groups"
+)
+
+// jwtAuthenticator authenticates requests with JSON Web Tokens passed as
+// bearer tokens, e.g. the ID tokens of an OpenID Connect provider. The
+// signatures of the tokens are verified against the keys of a local JSON Web
+// Key Set.
+type jwtAuthenticator struct {
+	// keys are the verification keys, by key ID.
+	keys        map[string]crypto.PublicKey
+	issuer      string
+	audience    string
+	userClaim   string
+	groupsClaim string
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func newJWTAuthenticator(options map[string]string) (Authenticator, error) {
+	keys, err := loadJWKS(options["jwks"])
+	if err != nil {
+		return nil, err
+	}
+
+	a := &jwtAuthenticator{
+		keys:        keys,
+		issuer:      options["issuer"],
+		audience:    options["audience"],
+		userClaim:   options["user-claim"],
+		groupsClaim: options["groups-claim"],
+	}
+	if a.userClaim == "" {
+		a.userClaim = defaultUserClaim
+	}
+	if a.groupsClaim == "" {
+		a.groupsClaim = defaultGroupsClaim
+	}
+	return a, nil
+}
+
+// loadJWKS loads the RSA and EC signature keys of the JSON Web Key Set at
+// path. Keys of other types are ignored.
+func loadJWKS(path string) (map[string]crypto.PublicKey, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &jwks); err != nil {
+		return nil, fmt.Errorf("invalid JSON Web Key Set %s: %v", path, err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		var key crypto.PublicKey
+		switch jwk.Kty {
+		case "RSA":
+			key, err = rsaPublicKey(jwk)
+		case "EC":
+			key, err = ecPublicKey(jwk)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q in JSON Web Key Set %s: %v", jwk.Kid, path, err)
+		}
+		if _, ok := keys[jwk.Kid]; ok {
+			return nil, fmt.Errorf("invalid JSON Web Key Set %s: duplicate key %q", path, jwk.Kid)
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("invalid JSON Web Key Set %s: no signature keys", path)
+	}
+	return keys, nil
+}
+
+func rsaPublicKey(jwk jsonWebKey) (crypto.PublicKey, error) {
+	n, err := decodeBigInt(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeBigInt(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	if e.BitLen() > 31 {
+		return nil, fmt.Errorf("invalid RSA exponent")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func ecPublicKey(jwk jsonWebKey) (crypto.PublicKey, error) {
+	var curve elliptic.Curve
+	switch jwk.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+	}
+	x, err := decodeBigInt(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeBigInt(jwk.Y)
+	if err != nil {
+		return nil, err
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("point is not on curve %s", jwk.Crv)
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("missing key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (a *jwtAuthenticator) Name() string {
+	return "jwt"
+}
+
+// Authenticate verifies the JSON Web Token passed as bearer token of r.
+// Bearer tokens that are not JSON Web Tokens are left to the next
+// authenticators.
+func (a *jwtAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token := bearerToken(r)
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg == "" {
+		return nil, nil
+	}
+
+	if err := a.verifySignature(header, parts[0]+"."+parts[1], parts[2]); err != nil {
+		return nil, err
+	}
+
+	claims := make(map[string]interface{})
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("invalid token claims: %v", err)
+	}
+	if err := a.validateClaims(claims, time.Now()); err != nil {
+		return nil, err
+	}
+
+	user, _ := claims[a.userClaim].(string)
+	if user == "" {
+		return nil, fmt.Errorf("token has no %s claim", a.userClaim)
+	}
+	return &Identity{
+		User:   user,
+		Groups: stringsClaim(claims[a.groupsClaim]),
+		Method: "jwt",
+	}, nil
+}
+
+func (a *jwtAuthenticator) verifySignature(header jwtHeader, signed, signature string) error {
+	key, ok := a.keys[header.Kid]
+	if !ok {
+		return fmt.Errorf("token is signed by unknown key %q", header.Kid)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid token signature: %v", err)
+	}
+
+	var hash crypto.Hash
+	switch header.Alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported token signature algorithm %q", header.Alg)
+	}
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(header.Alg, "RS") {
+			return fmt.Errorf("algorithm %q doesn't match the RSA key %q", header.Alg, header.Kid)
+		}
+		if err := rsa.VerifyPKCS1v15(key, hash, digest, sig); err != nil {
+			return fmt.Errorf("invalid token signature")
+		}
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(header.Alg, "ES") {
+			return fmt.Errorf("algorithm %q doesn't match the EC key %q", header.Alg, header.Kid)
+		}
+		size := (key.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return fmt.Errorf("invalid token signature")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return fmt.Errorf("invalid token signature")
+		}
+	}
+	return nil
+}
+
+// validateClaims validates the registered claims of a token: the expiration
+// time, the time before which the token is not valid, the issuer and the
+// audience.
+func (a *jwtAuthenticator) validateClaims(claims map[string]interface{}, now time.Time) error {
+	exp, ok := claims["exp"].(json.Number)
+	if !ok {
+		return fmt.Errorf("token has no expiration time")
+	}
+	if t, err := exp.Int64(); err != nil || now.Unix() >= t {
+		return fmt.Errorf("token is expired")
+	}
+	if nbf, ok := claims["nbf"].(json.Number); ok {
+		if t, err := nbf.Int64(); err != nil || now.Unix() < t {
+			return fmt.Errorf("token is not valid yet")
+		}
+	}
+	if a.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != a.issuer {
+			return fmt.Errorf("token is issued by %q, not %q", iss, a.issuer)
+		}
+	}
+	if a.audience != "" {
+		found := false
+		for _, aud := range stringsClaim(claims["aud"]) {
+			if aud == a.audience {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("token is not intended for %q", a.audience)
+		}
+	}
+	return nil
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token.
+func decodeSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// stringsClaim returns the values of a claim which is either a string or an
+// array of strings.
+func stringsClaim(claim interface{}) []string {
+	switch claim := claim.(type) {
+	case string:
+		return []string{claim}
+	case []interface{}:
+		var values []string
+		for _, v := range claim {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
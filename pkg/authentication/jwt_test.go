@@ -0,0 +1,159 @@
+package authentication
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func signToken(t *testing.T, key crypto.Signer, alg, kid string, claims map[string]interface{}) string {
+	signed := encodeSegment(t, map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, 64)
+		copy(sig[32-len(r.Bytes()):], r.Bytes())
+		copy(sig[64-len(s.Bytes()):], s.Bytes())
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks := fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "n": %q, "e": %q},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": %q, "y": %q},
+		{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"}
+	]}`, encodeBigInt(rsaKey.N), encodeBigInt(big.NewInt(int64(rsaKey.E))), encodeBigInt(ecKey.X), encodeBigInt(ecKey.Y))
+
+	tmp, err := ioutil.TempDir("", "authn-jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	path := writeFile(t, tmp, "jwks.json", jwks)
+
+	a, err := New(Config{Type: "jwt", Options: map[string]string{
+		"jwks":     path,
+		"issuer":   "https://issuer.example.com",
+		"audience": "docker",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().Unix()
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss":    "https://issuer.example.com",
+			"aud":    []string{"docker", "other"},
+			"sub":    "alice",
+			"groups": []string{"admin"},
+			"exp":    now + 60,
+			"nbf":    now - 60,
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	authenticate := func(token string) (*Identity, error) {
+		r, _ := http.NewRequest("GET", "/info", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		return a.Authenticate(r)
+	}
+
+	expected := &Identity{User: "alice", Groups: []string{"admin"}, Method: "jwt"}
+	for _, token := range []string{
+		signToken(t, rsaKey, "RS256", "rsa", claims(nil)),
+		signToken(t, ecKey, "ES256", "ec", claims(nil)),
+	} {
+		id, err := authenticate(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(id, expected) {
+			t.Fatalf("Expected %v, got %v", expected, id)
+		}
+	}
+
+	if id, err := authenticate("s3cr3t"); id != nil || err != nil {
+		t.Fatalf("Expected a bearer token which is not a JWT to be left to the next authenticators, got %v, %v", id, err)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalids := map[string]string{
+		signToken(t, otherKey, "RS256", "rsa", claims(nil)):                                   "invalid token signature",
+		signToken(t, rsaKey, "RS256", "unknown", claims(nil)):                                 "unknown key",
+		signToken(t, rsaKey, "ES256", "rsa", claims(nil)):                                     "doesn't match the RSA key",
+		signToken(t, rsaKey, "RS256", "rsa", claims(map[string]interface{}{"exp": now - 1})):  "token is expired",
+		signToken(t, rsaKey, "RS256", "rsa", claims(map[string]interface{}{"exp": nil})):      "no expiration time",
+		signToken(t, rsaKey, "RS256", "rsa", claims(map[string]interface{}{"nbf": now + 60})): "not valid yet",
+		signToken(t, rsaKey, "RS256", "rsa", claims(map[string]interface{}{"iss": "other"})):  "token is issued by",
+		signToken(t, rsaKey, "RS256", "rsa", claims(map[string]interface{}{"aud": "other"})):  "not intended for",
+		signToken(t, rsaKey, "RS256", "rsa", claims(map[string]interface{}{"sub": nil})):      "no sub claim",
+	}
+	// A token without signature must never be accepted.
+	unsigned := strings.Split(signToken(t, rsaKey, "RS256", "rsa", claims(nil)), ".")
+	invalids[encodeSegment(t, map[string]string{"alg": "none", "kid": "rsa"})+"."+unsigned[1]+"."] = "unsupported token signature algorithm"
+
+	for token, expected := range invalids {
+		if _, err := authenticate(token); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected an error containing %q, got %v", expected, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package authentication
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/pkg/plugins"
+)
+
+// pluginAuthenticator is an adapter to the docker plugin system, for
+// third party plugins authenticating requests
+type pluginAuthenticator struct {
+	mu     sync.Mutex
+	plugin *plugins.Client
+	name   string
+}
+
+func newPluginAuthenticator(name string) Authenticator {
+	return &pluginAuthenticator{name: name}
+}
+
+func (a *pluginAuthenticator) Name() string {
+	return a.name
+}
+
+func (a *pluginAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	plugin, err := a.initPlugin()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &PluginRequest{
+		RequestMethod:  r.Method,
+		RequestURI:     r.RequestURI,
+		RequestHeaders: make(map[string]string),
+	}
+	for k := range r.Header {
+		// Skip the registry credentials, the plugin only needs the
+		// credentials of the user on the daemon
+		if strings.EqualFold(k, "X-Registry-Config") || strings.EqualFold(k, "X-Registry-Auth") {
+			continue
+		}
+		req.RequestHeaders[k] = r.Header.Get(k)
+	}
+
+	res := &PluginResponse{}
+	if err := plugin.Call(AuthNApiAuthenticate, req, res); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case res.Err != "":
+		return nil, fmt.Errorf("plugin %s failed with error: %s", a.name, res.Err)
+	case res.Authenticated:
+		if res.User == "" {
+			return nil, fmt.Errorf("plugin %s authenticated a request without a user", a.name)
+		}
+		return &Identity{
+			User:   res.User,
+			Groups: res.UserGroups,
+			Method: a.name,
+		}, nil
+	case res.Msg != "":
+		return nil, errors.New(res.Msg)
+	}
+	return nil, nil
+}
+
+// initPlugin initializes the authentication plugin if needed. Loading the
+// plugin is retried on the next request if it fails.
+func (a *pluginAuthenticator) initPlugin() (*plugins.Client, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Lazy loading of plugins
+	if a.plugin == nil {
+		plugin, err := plugins.Get(a.name, AuthNApiImplements)
+		if err != nil {
+			return nil, err
+		}
+		a.plugin = plugin.Client()
+	}
+	return a.plugin, nil
+}
@@ -0,0 +1,83 @@
+package authentication
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// tokenAuthenticator authenticates requests with static bearer tokens.
+type tokenAuthenticator struct {
+	// identities are the identities of the users, by hash of their token.
+	identities map[[sha256.Size]byte]*Identity
+}
+
+// newTokenAuthenticator creates an authenticator for the tokens of the file
+// at path. Each line of the file is a comma separated list of a token, the
+// user the token belongs to, and the groups of the user:
+//
+//	token,user[,group...]
+//
+// Empty lines and lines starting with # are ignored.
+func newTokenAuthenticator(path string) (Authenticator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	a := &tokenAuthenticator{
+		identities: make(map[[sha256.Size]byte]*Identity),
+	}
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid token file %s: %v", path, err)
+		}
+		if len(record) < 2 || record[0] == "" || record[1] == "" {
+			return nil, fmt.Errorf("invalid token file %s: lines must hold a token and a user", path)
+		}
+
+		key := sha256.Sum256([]byte(record[0]))
+		if _, ok := a.identities[key]; ok {
+			return nil, fmt.Errorf("invalid token file %s: duplicate token for user %s", path, record[1])
+		}
+		a.identities[key] = &Identity{
+			User:   record[1],
+			Groups: record[2:],
+			Method: "token",
+		}
+	}
+	return a, nil
+}
+
+func (a *tokenAuthenticator) Name() string {
+	return "token"
+}
+
+// Authenticate looks up the bearer token of r. Unknown tokens are left to
+// the next authenticators.
+func (a *tokenAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token := bearerToken(r)
+	if token == "" {
+		return nil, nil
+	}
+	// Tokens are looked up by hash, so that the time of the lookup doesn't
+	// depend on how much of a token matches a valid one.
+	id, ok := a.identities[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, nil
+	}
+	return id, nil
+}
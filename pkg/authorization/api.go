@@ -16,6 +16,9 @@ type Request struct {
 	// User holds the user extracted by AuthN mechanism
 	User string `json:"User,omitempty"`
 
+	// UserGroups holds the groups of the user extracted by AuthN mechanism
+	UserGroups []string `json:"UserGroups,omitempty"`
+
 	// UserAuthNMethod holds the mechanism used to extract user details (e.g., krb)
 	UserAuthNMethod string `json:"UserAuthNMethod,omitempty"`
 
@@ -31,6 +34,11 @@ type Request struct {
 	// RequestHeaders stores the raw request headers sent to the docker daemon
 	RequestHeaders map[string]string `json:"RequestHeaders,omitempty"`
 
+	// RequestObject holds the identity of the object the request operates on
+	// (e.g., the container of /containers/{name}/exec), if the daemon could
+	// resolve it
+	RequestObject *Object `json:"RequestObject,omitempty"`
+
 	// ResponseStatusCode stores the status code returned from docker daemon
 	ResponseStatusCode int `json:"ResponseStatusCode,omitempty"`
 
@@ -41,6 +49,21 @@ type Request struct {
 	ResponseHeaders map[string]string `json:"ResponseHeaders,omitempty"`
 }
 
+// Object holds the identity of a docker object referenced by a request
+type Object struct {
+	// Type is the type of the object (container, image, volume or network)
+	Type string `json:"Type"`
+
+	// ID is the full ID of the object
+	ID string `json:"ID,omitempty"`
+
+	// Name is the name of the object
+	Name string `json:"Name,omitempty"`
+
+	// Labels holds the labels of the object
+	Labels map[string]string `json:"Labels,omitempty"`
+}
+
 // Response represents authZ plugin response
 type Response struct {
 	// Allow indicating whether the user is allowed or not
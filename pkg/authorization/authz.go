@@ -44,9 +44,13 @@ func NewCtx(authZPlugins []Plugin, user, userAuthNMethod, requestMethod, request
 type Ctx struct {
 	user            string
 	userAuthNMethod string
+	userGroups      []string
 	requestMethod   string
 	requestURI      string
+	requestObject   *Object
 	plugins         []Plugin
+	// cache stores the decisions of plugins on requests, if enabled
+	cache *DecisionCache
 	// authReq stores the cached request object for the current transaction
 	authReq *Request
 }
@@ -70,16 +74,18 @@ func (ctx *Ctx) AuthZRequest(w http.ResponseWriter, r *http.Request) error {
 	ctx.authReq = &Request{
 		User:            ctx.user,
 		UserAuthNMethod: ctx.userAuthNMethod,
+		UserGroups:      ctx.userGroups,
 		RequestMethod:   ctx.requestMethod,
 		RequestURI:      ctx.requestURI,
 		RequestBody:     body,
 		RequestHeaders:  headers(r.Header),
+		RequestObject:   ctx.requestObject,
 	}
 
 	for _, plugin := range ctx.plugins {
 		logrus.Debugf("AuthZ request using plugin %s", plugin.Name())
 
-		authRes, err := plugin.AuthZRequest(ctx.authReq)
+		authRes, err := ctx.authZRequest(plugin)
 		if err != nil {
 			return fmt.Errorf("plugin %s failed with error: %s", plugin.Name(), err)
 		}
@@ -92,6 +98,28 @@ func (ctx *Ctx) AuthZRequest(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// authZRequest returns the decision of plugin on the current request, from
+// the decision cache if possible.
+func (ctx *Ctx) authZRequest(plugin Plugin) (*Response, error) {
+	if ctx.cache != nil {
+		if authRes, ok := ctx.cache.get(plugin.Name(), ctx.authReq); ok {
+			logrus.Debugf("AuthZ request using cached decision of plugin %s", plugin.Name())
+			return authRes, nil
+		}
+	}
+
+	authRes, err := plugin.AuthZRequest(ctx.authReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failures of the plugin are not decisions, don't cache them.
+	if ctx.cache != nil && authRes.Err == "" {
+		ctx.cache.add(plugin.Name(), ctx.authReq, authRes)
+	}
+	return authRes, nil
+}
+
 // AuthZResponse authorized and manipulates the response from docker daemon using authZ plugins
 func (ctx *Ctx) AuthZResponse(rm ResponseModifier, r *http.Request) error {
 	ctx.authReq.ResponseStatusCode = rm.StatusCode()
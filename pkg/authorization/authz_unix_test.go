@@ -67,10 +67,17 @@ func TestAuthZRequestPlugin(t *testing.T) {
 
 	request := Request{
 		User:           "user",
+		UserGroups:     []string{"group"},
 		RequestBody:    []byte("sample body"),
 		RequestURI:     "www.authz.com/auth",
 		RequestMethod:  "GET",
 		RequestHeaders: map[string]string{"header": "value"},
+		RequestObject: &Object{
+			Type:   ContainerObject,
+			ID:     "abcdef",
+			Name:   "name",
+			Labels: map[string]string{"label": "value"},
+		},
 	}
 	server.replayResponse = Response{
 		Allow: true,
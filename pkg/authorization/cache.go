@@ -0,0 +1,120 @@
+package authorization
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+)
+
+// DecisionCache caches the decisions of authZ plugins on requests, so that
+// the same request, from the same user, on the same object is not sent to
+// the plugins again. It holds a bounded number of decisions, which expire
+// after a fixed duration.
+//
+// Only the decisions on requests are cached, as the decisions on responses
+// depend on the content of the response.
+type DecisionCache struct {
+	mu  sync.Mutex
+	lru *simplelru.LRU
+	ttl time.Duration
+}
+
+type decision struct {
+	res     Response
+	expires time.Time
+}
+
+// NewDecisionCache creates a cache holding at most size decisions, each one
+// for at most ttl.
+func NewDecisionCache(size int, ttl time.Duration) (*DecisionCache, error) {
+	lru, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DecisionCache{
+		lru: lru,
+		ttl: ttl,
+	}, nil
+}
+
+// get returns the cached decision of plugin on authReq, if any.
+func (c *DecisionCache) get(plugin string, authReq *Request) (*Response, bool) {
+	key, err := decisionKey(plugin, authReq)
+	if err != nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.lru.Get(key)
+	if !ok {
+		return nil, false
+	}
+	d := v.(decision)
+	if time.Now().After(d.expires) {
+		c.lru.Remove(key)
+		return nil, false
+	}
+	res := d.res
+	return &res, true
+}
+
+// add caches the decision of plugin on authReq.
+func (c *DecisionCache) add(plugin string, authReq *Request, res *Response) {
+	key, err := decisionKey(plugin, authReq)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lru.Add(key, decision{
+		res:     *res,
+		expires: time.Now().Add(c.ttl),
+	})
+}
+
+// Purge removes all the decisions from the cache.
+func (c *DecisionCache) Purge() {
+	c.mu.Lock()
+	c.lru.Purge()
+	c.mu.Unlock()
+}
+
+// decisionKey returns the key of the decision of plugin on authReq. The key
+// covers the identity of the user, the request, including its headers and
+// body, and the object the request operates on.
+func decisionKey(plugin string, authReq *Request) (string, error) {
+	b, err := json.Marshal(struct {
+		Plugin          string
+		User            string
+		UserAuthNMethod string
+		UserGroups      []string
+		RequestMethod   string
+		RequestURI      string
+		RequestHeaders  map[string]string
+		RequestBody     []byte
+		RequestObject   *Object
+	}{
+		Plugin:          plugin,
+		User:            authReq.User,
+		UserAuthNMethod: authReq.UserAuthNMethod,
+		UserGroups:      authReq.UserGroups,
+		RequestMethod:   authReq.RequestMethod,
+		RequestURI:      authReq.RequestURI,
+		RequestHeaders:  authReq.RequestHeaders,
+		RequestBody:     authReq.RequestBody,
+		RequestObject:   authReq.RequestObject,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
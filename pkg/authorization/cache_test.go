@@ -0,0 +1,69 @@
+package authorization
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecisionCache(t *testing.T) {
+	cache, err := NewDecisionCache(1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &Request{
+		User:          "user",
+		RequestMethod: "POST",
+		RequestURI:    "/containers/foo/start",
+		RequestObject: &Object{Type: ContainerObject, ID: "abcdef"},
+	}
+	cache.add("plugin", req, &Response{Allow: true})
+
+	res, ok := cache.get("plugin", req)
+	if !ok || !res.Allow {
+		t.Fatalf("expected a cached decision allowing the request, got %v", res)
+	}
+	if _, ok := cache.get("other", req); ok {
+		t.Fatal("expected no cached decision for another plugin")
+	}
+
+	// The same request on another object is a different decision.
+	other := *req
+	other.RequestObject = &Object{Type: ContainerObject, ID: "123456"}
+	if _, ok := cache.get("plugin", &other); ok {
+		t.Fatal("expected no cached decision for another object")
+	}
+
+	// The same request with other headers is a different decision.
+	withHeaders := *req
+	withHeaders.RequestHeaders = map[string]string{"X-Meta-Tenant": "other"}
+	if _, ok := cache.get("plugin", &withHeaders); ok {
+		t.Fatal("expected no cached decision for other headers")
+	}
+
+	// The cache holds a single decision, the first one is evicted.
+	cache.add("plugin", &other, &Response{Allow: false})
+	if _, ok := cache.get("plugin", req); ok {
+		t.Fatal("expected the first decision to be evicted")
+	}
+
+	cache.Purge()
+	if _, ok := cache.get("plugin", &other); ok {
+		t.Fatal("expected no cached decision after purge")
+	}
+}
+
+func TestDecisionCacheExpiry(t *testing.T) {
+	cache, err := NewDecisionCache(10, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &Request{User: "user", RequestMethod: "GET", RequestURI: "/info"}
+	cache.add("plugin", req, &Response{Allow: true})
+	time.Sleep(10 * time.Millisecond)
+
+	if _, ok := cache.get("plugin", req); ok {
+		t.Fatal("expected the decision to be expired")
+	}
+}
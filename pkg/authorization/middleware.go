@@ -2,42 +2,83 @@ package authorization
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/pkg/authentication"
 	"golang.org/x/net/context"
 )
 
 // Middleware uses a list of plugins to
 // handle authorization in the API requests.
 type Middleware struct {
-	plugins []Plugin
+	mu       sync.Mutex
+	plugins  []Plugin
+	resolver ObjectResolver
+	cache    *DecisionCache
 }
 
 // NewMiddleware creates a new Middleware
-// with a slice of plugins.
-func NewMiddleware(p []Plugin) Middleware {
-	return Middleware{
-		plugins: p,
+// with a slice of plugins. If resolver is not nil, it is used
+// to pass the identity of the objects referenced by requests
+// to the plugins. If cache is not nil, the decisions of the
+// plugins on requests are cached.
+func NewMiddleware(p []Plugin, resolver ObjectResolver, cache *DecisionCache) *Middleware {
+	return &Middleware{
+		plugins:  p,
+		resolver: resolver,
+		cache:    cache,
 	}
 }
 
+// SetPlugins replaces the plugins of the middleware, e.g. when the
+// configuration of the daemon is reloaded. The cached decisions of the
+// previous plugins are discarded.
+func (m *Middleware) SetPlugins(p []Plugin) {
+	m.mu.Lock()
+	m.plugins = p
+	m.mu.Unlock()
+
+	if m.cache != nil {
+		m.cache.Purge()
+	}
+}
+
+func (m *Middleware) getPlugins() []Plugin {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.plugins
+}
+
 // WrapHandler returns a new handler function wrapping the previous one in the request chain.
-func (m Middleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+func (m *Middleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		plugins := m.getPlugins()
+		if len(plugins) == 0 {
+			return handler(ctx, w, r, vars)
+		}
 
 		user := ""
 		userAuthNMethod := ""
+		var userGroups []string
 
-		// Default authorization using existing TLS connection credentials
-		// FIXME: Non trivial authorization mechanisms (such as advanced certificate validations, kerberos support
-		// and ldap) will be extracted using AuthN feature, which is tracked under:
-		// https://github.com/docker/docker/pull/20883
-		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
-			user = r.TLS.PeerCertificates[0].Subject.CommonName
-			userAuthNMethod = "TLS"
+		// The user is authenticated by the AuthN middleware, which falls back
+		// to the existing TLS connection credentials. Requests which didn't go
+		// through it are authorized with the TLS credentials only.
+		id := authentication.IdentityFromContext(ctx)
+		if id == nil {
+			id = authentication.TLSIdentity(r)
+		}
+		if id != nil {
+			user = id.User
+			userGroups = id.Groups
+			userAuthNMethod = id.Method
 		}
 
-		authCtx := NewCtx(m.plugins, user, userAuthNMethod, r.Method, r.RequestURI)
+		authCtx := NewCtx(plugins, user, userAuthNMethod, r.Method, r.RequestURI)
+		authCtx.userGroups = userGroups
+		authCtx.requestObject = m.resolveObject(r, vars)
+		authCtx.cache = m.cache
 
 		if err := authCtx.AuthZRequest(w, r); err != nil {
 			logrus.Errorf("AuthZRequest for %s %s returned error: %s", r.Method, r.RequestURI, err)
@@ -58,3 +99,12 @@ func (m Middleware) WrapHandler(handler func(ctx context.Context, w http.Respons
 		return nil
 	}
 }
+
+// resolveObject returns the object the request operates on, or nil if there
+// is none or it cannot be resolved.
+func (m *Middleware) resolveObject(r *http.Request, vars map[string]string) *Object {
+	if m.resolver == nil {
+		return nil
+	}
+	return ResolveRequestObject(m.resolver, r, vars)
+}
@@ -0,0 +1,54 @@
+package authorization
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+type denyPlugin struct{}
+
+func (denyPlugin) Name() string {
+	return "deny"
+}
+
+func (denyPlugin) AuthZRequest(*Request) (*Response, error) {
+	return &Response{Allow: false, Msg: "denied"}, nil
+}
+
+func (denyPlugin) AuthZResponse(*Request) (*Response, error) {
+	return &Response{Allow: false, Msg: "denied"}, nil
+}
+
+func TestMiddlewareSetPlugins(t *testing.T) {
+	called := false
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		called = true
+		return nil
+	}
+
+	m := NewMiddleware(nil, nil, nil)
+	h := m.WrapHandler(handler)
+
+	r, err := http.NewRequest("GET", "/containers/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h(context.Background(), httptest.NewRecorder(), r, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Fatal("Expected the request to be handled without plugins")
+	}
+
+	called = false
+	m.SetPlugins([]Plugin{denyPlugin{}})
+	if err := h(context.Background(), httptest.NewRecorder(), r, nil); err == nil {
+		t.Fatal("Expected the request to be denied by the new plugin")
+	}
+	if called {
+		t.Fatal("Expected the denied request not to be handled")
+	}
+}
@@ -0,0 +1,75 @@
+package authorization
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// Types of the objects referenced by requests
+const (
+	ContainerObject = "container"
+	ExecObject      = "exec"
+	ImageObject     = "image"
+	NetworkObject   = "network"
+	VolumeObject    = "volume"
+)
+
+// ObjectResolver resolves the objects referenced by the requests to the
+// docker daemon, so that authZ plugins can base their decisions on the
+// identity and the labels of the objects rather than on the request URI.
+type ObjectResolver interface {
+	// ResolveObject returns the object of type objectType referenced by
+	// name in a request. An exec instance resolves to its container.
+	ResolveObject(objectType, name string) (*Object, error)
+}
+
+var versionPrefix = regexp.MustCompile(`^/v[0-9.]+`)
+
+// requestObject returns the type and the reference of the object a request
+// operates on, given the path and the route variables of the request. It
+// returns empty strings if the request doesn't operate on a single object.
+func requestObject(path string, vars map[string]string) (string, string) {
+	path = versionPrefix.ReplaceAllString(path, "")
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+
+	var objectType, key string
+	switch segments[0] {
+	case "containers":
+		objectType, key = ContainerObject, "name"
+	case "exec":
+		objectType, key = ExecObject, "name"
+	case "images":
+		objectType, key = ImageObject, "name"
+	case "networks":
+		objectType, key = NetworkObject, "id"
+	case "volumes":
+		objectType, key = VolumeObject, "name"
+	default:
+		return "", ""
+	}
+
+	name := vars[key]
+	if name == "" {
+		return "", ""
+	}
+	return objectType, name
+}
+
+// ResolveRequestObject returns the object a request operates on, given the
+// route variables of the request, or nil if there is none or it cannot be
+// resolved, for example because it doesn't exist.
+func ResolveRequestObject(resolver ObjectResolver, r *http.Request, vars map[string]string) *Object {
+	objectType, name := requestObject(r.URL.Path, vars)
+	if objectType == "" {
+		return nil
+	}
+	object, err := resolver.ResolveObject(objectType, name)
+	if err != nil {
+		logrus.Debugf("Failed to resolve %s %s: %v", objectType, name, err)
+		return nil
+	}
+	return object
+}
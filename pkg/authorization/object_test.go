@@ -0,0 +1,28 @@
+package authorization
+
+import "testing"
+
+func TestRequestObject(t *testing.T) {
+	cases := []struct {
+		path       string
+		vars       map[string]string
+		objectType string
+		name       string
+	}{
+		{"/v1.24/containers/foo/start", map[string]string{"name": "foo"}, ContainerObject, "foo"},
+		{"/containers/foo/json", map[string]string{"name": "foo"}, ContainerObject, "foo"},
+		{"/v1.24/containers/create", map[string]string{}, "", ""},
+		{"/v1.24/exec/abc/start", map[string]string{"name": "abc"}, ExecObject, "abc"},
+		{"/v1.24/images/busybox:latest/json", map[string]string{"name": "busybox:latest"}, ImageObject, "busybox:latest"},
+		{"/v1.24/networks/net", map[string]string{"id": "net"}, NetworkObject, "net"},
+		{"/v1.24/volumes/vol", map[string]string{"name": "vol"}, VolumeObject, "vol"},
+		{"/v1.24/info", nil, "", ""},
+	}
+
+	for _, c := range cases {
+		objectType, name := requestObject(c.path, c.vars)
+		if objectType != c.objectType || name != c.name {
+			t.Fatalf("expected %q %q for %s, got %q %q", c.objectType, c.name, c.path, objectType, name)
+		}
+	}
+}
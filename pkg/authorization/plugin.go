@@ -1,6 +1,7 @@
 package authorization
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/docker/docker/pkg/plugins"
@@ -33,6 +34,21 @@ func NewPlugins(names []string) []Plugin {
 	return plugins
 }
 
+// ValidatePlugins checks that the plugins named names are available and
+// implement the authorization API, so that a configuration referencing a
+// missing plugin is rejected instead of denying all the requests.
+func ValidatePlugins(names []string) error {
+	for _, name := range names {
+		if name == "" {
+			return fmt.Errorf("authorization plugin name cannot be empty")
+		}
+		if _, err := plugins.GetNoRetry(name, AuthZApiImplements); err != nil {
+			return fmt.Errorf("invalid authorization plugin %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
 // authorizationPlugin is an internal adapter to docker plugin system
 type authorizationPlugin struct {
 	plugin *plugins.Client
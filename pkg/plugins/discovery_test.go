@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func Setup(t *testing.T) (string, func()) {
@@ -117,3 +118,16 @@ func TestFileJSONSpecPlugin(t *testing.T) {
 		t.Fatalf("Expected plugin Key `/usr/shared/docker/certs/example-key.pem`, got %s\n", plugin.TLSConfig.KeyFile)
 	}
 }
+
+func TestGetNoRetryNotFound(t *testing.T) {
+	_, unregister := Setup(t)
+	defer unregister()
+
+	start := time.Now()
+	if _, err := GetNoRetry("missing", "LogDriver"); err != ErrNotFound {
+		t.Fatalf("expected %v, got %v", ErrNotFound, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the lookup to fail at once, took %v", elapsed)
+	}
+}
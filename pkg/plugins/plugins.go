@@ -154,10 +154,6 @@ func (p *Plugin) implements(kind string) bool {
 	return false
 }
 
-func load(name string) (*Plugin, error) {
-	return loadWithRetry(name, true)
-}
-
 func loadWithRetry(name string, retry bool) (*Plugin, error) {
 	registry := newLocalRegistry()
 	start := time.Now()
@@ -196,19 +192,30 @@ func loadWithRetry(name string, retry bool) (*Plugin, error) {
 	}
 }
 
-func get(name string) (*Plugin, error) {
+func get(name string, retry bool) (*Plugin, error) {
 	storage.Lock()
 	pl, ok := storage.plugins[name]
 	storage.Unlock()
 	if ok {
 		return pl, pl.activate()
 	}
-	return load(name)
+	return loadWithRetry(name, retry)
 }
 
 // Get returns the plugin given the specified name and requested implementation.
 func Get(name, imp string) (*Plugin, error) {
-	pl, err := get(name)
+	return getImplementing(name, imp, true)
+}
+
+// GetNoRetry returns the plugin given the specified name and requested
+// implementation. Unlike Get, it fails at once if the plugin cannot be found
+// instead of waiting for it to become available.
+func GetNoRetry(name, imp string) (*Plugin, error) {
+	return getImplementing(name, imp, false)
+}
+
+func getImplementing(name, imp string, retry bool) (*Plugin, error) {
+	pl, err := get(name, retry)
 	if err != nil {
 		return nil, err
 	}
@@ -21,3 +21,9 @@ func FindWithCapability(capability string) ([]Plugin, error) {
 func LookupWithCapability(name, capability string) (Plugin, error) {
 	return plugins.Get(name, capability)
 }
+
+// LookupWithCapabilityNoRetry returns a plugin matching the given name and
+// capability, without waiting for the plugin if it cannot be found.
+func LookupWithCapabilityNoRetry(name, capability string) (Plugin, error) {
+	return plugins.GetNoRetry(name, capability)
+}
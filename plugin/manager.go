@@ -204,6 +204,16 @@ func FindWithCapability(capability string) ([]Plugin, error) {
 
 // LookupWithCapability returns a plugin matching the given name and capability.
 func LookupWithCapability(name, capability string) (Plugin, error) {
+	return lookupWithCapability(name, capability, true)
+}
+
+// LookupWithCapabilityNoRetry returns a plugin matching the given name and
+// capability, without waiting for a legacy plugin if it cannot be found.
+func LookupWithCapabilityNoRetry(name, capability string) (Plugin, error) {
+	return lookupWithCapability(name, capability, false)
+}
+
+func lookupWithCapability(name, capability string, retry bool) (Plugin, error) {
 	var (
 		p   *plugin
 		err error
@@ -232,7 +242,11 @@ func LookupWithCapability(name, capability string) (Plugin, error) {
 		}
 	}
 	if handleLegacy {
-		p, err := plugins.Get(name, capability)
+		get := plugins.Get
+		if !retry {
+			get = plugins.GetNoRetry
+		}
+		p, err := get(name, capability)
 		if err != nil {
 			return nil, fmt.Errorf("legacy plugin: %v", err)
 		}
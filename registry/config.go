@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/reference"
@@ -18,6 +19,11 @@ type ServiceOptions struct {
 	Mirrors            []string `json:"registry-mirrors,omitempty"`
 	InsecureRegistries []string `json:"insecure-registries,omitempty"`
 
+	// MirrorsByHost holds the mirrors of registries other than the official
+	// index, keyed by the hostname of the registry. The mirrors of a
+	// registry are tried in order before the registry itself.
+	MirrorsByHost map[string][]string `json:"registry-mirrors-by-host,omitempty"`
+
 	// V2Only controls access to legacy registries.  If it is set to true via the
 	// command line flag the daemon will not attempt to contact v1 legacy registries
 	V2Only bool `json:"disable-legacy-registry,omitempty"`
@@ -27,6 +33,10 @@ type ServiceOptions struct {
 type serviceConfig struct {
 	registrytypes.ServiceConfig
 	V2Only bool
+
+	// mirrorsByHost holds the validated mirrors of the registries which
+	// are not configured in IndexConfigs.
+	mirrorsByHost map[string][]string
 }
 
 var (
@@ -97,7 +107,8 @@ func newServiceConfig(options ServiceOptions) *serviceConfig {
 			// and Mirrors are only for the official registry anyways.
 			Mirrors: options.Mirrors,
 		},
-		V2Only: options.V2Only,
+		V2Only:        options.V2Only,
+		mirrorsByHost: make(map[string][]string),
 	}
 	// Split --insecure-registry into CIDR and registry-specific settings.
 	for _, r := range options.InsecureRegistries {
@@ -117,6 +128,24 @@ func newServiceConfig(options ServiceOptions) *serviceConfig {
 		}
 	}
 
+	// Configure the mirrors of private registries. Mirrors configured for
+	// the official index are added to the --registry-mirror ones.
+	mirrorsByHost, err := ValidateMirrorsByHost(options.MirrorsByHost)
+	if err != nil {
+		logrus.Warnf("Ignoring invalid registry mirrors: %v", err)
+	}
+	for host, mirrors := range mirrorsByHost {
+		if host == IndexName {
+			config.Mirrors = append(config.Mirrors, mirrors...)
+			continue
+		}
+		if index, ok := config.IndexConfigs[host]; ok {
+			index.Mirrors = mirrors
+			continue
+		}
+		config.mirrorsByHost[host] = mirrors
+	}
+
 	// Configure public registry.
 	config.IndexConfigs[IndexName] = &registrytypes.IndexInfo{
 		Name:     IndexName,
@@ -128,6 +157,18 @@ func newServiceConfig(options ServiceOptions) *serviceConfig {
 	return config
 }
 
+// mirrorsForHost returns the mirrors configured for the registry at
+// hostname, in order of preference.
+func (config *serviceConfig) mirrorsForHost(hostname string) []string {
+	if hostname == DefaultNamespace || hostname == DefaultV1Registry.Host {
+		return config.Mirrors
+	}
+	if index, ok := config.IndexConfigs[hostname]; ok {
+		return index.Mirrors
+	}
+	return config.mirrorsByHost[hostname]
+}
+
 // isSecureIndex returns false if the provided indexName is part of the list of insecure registries
 // Insecure registries accept HTTP and/or accept HTTPS with certificates from unknown CAs.
 //
@@ -195,6 +236,63 @@ func ValidateMirror(val string) (string, error) {
 	return fmt.Sprintf("%s://%s/", uri.Scheme, uri.Host), nil
 }
 
+// ValidateMirrorsByHost validates the hostnames and mirrors of per-registry
+// mirror configuration. It returns the configuration with normalized
+// hostnames and mirror URIs.
+func ValidateMirrorsByHost(mirrorsByHost map[string][]string) (map[string][]string, error) {
+	validated := make(map[string][]string, len(mirrorsByHost))
+	for host, mirrors := range mirrorsByHost {
+		indexName, err := ValidateIndexName(host)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(indexName, "://") || strings.Contains(indexName, "/") {
+			return nil, fmt.Errorf("Invalid registry host (%s). It must be a hostname with an optional port.", host)
+		}
+		for _, mirror := range mirrors {
+			mirror, err := ValidateMirror(mirror)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mirror for %s: %v", host, err)
+			}
+			validated[indexName] = append(validated[indexName], mirror)
+		}
+	}
+	return validated, nil
+}
+
+// ValidateOptions validates the mirrors and the insecure registries of
+// options the way the command line options are validated. It returns the
+// options with normalized mirror URIs and registry names.
+func ValidateOptions(options ServiceOptions) (ServiceOptions, error) {
+	validated := options
+
+	validated.Mirrors = make([]string, 0, len(options.Mirrors))
+	for _, mirror := range options.Mirrors {
+		mirror, err := ValidateMirror(mirror)
+		if err != nil {
+			return ServiceOptions{}, err
+		}
+		validated.Mirrors = append(validated.Mirrors, mirror)
+	}
+
+	validated.InsecureRegistries = make([]string, 0, len(options.InsecureRegistries))
+	for _, r := range options.InsecureRegistries {
+		r, err := ValidateIndexName(r)
+		if err != nil {
+			return ServiceOptions{}, err
+		}
+		validated.InsecureRegistries = append(validated.InsecureRegistries, r)
+	}
+
+	mirrorsByHost, err := ValidateMirrorsByHost(options.MirrorsByHost)
+	if err != nil {
+		return ServiceOptions{}, err
+	}
+	validated.MirrorsByHost = mirrorsByHost
+
+	return validated, nil
+}
+
 // ValidateIndexName validates an index name.
 func ValidateIndexName(val string) (string, error) {
 	if val == reference.LegacyDefaultHostname {
@@ -233,6 +331,9 @@ func newIndexInfo(config *serviceConfig, indexName string) (*registrytypes.Index
 		Mirrors:  make([]string, 0),
 		Official: false,
 	}
+	if mirrors, ok := config.mirrorsByHost[indexName]; ok {
+		index.Mirrors = mirrors
+	}
 	index.Secure = isSecureIndex(config, indexName)
 	return index, nil
 }
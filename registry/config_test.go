@@ -47,3 +47,30 @@ func TestValidateMirror(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateOptions(t *testing.T) {
+	options, err := ValidateOptions(ServiceOptions{
+		Mirrors:            []string{"https://mirror-1.com"},
+		InsecureRegistries: []string{"index.docker.io", "10.0.0.0/8"},
+		V2Only:             true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(options.Mirrors) != 1 || options.Mirrors[0] != "https://mirror-1.com/" {
+		t.Fatalf("Expected the mirror to be normalized, got %v", options.Mirrors)
+	}
+	if len(options.InsecureRegistries) != 2 || options.InsecureRegistries[0] != "docker.io" {
+		t.Fatalf("Expected the insecure registry to be normalized, got %v", options.InsecureRegistries)
+	}
+	if !options.V2Only {
+		t.Fatal("Expected the other options to be kept")
+	}
+
+	if _, err := ValidateOptions(ServiceOptions{Mirrors: []string{"ftp://mirror-1.com"}}); err == nil {
+		t.Fatal("Expected an error for an invalid mirror")
+	}
+	if _, err := ValidateOptions(ServiceOptions{InsecureRegistries: []string{"-invalid-"}}); err == nil {
+		t.Fatal("Expected an error for an invalid insecure registry")
+	}
+}
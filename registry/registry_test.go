@@ -684,6 +684,94 @@ func TestMirrorEndpointLookup(t *testing.T) {
 	}
 }
 
+func TestLoadOptions(t *testing.T) {
+	s := NewService(ServiceOptions{})
+	if len(s.ServiceConfig().Mirrors) != 0 {
+		t.Fatalf("Expected no mirrors, got %v", s.ServiceConfig().Mirrors)
+	}
+	if s.ServiceConfig().IndexConfigs["my.registry"] != nil {
+		t.Fatal("Expected my.registry not to be configured")
+	}
+
+	s.LoadOptions(ServiceOptions{
+		Mirrors:            []string{"https://my.mirror/"},
+		InsecureRegistries: []string{"my.registry"},
+	})
+
+	pullAPIEndpoints, err := s.LookupPullEndpoints(IndexName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pullAPIEndpoints) == 0 || pullAPIEndpoints[0].URL.Host != "my.mirror" {
+		t.Fatalf("Expected the reloaded mirror to be used: %v", pullAPIEndpoints)
+	}
+	index, err := s.ResolveIndex("my.registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index.Secure {
+		t.Fatal("Expected my.registry to be insecure")
+	}
+}
+
+func TestMirrorsByHostEndpointLookup(t *testing.T) {
+	s := NewService(ServiceOptions{
+		V2Only: true,
+		MirrorsByHost: map[string][]string{
+			"quay.io":         {"https://quay-mirror1.example.com", "http://quay-mirror2.example.com:5000"},
+			"index.docker.io": {"https://hub-mirror.example.com"},
+		},
+	})
+
+	pullAPIEndpoints, err := s.LookupPullEndpoints("quay.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"quay-mirror1.example.com", "quay-mirror2.example.com:5000", "quay.io"}
+	if len(pullAPIEndpoints) != len(expected) {
+		t.Fatalf("Expected %d pull endpoints, got %d", len(expected), len(pullAPIEndpoints))
+	}
+	for i, host := range expected {
+		if pullAPIEndpoints[i].URL.Host != host {
+			t.Fatalf("Expected pull endpoint %d to be %s, got %s", i, host, pullAPIEndpoints[i].URL.Host)
+		}
+		if mirror := i < 2; pullAPIEndpoints[i].Mirror != mirror {
+			t.Fatalf("Expected pull endpoint %s mirror to be %v", host, mirror)
+		}
+	}
+
+	pushAPIEndpoints, err := s.LookupPushEndpoints("quay.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pushAPIEndpoints) != 1 || pushAPIEndpoints[0].URL.Host != "quay.io" {
+		t.Fatalf("Push endpoints should not contain mirrors: %v", pushAPIEndpoints)
+	}
+
+	pullAPIEndpoints, err = s.LookupPullEndpoints(IndexName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pullAPIEndpoints) != 2 || pullAPIEndpoints[0].URL.Host != "hub-mirror.example.com" {
+		t.Fatalf("Expected the official index mirror to be used: %v", pullAPIEndpoints)
+	}
+
+	index, err := s.ResolveIndex("quay.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Mirrors) != 2 {
+		t.Fatalf("Expected 2 mirrors for quay.io, got %v", index.Mirrors)
+	}
+
+	if _, err := ValidateMirrorsByHost(map[string][]string{"quay.io": {"ftp://mirror"}}); err == nil {
+		t.Fatal("Expected an error for an invalid mirror")
+	}
+	if _, err := ValidateMirrorsByHost(map[string][]string{"https://quay.io": {"https://mirror"}}); err == nil {
+		t.Fatal("Expected an error for an invalid registry host")
+	}
+}
+
 func TestPushRegistryTag(t *testing.T) {
 	r := spawnTestRegistrySession(t)
 	repoRef, err := reference.ParseNamed(REPO)
@@ -22,6 +22,7 @@ var ErrRestartCanceled = errors.New("restart canceled")
 type RestartManager interface {
 	Cancel() error
 	ShouldRestart(exitCode uint32, hasBeenManuallyStopped bool, executionDuration time.Duration) (bool, chan error, error)
+	SetUnhealthyKill()
 }
 
 type restartManager struct {
@@ -33,6 +34,9 @@ type restartManager struct {
 	active       bool
 	cancel       chan struct{}
 	canceled     bool
+	// unhealthyKill is set when the container is killed for failing its
+	// health check, until the next call to ShouldRestart.
+	unhealthyKill bool
 }
 
 // New returns a new restartmanager based on a policy.
@@ -46,6 +50,14 @@ func (rm *restartManager) SetPolicy(policy container.RestartPolicy) {
 	rm.Unlock()
 }
 
+// SetUnhealthyKill records that the container is being killed because it is
+// unhealthy, which is the only exit the on-unhealthy policy restarts it for.
+func (rm *restartManager) SetUnhealthyKill() {
+	rm.Lock()
+	rm.unhealthyKill = true
+	rm.Unlock()
+}
+
 func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped bool, executionDuration time.Duration) (bool, chan error, error) {
 	if rm.policy.IsNone() {
 		return false, nil, nil
@@ -65,6 +77,8 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	if rm.active {
 		return false, nil, fmt.Errorf("invalid call on active restartmanager")
 	}
+	unhealthyKill := rm.unhealthyKill
+	rm.unhealthyKill = false
 	// if the container ran for more than 10s, regardless of status and policy reset the
 	// the timeout back to the default.
 	if executionDuration.Seconds() >= 10 {
@@ -87,6 +101,10 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 		if max := rm.policy.MaximumRetryCount; max == 0 || rm.restartCount < max {
 			restart = exitCode != 0
 		}
+	case rm.policy.IsOnUnhealthy():
+		if max := rm.policy.MaximumRetryCount; max == 0 || rm.restartCount < max {
+			restart = unhealthyKill
+		}
 	}
 
 	if !restart {
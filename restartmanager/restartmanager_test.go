@@ -32,3 +32,41 @@ func TestRestartManagerTimeoutReset(t *testing.T) {
 		t.Fatalf("restart manager should have a timeout of 100ms but has %s", rm.timeout)
 	}
 }
+
+func TestRestartManagerOnUnhealthy(t *testing.T) {
+	rm := New(container.RestartPolicy{Name: "on-unhealthy", MaximumRetryCount: 1}, 0).(*restartManager)
+	should, _, err := rm.ShouldRestart(0, false, 1*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if should {
+		t.Fatal("container should not be restarted after a successful exit")
+	}
+
+	should, _, err = rm.ShouldRestart(1, false, 1*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if should {
+		t.Fatal("container should not be restarted after a failure which is not a health kill")
+	}
+
+	rm.SetUnhealthyKill()
+	should, wait, err := rm.ShouldRestart(137, false, 1*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !should {
+		t.Fatal("container should be restarted after being killed for being unhealthy")
+	}
+	<-wait
+
+	rm.SetUnhealthyKill()
+	should, _, err = rm.ShouldRestart(137, false, 1*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if should {
+		t.Fatal("container should not be restarted more than the maximum retry count")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"path"
 	"strconv"
 	"strings"
@@ -99,6 +101,8 @@ type ContainerOptions struct {
 	flShmSize           *string
 	flNoHealthcheck     *bool
 	flHealthCmd         *string
+	flHealthHTTP        *string
+	flHealthTCP         *string
 	flHealthInterval    *time.Duration
 	flHealthTimeout     *time.Duration
 	flHealthRetries     *int
@@ -189,6 +193,8 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 		flShmSize:           flags.String("shm-size", "", "Size of /dev/shm, default value is 64MB"),
 		flNoHealthcheck:     flags.Bool("no-healthcheck", false, "Disable any container-specified HEALTHCHECK"),
 		flHealthCmd:         flags.String("health-cmd", "", "Command to run to check health"),
+		flHealthHTTP:        flags.String("health-http", "", "URL to send a GET request to to check health"),
+		flHealthTCP:         flags.String("health-tcp", "", "Address to connect to to check health"),
 		flHealthInterval:    flags.Duration("health-interval", 0, "Time between running the check"),
 		flHealthTimeout:     flags.Duration("health-timeout", 0, "Maximum time to allow one check to run"),
 		flHealthRetries:     flags.Int("health-retries", 0, "Consecutive failures needed to report unhealthy"),
@@ -447,6 +453,8 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 	// Healthcheck
 	var healthConfig *container.HealthConfig
 	haveHealthSettings := *copts.flHealthCmd != "" ||
+		*copts.flHealthHTTP != "" ||
+		*copts.flHealthTCP != "" ||
 		*copts.flHealthInterval != 0 ||
 		*copts.flHealthTimeout != 0 ||
 		*copts.flHealthRetries != 0
@@ -458,9 +466,28 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		healthConfig = &container.HealthConfig{Test: test}
 	} else if haveHealthSettings {
 		var probe strslice.StrSlice
+		probes := 0
 		if *copts.flHealthCmd != "" {
 			args := []string{"CMD-SHELL", *copts.flHealthCmd}
 			probe = strslice.StrSlice(args)
+			probes++
+		}
+		if *copts.flHealthHTTP != "" {
+			if err := ValidateHealthHTTP(*copts.flHealthHTTP); err != nil {
+				return nil, nil, nil, err
+			}
+			probe = strslice.StrSlice{"HTTP", *copts.flHealthHTTP}
+			probes++
+		}
+		if *copts.flHealthTCP != "" {
+			if err := ValidateHealthTCP(*copts.flHealthTCP); err != nil {
+				return nil, nil, nil, err
+			}
+			probe = strslice.StrSlice{"TCP", *copts.flHealthTCP}
+			probes++
+		}
+		if probes > 1 {
+			return nil, nil, nil, fmt.Errorf("--health-cmd, --health-http and --health-tcp are mutually exclusive")
 		}
 		if *copts.flHealthInterval < 0 {
 			return nil, nil, nil, fmt.Errorf("--health-interval cannot be negative")
@@ -699,6 +726,60 @@ func parseStorageOpts(storageOpts []string) (map[string]string, error) {
 	return m, nil
 }
 
+// ValidateHealthcheck checks the targets of the HTTP and TCP health checks
+// of healthcheck.
+func ValidateHealthcheck(healthcheck *container.HealthConfig) error {
+	if healthcheck == nil || len(healthcheck.Test) != 2 {
+		return nil
+	}
+	switch healthcheck.Test[0] {
+	case "HTTP":
+		return ValidateHealthHTTP(healthcheck.Test[1])
+	case "TCP":
+		return ValidateHealthTCP(healthcheck.Test[1])
+	}
+	return nil
+}
+
+// ValidateHealthHTTP checks that the URL of an HTTP health check is an
+// absolute http or https URL whose host is an IP address or localhost. The
+// check is run from the network namespace of the container, where the daemon
+// cannot resolve names.
+func ValidateHealthHTTP(rawurl string) error {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return fmt.Errorf("invalid health check URL: %v", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid health check URL %s: must be an absolute http or https URL", rawurl)
+	}
+	host := u.Host
+	if h, _, err := net.SplitHostPort(u.Host); err == nil {
+		host = h
+	}
+	if !isHealthHost(strings.Trim(host, "[]")) {
+		return fmt.Errorf("invalid health check URL %s: the host must be an IP address or localhost", rawurl)
+	}
+	return nil
+}
+
+// ValidateHealthTCP checks that the address of a TCP health check is a
+// host:port address whose host is an IP address or localhost.
+func ValidateHealthTCP(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid health check address: %v", err)
+	}
+	if !isHealthHost(host) {
+		return fmt.Errorf("invalid health check address %s: the host must be an IP address or localhost", address)
+	}
+	return nil
+}
+
+func isHealthHost(host string) bool {
+	return host == "localhost" || net.ParseIP(host) != nil
+}
+
 // ParseRestartPolicy returns the parsed policy or an error indicating what is incorrect
 func ParseRestartPolicy(policy string) (container.RestartPolicy, error) {
 	p := container.RestartPolicy{}
@@ -720,9 +801,9 @@ func ParseRestartPolicy(policy string) (container.RestartPolicy, error) {
 		}
 	case "no":
 		// do nothing
-	case "on-failure":
+	case "on-failure", "on-unhealthy":
 		if len(parts) > 2 {
-			return p, fmt.Errorf("restart count format is not valid, usage: 'on-failure:N' or 'on-failure'")
+			return p, fmt.Errorf("restart count format is not valid, usage: '%s:N' or '%s'", name, name)
 		}
 		if len(parts) == 2 {
 			count, err := strconv.Atoi(parts[1])
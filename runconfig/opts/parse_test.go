@@ -561,6 +561,7 @@ func TestParseRestartPolicy(t *testing.T) {
 		"always:2:3":         "maximum restart count not valid with restart policy of \"always\"",
 		"on-failure:invalid": `strconv.ParseInt: parsing "invalid": invalid syntax`,
 		"on-failure:2:5":     "restart count format is not valid, usage: 'on-failure:N' or 'on-failure'",
+		"on-unhealthy:2:5":   "restart count format is not valid, usage: 'on-unhealthy:N' or 'on-unhealthy'",
 	}
 	valids := map[string]container.RestartPolicy{
 		"": {},
@@ -572,6 +573,14 @@ func TestParseRestartPolicy(t *testing.T) {
 			Name:              "on-failure",
 			MaximumRetryCount: 1,
 		},
+		"on-unhealthy": {
+			Name:              "on-unhealthy",
+			MaximumRetryCount: 0,
+		},
+		"on-unhealthy:3": {
+			Name:              "on-unhealthy",
+			MaximumRetryCount: 3,
+		},
 	}
 	for restart, expectedError := range invalids {
 		if _, _, _, err := parseRun([]string{fmt.Sprintf("--restart=%s", restart), "img", "cmd"}); err == nil || err.Error() != expectedError {
@@ -622,6 +631,37 @@ func TestParseHealth(t *testing.T) {
 	checkError("--no-healthcheck conflicts with --health-* options",
 		"--no-healthcheck", "--health-cmd=/check.sh -q", "img", "cmd")
 
+	health = checkOk("--health-http=http://localhost:8080/health", "img", "cmd")
+	if len(health.Test) != 2 || health.Test[0] != "HTTP" || health.Test[1] != "http://localhost:8080/health" {
+		t.Fatalf("--health-http: got %#v", health.Test)
+	}
+
+	health = checkOk("--health-tcp=localhost:6379", "img", "cmd")
+	if len(health.Test) != 2 || health.Test[0] != "TCP" || health.Test[1] != "localhost:6379" {
+		t.Fatalf("--health-tcp: got %#v", health.Test)
+	}
+
+	health = checkOk("--health-http=http://[::1]:8080/health", "img", "cmd")
+	if len(health.Test) != 2 || health.Test[0] != "HTTP" || health.Test[1] != "http://[::1]:8080/health" {
+		t.Fatalf("--health-http: got %#v", health.Test)
+	}
+
+	health = checkOk("--health-tcp=10.0.0.1:6379", "img", "cmd")
+	if len(health.Test) != 2 || health.Test[0] != "TCP" || health.Test[1] != "10.0.0.1:6379" {
+		t.Fatalf("--health-tcp: got %#v", health.Test)
+	}
+
+	checkError("invalid health check URL localhost:8080: must be an absolute http or https URL",
+		"--health-http=localhost:8080", "img", "cmd")
+	checkError("invalid health check URL http://web:8080/health: the host must be an IP address or localhost",
+		"--health-http=http://web:8080/health", "img", "cmd")
+	checkError("invalid health check address: address localhost: missing port in address",
+		"--health-tcp=localhost", "img", "cmd")
+	checkError("invalid health check address redis:6379: the host must be an IP address or localhost",
+		"--health-tcp=redis:6379", "img", "cmd")
+	checkError("--health-cmd, --health-http and --health-tcp are mutually exclusive",
+		"--health-cmd=/check.sh -q", "--health-tcp=localhost:6379", "img", "cmd")
+
 	health = checkOk("--health-timeout=2s", "--health-retries=3", "--health-interval=4.5s", "img", "cmd")
 	if health.Timeout != 2*time.Second || health.Retries != 3 || health.Interval != 4500*time.Millisecond {
 		t.Fatalf("--health-*: got %#v", health)
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/pkg/idtools"
@@ -24,6 +25,7 @@ import (
 const (
 	VolumeDataPathName = "_data"
 	volumesPathName    = "volumes"
+	metadataFileName   = "metadata.json"
 )
 
 var (
@@ -43,6 +45,12 @@ func (validationError) IsValidationError() bool {
 	return true
 }
 
+// volumeMetadata is the information about a volume which is stored next to
+// its data directory.
+type volumeMetadata struct {
+	CreatedAt time.Time
+}
+
 type activeMount struct {
 	count   uint64
 	mounted bool
@@ -88,6 +96,13 @@ func New(scope string, rootUID, rootGID int) (*Root, error) {
 			path:       r.DataPath(name),
 		}
 		r.volumes[name] = v
+		if b, err := ioutil.ReadFile(filepath.Join(rootDirectory, name, metadataFileName)); err == nil {
+			var meta volumeMetadata
+			if err := json.Unmarshal(b, &meta); err != nil {
+				logrus.Warnf("error reading metadata of local volume %s: %v", name, err)
+			}
+			v.createdAt = meta.CreatedAt
+		}
 		if b, err := ioutil.ReadFile(filepath.Join(name, "opts.json")); err == nil {
 			if err := json.Unmarshal(b, v.opts); err != nil {
 				return nil, err
@@ -174,6 +189,16 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 		driverName: r.Name(),
 		name:       name,
 		path:       path,
+		createdAt:  time.Now().UTC(),
+	}
+
+	var meta []byte
+	meta, err = json.Marshal(volumeMetadata{CreatedAt: v.createdAt})
+	if err != nil {
+		return nil, err
+	}
+	if err = ioutil.WriteFile(filepath.Join(filepath.Dir(path), metadataFileName), meta, 0600); err != nil {
+		return nil, err
 	}
 
 	if opts != nil {
@@ -274,6 +299,9 @@ type localVolume struct {
 	opts *optsConfig
 	// active refcounts the active mounts
 	active activeMount
+	// createdAt is the time the volume was created, it is zero for volumes
+	// created before it was recorded
+	createdAt time.Time
 }
 
 // Name returns the name of the given Volume.
@@ -291,6 +319,15 @@ func (v *localVolume) Path() string {
 	return v.path
 }
 
+// CreatedAt returns the time the volume was created, as recorded in its
+// metadata when it was created.
+func (v *localVolume) CreatedAt() (time.Time, error) {
+	if v.createdAt.IsZero() {
+		return time.Time{}, fmt.Errorf("creation time of volume %s was not recorded", v.name)
+	}
+	return v.createdAt, nil
+}
+
 // Mount implements the localVolume interface, returning the data location.
 func (v *localVolume) Mount(id string) (string, error) {
 	v.m.Lock()
@@ -3,11 +3,14 @@ package local
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/volume"
 )
 
 func TestRemove(t *testing.T) {
@@ -134,6 +137,76 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreatedAt(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := time.Now().Add(-time.Second)
+	v, err := r.Create("created", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := v.(volume.CreatedVolume).CreatedAt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Before(before) || created.After(time.Now().Add(time.Second)) {
+		t.Fatalf("Expected creation time close to %v, got %v", before, created)
+	}
+
+	// writing to the volume doesn't change its creation time, which is kept
+	// across restarts
+	if err := ioutil.WriteFile(filepath.Join(v.Path(), "data"), []byte("data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	r, err = New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err = r.Get("created")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := v.(volume.CreatedVolume).CreatedAt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.Equal(created) {
+		t.Fatalf("Expected creation time %v after restart, got %v", created, reloaded)
+	}
+}
+
+func TestCreatedAtNotRecorded(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	if err := os.MkdirAll(filepath.Join(rootDir, volumesPathName, "old", VolumeDataPathName), 0755); err != nil {
+		t.Fatal(err)
+	}
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := r.Get("old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.(volume.CreatedVolume).CreatedAt(); err == nil {
+		t.Fatal("Expected an error for a volume without a recorded creation time")
+	}
+}
+
 func TestValidateName(t *testing.T) {
 	r := &Root{}
 	names := map[string]bool{
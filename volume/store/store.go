@@ -3,6 +3,7 @@ package store
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
@@ -39,6 +40,13 @@ func (v volumeWrapper) Scope() string {
 	return v.scope
 }
 
+func (v volumeWrapper) CreatedAt() (time.Time, error) {
+	if vv, ok := v.Volume.(volume.CreatedVolume); ok {
+		return vv.CreatedAt()
+	}
+	return time.Time{}, fmt.Errorf("volume %s does not report its creation time", v.Name())
+}
+
 func (v volumeWrapper) CachedPath() string {
 	if vv, ok := v.Volume.(interface {
 		CachedPath() string
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/pkg/system"
@@ -71,6 +72,12 @@ type LabeledVolume interface {
 	Volume
 }
 
+// CreatedVolume wraps a Volume whose creation time is known
+type CreatedVolume interface {
+	CreatedAt() (time.Time, error)
+	Volume
+}
+
 // ScopedVolume wraps a volume with a cluster scope (e.g., `local` or `global`)
 type ScopedVolume interface {
 	Scope() string